@@ -0,0 +1,318 @@
+// Package bls implements BLS signatures over the BLS12-381 curve.
+//
+// This follows draft-irtf-cfrg-bls-signature-05, using the proof of possession
+// scheme, which is the one used by Ethereum. Public keys must either come with
+// a proof of possession, checked with PopVerify, or be otherwise known to belong
+// to their owner, before being used with FastAggregateVerify.
+//
+// There are two variants, depending on which group holds public keys, and which
+// holds signatures. With MinPublicKeySize, public keys are 48 byte points in G1,
+// and signatures are 96 byte points in G2. With MinSignatureSize, the roles of the
+// two groups are swapped.
+//
+// Secret keys are represented as kyokusen.Scalar values, and public keys and
+// signatures as kyokusen.Point values, which can be serialized with their
+// MarshalBinary methods.
+package bls
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/bls12381"
+	"github.com/cronokirby/saferith"
+)
+
+// Variant determines which groups hold public keys and signatures.
+type Variant struct {
+	keyCurve kyokusen.Curve
+	sigCurve kyokusen.Curve
+	// hashToSig hashes a message to the group holding signatures.
+	hashToSig func(msg, dst []byte) (kyokusen.Point, error)
+	// sigDST and popDST are the domain separation tags for signatures, and proofs of possession.
+	sigDST []byte
+	popDST []byte
+}
+
+// MinPublicKeySize places public keys in G1, and signatures in G2.
+//
+// This is the variant used by Ethereum.
+var MinPublicKeySize = &Variant{
+	keyCurve: bls12381.G1Curve{},
+	sigCurve: bls12381.G2Curve{},
+	hashToSig: func(msg, dst []byte) (kyokusen.Point, error) {
+		return bls12381.HashToG2(msg, dst)
+	},
+	sigDST: []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"),
+	popDST: []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"),
+}
+
+// MinSignatureSize places public keys in G2, and signatures in G1.
+var MinSignatureSize = &Variant{
+	keyCurve: bls12381.G2Curve{},
+	sigCurve: bls12381.G1Curve{},
+	hashToSig: func(msg, dst []byte) (kyokusen.Point, error) {
+		return bls12381.HashToG1(msg, dst)
+	},
+	sigDST: []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"),
+	popDST: []byte("BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"),
+}
+
+// MinIKMBytes is the minimum number of bytes of input keying material accepted by KeyGen.
+const MinIKMBytes = 32
+
+// keyGenL is the number of bytes produced by HKDF in KeyGen, ceil((3 * ceil(log2(r))) / 16).
+const keyGenL = 48
+
+// hkdf implements HKDF-Extract followed by HKDF-Expand, from RFC 5869, with SHA-256.
+func hkdf(salt, ikm, info []byte, length int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(ikm)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	var out, t []byte
+	for i := byte(1); len(out) < length; i++ {
+		expand.Reset()
+		expand.Write(t)
+		expand.Write(info)
+		expand.Write([]byte{i})
+		t = expand.Sum(nil)
+		out = append(out, t...)
+	}
+	return out[:length]
+}
+
+// KeyGen derives a secret key from input keying material, and optional key information.
+//
+// The keying material must contain at least 32 bytes, and must be secret, and
+// uniformly random. The same inputs always produce the same key.
+func (v *Variant) KeyGen(ikm, keyInfo []byte) (kyokusen.Scalar, error) {
+	if len(ikm) < MinIKMBytes {
+		return nil, errors.New("bls.Variant.KeyGen: input keying material is too short")
+	}
+	ikmPrime := append(append([]byte{}, ikm...), 0)
+	info := append(append([]byte{}, keyInfo...), 0, keyGenL)
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	for {
+		digest := sha256.Sum256(salt)
+		salt = digest[:]
+		okm := hkdf(salt, ikmPrime, info, keyGenL)
+		sk := v.keyCurve.NewScalar().SetNat(new(saferith.Nat).SetBytes(okm))
+		if !sk.IsZero() {
+			return sk, nil
+		}
+	}
+}
+
+// PublicKey calculates the public key associated with a secret key.
+func (v *Variant) PublicKey(sk kyokusen.Scalar) kyokusen.Point {
+	return v.keyCurve.NewScalar().Set(sk).ActOnBase()
+}
+
+// KeyValidate checks that a public key is valid.
+//
+// This means that the key must be a point in the right group, other than the identity.
+func (v *Variant) KeyValidate(pk kyokusen.Point) error {
+	if pk.Curve().Name() != v.keyCurve.Name() {
+		return errors.New("bls.Variant.KeyValidate: public key is in the wrong group")
+	}
+	if pk.IsIdentity() {
+		return errors.New("bls.Variant.KeyValidate: public key is the identity")
+	}
+	if !kyokusen.IsTorsionFree(pk) {
+		return errors.New("bls.Variant.KeyValidate: public key is not in the prime order subgroup")
+	}
+	return nil
+}
+
+// checkSignature checks that a signature is a point in the right group.
+func (v *Variant) checkSignature(sig kyokusen.Point) error {
+	if sig.Curve().Name() != v.sigCurve.Name() {
+		return errors.New("signature is in the wrong group")
+	}
+	if !kyokusen.IsTorsionFree(sig) {
+		return errors.New("signature is not in the prime order subgroup")
+	}
+	return nil
+}
+
+// UnmarshalPublicKey decodes a public key, checking that it's valid.
+func (v *Variant) UnmarshalPublicKey(data []byte) (kyokusen.Point, error) {
+	pk := v.keyCurve.NewPoint()
+	if err := pk.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if err := v.KeyValidate(pk); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+// UnmarshalSignature decodes a signature, checking that it lies in the right group.
+func (v *Variant) UnmarshalSignature(data []byte) (kyokusen.Point, error) {
+	sig := v.sigCurve.NewPoint()
+	if err := sig.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// sign multiplies the hash of a message by a secret key.
+func (v *Variant) sign(sk kyokusen.Scalar, msg, dst []byte) (kyokusen.Point, error) {
+	if sk.IsZero() {
+		return nil, errors.New("secret key is zero")
+	}
+	h, err := v.hashToSig(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return v.sigCurve.NewScalar().Set(sk).Act(h), nil
+}
+
+// pairingIsOne checks if the product of the pairings e(keys_i, sigs_i) is 1.
+//
+// Each key lies in the group holding public keys, and each sig in the group holding signatures.
+func pairingIsOne(keys, sigs []kyokusen.Point) bool {
+	ps := make([]*bls12381.G1Point, len(keys))
+	qs := make([]*bls12381.G2Point, len(keys))
+	for i := range keys {
+		switch key := keys[i].(type) {
+		case *bls12381.G1Point:
+			ps[i], qs[i] = key, sigs[i].(*bls12381.G2Point)
+		case *bls12381.G2Point:
+			ps[i], qs[i] = sigs[i].(*bls12381.G1Point), key
+		}
+	}
+	return bls12381.MultiPairing(ps, qs).IsOne()
+}
+
+// coreAggregateVerify checks that a signature is valid for a list of public keys and messages.
+//
+// This checks that e(pk_1, H(msg_1)) ... e(pk_n, H(msg_n)) = e(G, sig).
+func (v *Variant) coreAggregateVerify(pks []kyokusen.Point, msgs [][]byte, sig kyokusen.Point, dst []byte) error {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return errors.New("invalid number of public keys")
+	}
+	if err := v.checkSignature(sig); err != nil {
+		return err
+	}
+	keys := make([]kyokusen.Point, 0, len(pks)+1)
+	sigs := make([]kyokusen.Point, 0, len(pks)+1)
+	for i, pk := range pks {
+		if err := v.KeyValidate(pk); err != nil {
+			return err
+		}
+		h, err := v.hashToSig(msgs[i], dst)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, pk)
+		sigs = append(sigs, h)
+	}
+	keys = append(keys, v.keyCurve.NewBasePoint().Negate())
+	sigs = append(sigs, sig)
+	if !pairingIsOne(keys, sigs) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// Sign creates a signature of a message using a secret key.
+func (v *Variant) Sign(sk kyokusen.Scalar, msg []byte) (kyokusen.Point, error) {
+	sig, err := v.sign(sk, msg, v.sigDST)
+	if err != nil {
+		return nil, errors.New("bls.Variant.Sign: " + err.Error())
+	}
+	return sig, nil
+}
+
+// Verify checks that a signature of a message is valid under a public key.
+//
+// This returns nil if the signature is valid, and an error otherwise.
+func (v *Variant) Verify(pk kyokusen.Point, msg []byte, sig kyokusen.Point) error {
+	if err := v.coreAggregateVerify([]kyokusen.Point{pk}, [][]byte{msg}, sig, v.sigDST); err != nil {
+		return errors.New("bls.Variant.Verify: " + err.Error())
+	}
+	return nil
+}
+
+// Aggregate combines several signatures into a single signature.
+//
+// The result can be checked with AggregateVerify, or with FastAggregateVerify,
+// when all the signatures are for the same message. At least one signature is needed.
+func (v *Variant) Aggregate(sigs []kyokusen.Point) (kyokusen.Point, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("bls.Variant.Aggregate: no signatures to aggregate")
+	}
+	out := v.sigCurve.NewPoint()
+	for _, sig := range sigs {
+		if sig.Curve().Name() != v.sigCurve.Name() {
+			return nil, errors.New("bls.Variant.Aggregate: signature is in the wrong group")
+		}
+		out = out.Add(sig)
+	}
+	return out, nil
+}
+
+// AggregateVerify checks an aggregate signature, for a list of public keys, and the message signed by each key.
+//
+// Because we use the proof of possession scheme, the messages don't need to be distinct.
+func (v *Variant) AggregateVerify(pks []kyokusen.Point, msgs [][]byte, sig kyokusen.Point) error {
+	if err := v.coreAggregateVerify(pks, msgs, sig, v.sigDST); err != nil {
+		return errors.New("bls.Variant.AggregateVerify: " + err.Error())
+	}
+	return nil
+}
+
+// FastAggregateVerify checks an aggregate signature, for a list of public keys, all signing the same message.
+//
+// This is much faster than AggregateVerify, but is only secure if the caller
+// has checked a proof of possession for each public key, using PopVerify.
+func (v *Variant) FastAggregateVerify(pks []kyokusen.Point, msg []byte, sig kyokusen.Point) error {
+	if len(pks) == 0 {
+		return errors.New("bls.Variant.FastAggregateVerify: no public keys")
+	}
+	aggregate := v.keyCurve.NewPoint()
+	for _, pk := range pks {
+		if err := v.KeyValidate(pk); err != nil {
+			return errors.New("bls.Variant.FastAggregateVerify: " + err.Error())
+		}
+		aggregate = aggregate.Add(pk)
+	}
+	if err := v.coreAggregateVerify([]kyokusen.Point{aggregate}, [][]byte{msg}, sig, v.sigDST); err != nil {
+		return errors.New("bls.Variant.FastAggregateVerify: " + err.Error())
+	}
+	return nil
+}
+
+// PopProve creates a proof of possession for the public key associated with a secret key.
+//
+// This is a signature of the public key itself, using a separate domain separation tag.
+func (v *Variant) PopProve(sk kyokusen.Scalar) (kyokusen.Point, error) {
+	pkBytes, err := v.PublicKey(sk).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := v.sign(sk, pkBytes, v.popDST)
+	if err != nil {
+		return nil, errors.New("bls.Variant.PopProve: " + err.Error())
+	}
+	return proof, nil
+}
+
+// PopVerify checks a proof of possession for a public key.
+//
+// This returns nil if the proof is valid, and an error otherwise.
+func (v *Variant) PopVerify(pk kyokusen.Point, proof kyokusen.Point) error {
+	pkBytes, err := pk.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := v.coreAggregateVerify([]kyokusen.Point{pk}, [][]byte{pkBytes}, proof, v.popDST); err != nil {
+		return errors.New("bls.Variant.PopVerify: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+package bls
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+)
+
+// vectorCase is one test case, with the same layout as the Ethereum consensus BLS tests.
+type vectorCase[I, O any] struct {
+	Name   string
+	Input  I
+	Output O
+}
+
+// runGenerated runs a test against the cases of one handler from one of our generated files.
+//
+// These were produced with this implementation, and checked against blst, see testdata/README.md.
+func runGenerated[I, O any](t *testing.T, file, handler string, test func(t *testing.T, input I, output O)) {
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var handlers map[string]json.RawMessage
+	if err := json.Unmarshal(data, &handlers); err != nil {
+		t.Fatal(err)
+	}
+	var cases []vectorCase[I, O]
+	if err := json.Unmarshal(handlers[handler], &cases); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) { test(t, c.Input, c.Output) })
+	}
+}
+
+// runVectors runs a test against every case of one handler, for the minimal-pubkey-size variant.
+//
+// The cases come from our generated file, and from the unmodified Ethereum
+// consensus BLS tests, in testdata/ethereum, which must be present.
+func runVectors[I, O any](t *testing.T, handler string, test func(t *testing.T, input I, output O)) {
+	t.Run("generated", func(t *testing.T) {
+		runGenerated(t, "generated_min_pk.json", handler, test)
+	})
+	t.Run("ethereum", func(t *testing.T) {
+		files, err := filepath.Glob(filepath.Join("testdata", "ethereum", handler, "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) == 0 {
+			t.Fatalf("no %s cases in testdata/ethereum, see testdata/README.md", handler)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var c vectorCase[I, O]
+			if err := json.Unmarshal(data, &c); err != nil {
+				t.Fatalf("%s: %v", file, err)
+			}
+			t.Run(strings.TrimSuffix(filepath.Base(file), ".json"), func(t *testing.T) { test(t, c.Input, c.Output) })
+		}
+	})
+}
+
+func decodeHex(s string) []byte {
+	out, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// decodePublicKeys decodes a list of public keys, returning false if any of them is invalid.
+func decodePublicKeys(v *Variant, hexKeys []string) ([]kyokusen.Point, bool) {
+	out := make([]kyokusen.Point, len(hexKeys))
+	for i, s := range hexKeys {
+		pk, err := v.UnmarshalPublicKey(decodeHex(s))
+		if err != nil {
+			return nil, false
+		}
+		out[i] = pk
+	}
+	return out, true
+}
+
+func decodeSecretKey(v *Variant, s string) kyokusen.Scalar {
+	sk := v.keyCurve.NewScalar()
+	if err := sk.UnmarshalBinary(decodeHex(s)); err != nil {
+		panic(err)
+	}
+	return sk
+}
+
+func TestSignVectors(t *testing.T) {
+	type input struct{ Privkey, Message string }
+	runVectors(t, "sign", func(t *testing.T, in input, out *string) {
+		sk := decodeSecretKey(MinPublicKeySize, in.Privkey)
+		msg := decodeHex(in.Message)
+		sig, err := MinPublicKeySize.Sign(sk, msg)
+		if out == nil {
+			if err == nil {
+				t.Error("signing should fail")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigBytes, _ := sig.MarshalBinary()
+		if !bytes.Equal(sigBytes, decodeHex(*out)) {
+			t.Error("incorrect signature")
+		}
+		if err := MinPublicKeySize.Verify(MinPublicKeySize.PublicKey(sk), msg, sig); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestMinSignatureSizeSignVectors(t *testing.T) {
+	type input struct{ Privkey, Message string }
+	type output struct{ Pubkey, Signature string }
+	runGenerated(t, "generated_min_sig.json", "sign", func(t *testing.T, in input, out output) {
+		sk := decodeSecretKey(MinSignatureSize, in.Privkey)
+		msg := decodeHex(in.Message)
+		pkBytes, _ := MinSignatureSize.PublicKey(sk).MarshalBinary()
+		if !bytes.Equal(pkBytes, decodeHex(out.Pubkey)) {
+			t.Error("incorrect public key")
+		}
+		sig, err := MinSignatureSize.Sign(sk, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigBytes, _ := sig.MarshalBinary()
+		if !bytes.Equal(sigBytes, decodeHex(out.Signature)) {
+			t.Error("incorrect signature")
+		}
+		pk, err := MinSignatureSize.UnmarshalPublicKey(pkBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := MinSignatureSize.Verify(pk, msg, sig); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestVerifyVectors(t *testing.T) {
+	type input struct{ Pubkey, Message, Signature string }
+	runVectors(t, "verify", func(t *testing.T, in input, out bool) {
+		valid := func() bool {
+			pks, ok := decodePublicKeys(MinPublicKeySize, []string{in.Pubkey})
+			if !ok {
+				return false
+			}
+			sig, err := MinPublicKeySize.UnmarshalSignature(decodeHex(in.Signature))
+			if err != nil {
+				return false
+			}
+			return MinPublicKeySize.Verify(pks[0], decodeHex(in.Message), sig) == nil
+		}()
+		if valid != out {
+			t.Errorf("expected %v, found %v", out, valid)
+		}
+	})
+}
+
+func TestAggregateVectors(t *testing.T) {
+	runVectors(t, "aggregate", func(t *testing.T, in []string, out *string) {
+		sigs := make([]kyokusen.Point, len(in))
+		for i, s := range in {
+			sig, err := MinPublicKeySize.UnmarshalSignature(decodeHex(s))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sigs[i] = sig
+		}
+		aggregate, err := MinPublicKeySize.Aggregate(sigs)
+		if out == nil {
+			if err == nil {
+				t.Error("aggregation should fail")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		aggregateBytes, _ := aggregate.MarshalBinary()
+		if !bytes.Equal(aggregateBytes, decodeHex(*out)) {
+			t.Error("incorrect aggregate")
+		}
+	})
+}
+
+func TestFastAggregateVerifyVectors(t *testing.T) {
+	type input struct {
+		Pubkeys   []string
+		Message   string
+		Signature string
+	}
+	runVectors(t, "fast_aggregate_verify", func(t *testing.T, in input, out bool) {
+		valid := func() bool {
+			pks, ok := decodePublicKeys(MinPublicKeySize, in.Pubkeys)
+			if !ok {
+				return false
+			}
+			sig, err := MinPublicKeySize.UnmarshalSignature(decodeHex(in.Signature))
+			if err != nil {
+				return false
+			}
+			return MinPublicKeySize.FastAggregateVerify(pks, decodeHex(in.Message), sig) == nil
+		}()
+		if valid != out {
+			t.Errorf("expected %v, found %v", out, valid)
+		}
+	})
+}
+
+func TestAggregateVerifyVectors(t *testing.T) {
+	type input struct {
+		Pubkeys   []string
+		Messages  []string
+		Signature string
+	}
+	runVectors(t, "aggregate_verify", func(t *testing.T, in input, out bool) {
+		valid := func() bool {
+			pks, ok := decodePublicKeys(MinPublicKeySize, in.Pubkeys)
+			if !ok {
+				return false
+			}
+			msgs := make([][]byte, len(in.Messages))
+			for i, m := range in.Messages {
+				msgs[i] = decodeHex(m)
+			}
+			sig, err := MinPublicKeySize.UnmarshalSignature(decodeHex(in.Signature))
+			if err != nil {
+				return false
+			}
+			return MinPublicKeySize.AggregateVerify(pks, msgs, sig) == nil
+		}()
+		if valid != out {
+			t.Errorf("expected %v, found %v", out, valid)
+		}
+	})
+}
+
+var variants = map[string]*Variant{
+	"MinPublicKeySize": MinPublicKeySize,
+	"MinSignatureSize": MinSignatureSize,
+}
+
+// testKeys deterministically generates several secret keys.
+func testKeys(t *testing.T, v *Variant, n int) []kyokusen.Scalar {
+	out := make([]kyokusen.Scalar, n)
+	for i := range out {
+		ikm := bytes.Repeat([]byte{byte(i)}, MinIKMBytes)
+		sk, err := v.KeyGen(ikm, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[i] = sk
+	}
+	return out
+}
+
+func TestKeyGen(t *testing.T) {
+	for name, v := range variants {
+		if _, err := v.KeyGen(make([]byte, MinIKMBytes-1), nil); err == nil {
+			t.Errorf("%s: short keying material should be rejected", name)
+		}
+		ikm := bytes.Repeat([]byte{1}, MinIKMBytes)
+		sk1, _ := v.KeyGen(ikm, nil)
+		sk2, _ := v.KeyGen(ikm, nil)
+		sk3, _ := v.KeyGen(ikm, []byte("info"))
+		if !sk1.Equal(sk2) {
+			t.Errorf("%s: key generation should be deterministic", name)
+		}
+		if sk1.Equal(sk3) {
+			t.Errorf("%s: key information should change the key", name)
+		}
+		if sk1.Curve().Name() != v.keyCurve.Name() {
+			t.Errorf("%s: secret key should be associated with the group of public keys", name)
+		}
+	}
+}
+
+func TestSignVerifyRoundtrip(t *testing.T) {
+	for name, v := range variants {
+		sk := testKeys(t, v, 1)[0]
+		pk := v.PublicKey(sk)
+		err := quick.Check(func(msg []byte) bool {
+			sig, err := v.Sign(sk, msg)
+			if err != nil {
+				return false
+			}
+			data, _ := sig.MarshalBinary()
+			decoded, err := v.UnmarshalSignature(data)
+			if err != nil {
+				return false
+			}
+			return v.Verify(pk, msg, decoded) == nil && v.Verify(pk, append(msg, 0), decoded) != nil
+		}, &quick.Config{MaxCount: 5})
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestAggregation(t *testing.T) {
+	for name, v := range variants {
+		sks := testKeys(t, v, 3)
+		pks := make([]kyokusen.Point, len(sks))
+		msgs := make([][]byte, len(sks))
+		sameMsgSigs := make([]kyokusen.Point, len(sks))
+		sigs := make([]kyokusen.Point, len(sks))
+		for i, sk := range sks {
+			pks[i] = v.PublicKey(sk)
+			msgs[i] = []byte{byte(i)}
+			sameMsgSigs[i], _ = v.Sign(sk, []byte("same"))
+			sigs[i], _ = v.Sign(sk, msgs[i])
+		}
+		sameMsgAggregate, _ := v.Aggregate(sameMsgSigs)
+		if err := v.FastAggregateVerify(pks, []byte("same"), sameMsgAggregate); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if v.FastAggregateVerify(pks[:2], []byte("same"), sameMsgAggregate) == nil {
+			t.Errorf("%s: missing public key should be detected", name)
+		}
+		aggregate, _ := v.Aggregate(sigs)
+		if err := v.AggregateVerify(pks, msgs, aggregate); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		if v.AggregateVerify(pks, msgs, aggregate) == nil {
+			t.Errorf("%s: swapped messages should be detected", name)
+		}
+	}
+}
+
+func TestProofOfPossession(t *testing.T) {
+	for name, v := range variants {
+		sks := testKeys(t, v, 2)
+		proof, err := v.PopProve(sks[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := v.PopVerify(v.PublicKey(sks[0]), proof); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if v.PopVerify(v.PublicKey(sks[1]), proof) == nil {
+			t.Errorf("%s: proof for a different key should be rejected", name)
+		}
+		// A proof of possession must not be usable as a signature of the public key.
+		pkBytes, _ := v.PublicKey(sks[0]).MarshalBinary()
+		if v.Verify(v.PublicKey(sks[0]), pkBytes, proof) == nil {
+			t.Errorf("%s: proof should not be a valid signature", name)
+		}
+	}
+}
+
+func TestWrongGroupsAreRejected(t *testing.T) {
+	sk := testKeys(t, MinPublicKeySize, 1)[0]
+	sig, _ := MinPublicKeySize.Sign(sk, nil)
+	// Using keys and signatures from the other variant swaps their groups.
+	if MinSignatureSize.Verify(MinPublicKeySize.PublicKey(sk), nil, sig) == nil {
+		t.Error("keys and signatures from the wrong groups should be rejected")
+	}
+	if _, err := MinSignatureSize.Aggregate([]kyokusen.Point{sig}); err == nil {
+		t.Error("signatures from the wrong group should not be aggregated")
+	}
+	if MinPublicKeySize.KeyValidate(MinPublicKeySize.keyCurve.NewPoint()) == nil {
+		t.Error("the identity should not be a valid public key")
+	}
+}
@@ -0,0 +1,5 @@
+module blstcheck
+
+go 1.20
+
+require github.com/supranational/blst v0.3.16
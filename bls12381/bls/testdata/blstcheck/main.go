@@ -0,0 +1,244 @@
+// Command blstcheck checks the generated BLS test vectors against blst.
+//
+// Run it from this directory, with a C compiler available for cgo:
+//
+//	go run . ../generated_min_pk.json ../generated_min_sig.json
+//
+// This recomputes the output of every case with blst, and reports any mismatch.
+// It lives in its own module, so that the main module doesn't depend on blst.
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+var (
+	minPkDST  = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	minSigDST = []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+type vectorCase struct {
+	Name   string
+	Input  json.RawMessage
+	Output json.RawMessage
+}
+
+func decodeHex(s string) []byte {
+	out, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func encodeHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// secretKey decodes a secret key, returning nil if it's zero, or out of range.
+func secretKey(s string) *blst.SecretKey {
+	data := decodeHex(s)
+	if bytes.Equal(data, make([]byte, len(data))) {
+		return nil
+	}
+	return new(blst.SecretKey).Deserialize(data)
+}
+
+// publicKeys decodes and validates a list of public keys, returning false if any of them is invalid.
+func publicKeys(hexKeys []string) ([]*blst.P1Affine, bool) {
+	out := make([]*blst.P1Affine, len(hexKeys))
+	for i, s := range hexKeys {
+		pk := new(blst.P1Affine).Uncompress(decodeHex(s))
+		if pk == nil || !pk.KeyValidate() {
+			return nil, false
+		}
+		out[i] = pk
+	}
+	return out, true
+}
+
+func signature(s string) *blst.P2Affine {
+	return new(blst.P2Affine).Uncompress(decodeHex(s))
+}
+
+func sign(input json.RawMessage) (interface{}, error) {
+	var in struct{ Privkey, Message string }
+	if err := json.Unmarshal(input, &in); err != nil {
+		return nil, err
+	}
+	sk := secretKey(in.Privkey)
+	if sk == nil {
+		return nil, nil
+	}
+	return encodeHex(new(blst.P2Affine).Sign(sk, decodeHex(in.Message), minPkDST).Compress()), nil
+}
+
+func verify(input json.RawMessage) (interface{}, error) {
+	var in struct{ Pubkey, Message, Signature string }
+	if err := json.Unmarshal(input, &in); err != nil {
+		return nil, err
+	}
+	pks, ok := publicKeys([]string{in.Pubkey})
+	sig := signature(in.Signature)
+	if !ok || sig == nil {
+		return false, nil
+	}
+	return sig.Verify(true, pks[0], false, decodeHex(in.Message), minPkDST), nil
+}
+
+func aggregate(input json.RawMessage) (interface{}, error) {
+	var in []string
+	if err := json.Unmarshal(input, &in); err != nil {
+		return nil, err
+	}
+	if len(in) == 0 {
+		return nil, nil
+	}
+	sigs := make([][]byte, len(in))
+	for i, s := range in {
+		sigs[i] = decodeHex(s)
+	}
+	agg := new(blst.P2Aggregate)
+	if !agg.AggregateCompressed(sigs, true) {
+		return nil, nil
+	}
+	return encodeHex(agg.ToAffine().Compress()), nil
+}
+
+func fastAggregateVerify(input json.RawMessage) (interface{}, error) {
+	var in struct {
+		Pubkeys            []string
+		Message, Signature string
+	}
+	if err := json.Unmarshal(input, &in); err != nil {
+		return nil, err
+	}
+	pks, ok := publicKeys(in.Pubkeys)
+	sig := signature(in.Signature)
+	if !ok || sig == nil || len(pks) == 0 {
+		return false, nil
+	}
+	return sig.FastAggregateVerify(true, pks, decodeHex(in.Message), minPkDST), nil
+}
+
+func aggregateVerify(input json.RawMessage) (interface{}, error) {
+	var in struct {
+		Pubkeys, Messages []string
+		Signature         string
+	}
+	if err := json.Unmarshal(input, &in); err != nil {
+		return nil, err
+	}
+	pks, ok := publicKeys(in.Pubkeys)
+	sig := signature(in.Signature)
+	if !ok || sig == nil || len(pks) == 0 || len(pks) != len(in.Messages) {
+		return false, nil
+	}
+	msgs := make([]blst.Message, len(in.Messages))
+	for i, m := range in.Messages {
+		msgs[i] = decodeHex(m)
+	}
+	return sig.AggregateVerify(true, pks, false, msgs, minPkDST), nil
+}
+
+func minSigSign(input json.RawMessage) (interface{}, error) {
+	var in struct{ Privkey, Message string }
+	if err := json.Unmarshal(input, &in); err != nil {
+		return nil, err
+	}
+	sk := secretKey(in.Privkey)
+	if sk == nil {
+		return nil, nil
+	}
+	return map[string]string{
+		"pubkey":    encodeHex(new(blst.P2Affine).From(sk).Compress()),
+		"signature": encodeHex(new(blst.P1Affine).Sign(sk, decodeHex(in.Message), minSigDST).Compress()),
+	}, nil
+}
+
+// handlers maps the name of each handler, in each file, to the function recomputing its output.
+var handlers = map[string]map[string]func(json.RawMessage) (interface{}, error){
+	"generated_min_pk.json": {
+		"sign":                  sign,
+		"verify":                verify,
+		"aggregate":             aggregate,
+		"fast_aggregate_verify": fastAggregateVerify,
+		"aggregate_verify":      aggregateVerify,
+	},
+	"generated_min_sig.json": {
+		"sign": minSigSign,
+	},
+}
+
+// sameJSON checks if two JSON values are equal, ignoring formatting.
+func sameJSON(a json.RawMessage, b interface{}) (bool, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(a, &decoded); err != nil {
+		return false, err
+	}
+	encodedA, err := json.Marshal(decoded)
+	if err != nil {
+		return false, err
+	}
+	encodedB, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(encodedA, encodedB), nil
+}
+
+func checkFile(path string) (int, error) {
+	name := path[strings.LastIndex(path, "/")+1:]
+	fileHandlers, ok := handlers[name]
+	if !ok {
+		return 0, fmt.Errorf("%s: unknown file", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var cases map[string][]vectorCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return 0, err
+	}
+	count := 0
+	for handler, cs := range cases {
+		recompute, ok := fileHandlers[handler]
+		if !ok {
+			return 0, fmt.Errorf("%s: unknown handler %s", path, handler)
+		}
+		for _, c := range cs {
+			expected, err := recompute(c.Input)
+			if err != nil {
+				return 0, fmt.Errorf("%s: %w", c.Name, err)
+			}
+			same, err := sameJSON(c.Output, expected)
+			if err != nil {
+				return 0, fmt.Errorf("%s: %w", c.Name, err)
+			}
+			if !same {
+				return 0, fmt.Errorf("%s: blst disagrees, giving %v", c.Name, expected)
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+func main() {
+	for _, path := range os.Args[1:] {
+		count, err := checkFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: %d cases match blst\n", path, count)
+	}
+}
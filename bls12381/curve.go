@@ -0,0 +1,95 @@
+// Package bls12381 implements the BLS12-381 pairing friendly curve.
+//
+// This provides two groups, G1 and G2, each implementing kyokusen.Curve, and
+// sharing the same Scalar type. G1 is a subgroup of a curve over the base field,
+// and G2 is a subgroup of a twist of that curve, over a quadratic extension.
+// Both have the same prime order r, and a bilinear pairing G1 x G2 -> GT is
+// provided by Pairing and MultiPairing.
+//
+// Points are encoded following the ZCash serialization format. Decoding points
+// checks that they lie in the prime order subgroup.
+//
+// Messages can be hashed to either group with HashToG1 and HashToG2, which
+// implement the suites for BLS12-381 from RFC 9380.
+package bls12381
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// G1Curve represents the group G1, implementing the kyokusen.Curve interface.
+type G1Curve struct{}
+
+func (G1Curve) NewPoint() kyokusen.Point {
+	return NewG1Point()
+}
+
+func (G1Curve) NewBasePoint() kyokusen.Point {
+	return NewG1BasePoint()
+}
+
+func (G1Curve) NewScalar() kyokusen.Scalar {
+	return NewScalar()
+}
+
+func (G1Curve) Name() string {
+	return "BLS12-381 G1"
+}
+
+func (G1Curve) ScalarBits() int {
+	return 255
+}
+
+func (G1Curve) SafeScalarBytes() int {
+	return 64
+}
+
+func (G1Curve) Order() *saferith.Modulus {
+	return r
+}
+
+// Cofactor returns the cofactor of the curve containing G1.
+//
+// This implements the kyokusen.CofactorCurve interface.
+func (G1Curve) Cofactor() *saferith.Nat {
+	return new(saferith.Nat).SetBytes(g1Cofactor)
+}
+
+// G2Curve represents the group G2, implementing the kyokusen.Curve interface.
+type G2Curve struct{}
+
+func (G2Curve) NewPoint() kyokusen.Point {
+	return NewG2Point()
+}
+
+func (G2Curve) NewBasePoint() kyokusen.Point {
+	return NewG2BasePoint()
+}
+
+func (G2Curve) NewScalar() kyokusen.Scalar {
+	return newG2Scalar()
+}
+
+func (G2Curve) Name() string {
+	return "BLS12-381 G2"
+}
+
+func (G2Curve) ScalarBits() int {
+	return 255
+}
+
+func (G2Curve) SafeScalarBytes() int {
+	return 64
+}
+
+func (G2Curve) Order() *saferith.Modulus {
+	return r
+}
+
+// Cofactor returns the cofactor of the twisted curve containing G2.
+//
+// This implements the kyokusen.CofactorCurve interface.
+func (G2Curve) Cofactor() *saferith.Nat {
+	return new(saferith.Nat).SetBytes(g2Cofactor)
+}
@@ -0,0 +1,15 @@
+package bls12381
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/kyokusentest"
+)
+
+func TestG1Conformance(t *testing.T) {
+	kyokusentest.TestCurve(t, G1Curve{})
+}
+
+func TestG2Conformance(t *testing.T) {
+	kyokusentest.TestCurve(t, G2Curve{})
+}
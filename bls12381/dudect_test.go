@@ -0,0 +1,20 @@
+//go:build dudect
+
+package bls12381
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/internal/dudect"
+)
+
+// These check that operations on secret values don't leak timing information:
+//
+//	go test -tags dudect -run ConstantTime -v ./bls12381
+func TestG1ConstantTime(t *testing.T) {
+	dudect.TestCurve(t, G1Curve{})
+}
+
+func TestG2ConstantTime(t *testing.T) {
+	dudect.TestCurve(t, G2Curve{})
+}
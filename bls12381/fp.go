@@ -0,0 +1,301 @@
+package bls12381
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/cronokirby/kyokusen/internal/limbs"
+	"github.com/cronokirby/saferith"
+)
+
+// This file implements arithmetic modulo the 381 bit prime p, which the curve is defined over.
+//
+// Field elements are represented as 6 64 bit limbs, in little endian order,
+// in Montgomery form: we store x * 2^384 mod p, instead of x.
+
+// fpBytes is the number of bytes in the encoding of a field element.
+const fpBytes = 48
+
+// pLimbs is the prime p, in little endian order.
+var pLimbs = [6]uint64{0xB9FEFFFFFFFFAAAB, 0x1EABFFFEB153FFFF, 0x6730D2A0F6B0F624, 0x64774B84F38512BF, 0x4B1BA7B6434BACD7, 0x1A0111EA397FE69A}
+
+// pInv is -p^-1 mod 2^64, used in Montgomery reduction.
+const pInv = 0x89F3FFFCFFFCFFFD
+
+// rSquared is 2^768 mod p, which converts numbers into Montgomery form.
+var rSquared = fp{0xF4DF1F341C341746, 0x0A76E6A609D104F1, 0x8DE5476C4C95B6D5, 0x67EB88A9939D83C0, 0x9A793E85B519952D, 0x11988FE592CAE3AA}
+
+// fpOne is 1, in Montgomery form.
+var fpOne = fp{0x760900000002FFFD, 0xEBF4000BC40C0002, 0x5F48985753C758BA, 0x77CE585370525745, 0x5C071A97A256EC6D, 0x15F65EC3FA80E493}
+
+// pMinusOneHalf is (p - 1) / 2, not in Montgomery form.
+var pMinusOneHalf = [6]uint64{0xDCFF7FFFFFFFD555, 0x0F55FFFF58A9FFFF, 0xB39869507B587B12, 0xB23BA5C279C2895F, 0x258DD3DB21A5D66B, 0x0D0088F51CBFF34D}
+
+// fp represents an element of the base field.
+type fp [6]uint64
+
+// condSubtract sets z <- l mod p, assuming that l < 2p, in constant-time.
+func (z *fp) condSubtract(l [6]uint64) *fp {
+	var reduced [6]uint64
+	var borrow uint64
+	for i := range reduced {
+		reduced[i], borrow = bits.Sub64(l[i], pLimbs[i], borrow)
+	}
+	// If we borrowed, then l < p, and we keep it.
+	m := limbs.Mask(saferith.Choice(borrow))
+	for i := range z {
+		z[i] = reduced[i] ^ (m & (reduced[i] ^ l[i]))
+	}
+	return z
+}
+
+// Set sets z <- x, returning z.
+func (z *fp) Set(x *fp) *fp {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z <- x, returning z.
+func (z *fp) SetUint64(x uint64) *fp {
+	*z = fp{x}
+	return z.Mul(&rSquared)
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *fp) CondAssign(yes saferith.Choice, x *fp) *fp {
+	m := limbs.Mask(yes)
+	for i := range z {
+		z[i] ^= m & (z[i] ^ x[i])
+	}
+	return z
+}
+
+// Add sets z <- z + a, returning z.
+func (z *fp) Add(a *fp) *fp {
+	var l [6]uint64
+	var carry uint64
+	for i := range l {
+		l[i], carry = bits.Add64(z[i], a[i], carry)
+	}
+	// Since p < 2^383, there's never a carry out of the top limb.
+	return z.condSubtract(l)
+}
+
+// Double sets z <- 2 * z, returning z.
+func (z *fp) Double() *fp {
+	return z.Add(z)
+}
+
+// Sub sets z <- z - a, returning z.
+func (z *fp) Sub(a *fp) *fp {
+	var borrow uint64
+	for i := range z {
+		z[i], borrow = bits.Sub64(z[i], a[i], borrow)
+	}
+	// If we borrowed, we need to add p back.
+	m := limbs.Mask(saferith.Choice(borrow))
+	var carry uint64
+	for i := range z {
+		z[i], carry = bits.Add64(z[i], m&pLimbs[i], carry)
+	}
+	return z
+}
+
+// Negate sets z <- -z, returning z.
+func (z *fp) Negate() *fp {
+	x := *z
+	*z = fp{}
+	return z.Sub(&x)
+}
+
+// Mul sets z <- z * a, returning z.
+//
+// This uses Montgomery multiplication, interleaving the reduction with the product.
+func (z *fp) Mul(a *fp) *fp {
+	var t [8]uint64
+	for i := 0; i < 6; i++ {
+		// t += z * a[i]
+		var carry, c uint64
+		for j := 0; j < 6; j++ {
+			hi, lo := bits.Mul64(z[j], a[i])
+			lo, c = bits.Add64(lo, t[j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[j] = lo
+			carry = hi
+		}
+		t[6], c = bits.Add64(t[6], carry, 0)
+		t[7] = c
+
+		// We add a multiple of p to make the bottom limb 0, and then shift it out.
+		m := t[0] * pInv
+		hi, lo := bits.Mul64(m, pLimbs[0])
+		_, c = bits.Add64(lo, t[0], 0)
+		carry = hi + c
+		for j := 1; j < 6; j++ {
+			hi, lo := bits.Mul64(m, pLimbs[j])
+			lo, c = bits.Add64(lo, t[j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[j-1] = lo
+			carry = hi
+		}
+		t[5], c = bits.Add64(t[6], carry, 0)
+		t[6] = t[7] + c
+	}
+	// Because 4p < 2^384, the result is < 2p, and t[6] = 0.
+	return z.condSubtract([6]uint64{t[0], t[1], t[2], t[3], t[4], t[5]})
+}
+
+// Square sets z <- z * z, returning z.
+func (z *fp) Square() *fp {
+	return z.Mul(z)
+}
+
+// pow sets z <- z^e, returning z, where e is given as little endian limbs.
+//
+// This takes time depending only on the length of e, and not on the value of z.
+// The exponent is assumed to be public.
+func (z *fp) pow(e []uint64) *fp {
+	x := *z
+	z.Set(&fpOne)
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			z.Square()
+			if (e[i]>>j)&1 == 1 {
+				z.Mul(&x)
+			}
+		}
+	}
+	return z
+}
+
+// pMinusTwo is p - 2, which we use to calculate inverses.
+var pMinusTwo = [6]uint64{0xB9FEFFFFFFFFAAA9, 0x1EABFFFEB153FFFF, 0x6730D2A0F6B0F624, 0x64774B84F38512BF, 0x4B1BA7B6434BACD7, 0x1A0111EA397FE69A}
+
+// pPlusOneQuarter is (p + 1) / 4, which we use to calculate square roots.
+var pPlusOneQuarter = [6]uint64{0xEE7FBFFFFFFFEAAB, 0x07AAFFFFAC54FFFF, 0xD9CC34A83DAC3D89, 0xD91DD2E13CE144AF, 0x92C6E9ED90D2EB35, 0x0680447A8E5FF9A6}
+
+// Invert sets z <- z^-1, returning z.
+//
+// If z = 0, the result is 0.
+func (z *fp) Invert() *fp {
+	return z.pow(pMinusTwo[:])
+}
+
+// Sqrt sets z <- sqrt(z), returning a choice indicating if z was actually square.
+//
+// Since p = 3 mod 4, we calculate z^((p + 1) / 4). If z isn't square, then z is left unmodified.
+func (z *fp) Sqrt() saferith.Choice {
+	root := new(fp).Set(z).pow(pPlusOneQuarter[:])
+	wasSquare := new(fp).Set(root).Square().Eq(z)
+	z.CondAssign(wasSquare, root)
+	return wasSquare
+}
+
+// Eq checks if z = x, in constant-time.
+func (z *fp) Eq(x *fp) saferith.Choice {
+	var diff uint64
+	for i := range z {
+		diff |= z[i] ^ x[i]
+	}
+	return limbs.IsZero(diff)
+}
+
+// EqZero checks if z = 0, in constant-time.
+func (z *fp) EqZero() saferith.Choice {
+	return z.Eq(&fp{})
+}
+
+// fromMontgomery returns the limbs of z, taken out of Montgomery form.
+func (z *fp) fromMontgomery() [6]uint64 {
+	out := *z
+	out.Mul(&fp{1})
+	return out
+}
+
+// IsOdd checks if z is odd, when taken out of Montgomery form.
+func (z *fp) IsOdd() saferith.Choice {
+	return saferith.Choice(z.fromMontgomery()[0] & 1)
+}
+
+// IsLexicographicallyLargest checks if z > (p - 1) / 2, i.e. z is larger than -z.
+//
+// This is how the sign of a coordinate is defined in the ZCash encoding of points.
+func (z *fp) IsLexicographicallyLargest() saferith.Choice {
+	l := z.fromMontgomery()
+	// We check if (p - 1) / 2 - z borrows.
+	var borrow uint64
+	for i := range l {
+		_, borrow = bits.Sub64(pMinusOneHalf[i], l[i], borrow)
+	}
+	return saferith.Choice(borrow)
+}
+
+// Bytes returns the Big Endian encoding of z, using 48 bytes.
+func (z *fp) Bytes() []byte {
+	l := z.fromMontgomery()
+	out := make([]byte, fpBytes)
+	for i, limb := range l {
+		binary.BigEndian.PutUint64(out[fpBytes-8*(i+1):], limb)
+	}
+	return out
+}
+
+// SetBytes sets z to the value of 48 Big Endian bytes.
+//
+// This returns an error if the value isn't less than p.
+func (z *fp) SetBytes(data []byte) error {
+	if len(data) != fpBytes {
+		return errors.New("bls12381.fp.SetBytes: invalid data length")
+	}
+	var l [6]uint64
+	for i := range l {
+		l[i] = binary.BigEndian.Uint64(data[fpBytes-8*(i+1):])
+	}
+	// The value is canonical exactly when subtracting p borrows.
+	var borrow uint64
+	for i := range l {
+		_, borrow = bits.Sub64(l[i], pLimbs[i], borrow)
+	}
+	if borrow != 1 {
+		return errors.New("bls12381.fp.SetBytes: value is greater than field prime")
+	}
+	*z = l
+	z.Mul(&rSquared)
+	return nil
+}
+
+// setNat sets z to the value of a number, reduced modulo p.
+func (z *fp) setNat(x *saferith.Nat) *fp {
+	reduced := new(saferith.Nat).Mod(x, pModulus)
+	// This can't fail, since the value is reduced.
+	_ = z.SetBytes(reduced.FillBytes(make([]byte, fpBytes)))
+	return z
+}
+
+// pModulus is p, as a saferith Modulus.
+var pModulus = saferith.ModulusFromBytes(reverseLimbs(pLimbs[:]))
+
+// reverseLimbs converts little endian limbs into Big Endian bytes.
+func reverseLimbs(l []uint64) []byte {
+	out := make([]byte, 8*len(l))
+	for i, limb := range l {
+		binary.BigEndian.PutUint64(out[len(out)-8*(i+1):], limb)
+	}
+	return out
+}
+
+// fpFromHex creates a field element from a Big Endian hex string, panicking on failure.
+func fpFromHex(hex string) fp {
+	nat, err := new(saferith.Nat).SetHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	var z fp
+	z.setNat(nat)
+	return z
+}
@@ -0,0 +1,221 @@
+package bls12381
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/cronokirby/kyokusen/internal/limbs"
+	"github.com/cronokirby/saferith"
+)
+
+// fp12 represents an element c0 + c1 * w of the quadratic extension Fp6[w] / (w^2 - v).
+type fp12 struct {
+	c0, c1 fp6
+}
+
+// fp12One returns the element 1.
+func fp12One() fp12 {
+	return fp12{c0: fp6One()}
+}
+
+// Set sets z <- x, returning z.
+func (z *fp12) Set(x *fp12) *fp12 {
+	*z = *x
+	return z
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *fp12) CondAssign(yes saferith.Choice, x *fp12) *fp12 {
+	z.c0.CondAssign(yes, &x.c0)
+	z.c1.CondAssign(yes, &x.c1)
+	return z
+}
+
+// Conjugate sets z <- c0 - c1 * w, returning z.
+//
+// This is the Frobenius map z^(p^6). In the cyclotomic subgroup, this is the same as inversion.
+func (z *fp12) Conjugate() *fp12 {
+	z.c1.Negate()
+	return z
+}
+
+// Mul sets z <- z * a, returning z.
+func (z *fp12) Mul(a *fp12) *fp12 {
+	// Karatsuba: (a0 + a1 w)(b0 + b1 w) = (a0 b0 + a1 b1 v) + ((a0 + a1)(b0 + b1) - a0 b0 - a1 b1) w
+	v0 := new(fp6).Set(&z.c0).Mul(&a.c0)
+	v1 := new(fp6).Set(&z.c1).Mul(&a.c1)
+	z.c1.Add(&z.c0)
+	z.c1.Mul(new(fp6).Set(&a.c0).Add(&a.c1)).Sub(v0).Sub(v1)
+	z.c0.Set(v1).MulByV().Add(v0)
+	return z
+}
+
+// Square sets z <- z * z, returning z.
+func (z *fp12) Square() *fp12 {
+	// (a0 + a1 w)^2 = (a0 + a1)(a0 + a1 v) - a0 a1 - a0 a1 v + 2 a0 a1 w
+	ab := new(fp6).Set(&z.c0).Mul(&z.c1)
+	sum := new(fp6).Set(&z.c0).Add(&z.c1)
+	z.c0.Add(new(fp6).Set(&z.c1).MulByV()).Mul(sum)
+	z.c0.Sub(ab).Sub(new(fp6).Set(ab).MulByV())
+	z.c1.Set(ab).Add(ab)
+	return z
+}
+
+// Invert sets z <- z^-1, returning z.
+//
+// If z = 0, the result is 0.
+func (z *fp12) Invert() *fp12 {
+	// 1 / (a0 + a1 w) = (a0 - a1 w) / (a0^2 - a1^2 v)
+	norm := new(fp6).Set(&z.c0).Square()
+	norm.Sub(new(fp6).Set(&z.c1).Square().MulByV())
+	norm.Invert()
+	z.c0.Mul(norm)
+	z.c1.Mul(norm).Negate()
+	return z
+}
+
+// Exp sets z <- z^e, returning z, where e is given as Big Endian bytes.
+//
+// This runs in constant-time, processing the exponent 4 bits at a time.
+func (z *fp12) Exp(e []byte) *fp12 {
+	var table [16]fp12
+	table[0] = fp12One()
+	for j := 1; j < len(table); j++ {
+		table[j].Set(&table[j-1]).Mul(z)
+	}
+	acc := fp12One()
+	var selected fp12
+	for i := 0; i < 2*len(e); i++ {
+		for j := 0; j < 4; j++ {
+			acc.Square()
+		}
+		d := (e[i/2] >> (4 * (1 - i%2))) & 0xF
+		for j := range table {
+			selected.CondAssign(saferith.Choice(limbs.IsZero(uint64(d)^uint64(j))), &table[j])
+		}
+		acc.Mul(&selected)
+	}
+	return z.Set(&acc)
+}
+
+// frobeniusCoefficients holds xi^(k (p - 1) / 6), for k = 0, ..., 5, where xi = 1 + u.
+//
+// Since w^6 = xi, raising c w^k to the power p gives conj(c) w^k times the k-th coefficient.
+var frobeniusCoefficients = func() [6]fp2 {
+	e := new(big.Int).SetBytes(pModulus.Bytes())
+	e.Sub(e, big.NewInt(1))
+	e.Div(e, big.NewInt(6))
+	data := e.FillBytes(make([]byte, fpBytes))
+	var limbs [6]uint64
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(data[len(data)-8*(i+1):])
+	}
+	gamma := fp2{c0: fpOne, c1: fpOne}
+	gamma.pow(limbs[:])
+	var out [6]fp2
+	out[0] = fp2One()
+	for k := 1; k < len(out); k++ {
+		out[k].Set(&out[k-1]).Mul(&gamma)
+	}
+	return out
+}()
+
+// Frobenius sets z <- z^p, returning z.
+func (z *fp12) Frobenius() *fp12 {
+	// The coefficients of w^0, ..., w^5 are c0.c0, c1.c0, c0.c1, c1.c1, c0.c2, c1.c2.
+	for k, c := range [6]*fp2{&z.c0.c0, &z.c1.c0, &z.c0.c1, &z.c1.c1, &z.c0.c2, &z.c1.c2} {
+		c.Conjugate().Mul(&frobeniusCoefficients[k])
+	}
+	return z
+}
+
+// fp4Square returns (a + b s)^2, in the quadratic extension Fp2[s] / (s^2 - (1 + u)).
+func fp4Square(a, b *fp2) (c0, c1 fp2) {
+	t0 := new(fp2).Set(a).Square()
+	t1 := new(fp2).Set(b).Square()
+	c0.Set(t1).MulByNonResidue().Add(t0)
+	c1.Set(a).Add(b).Square().Sub(t0).Sub(t1)
+	return c0, c1
+}
+
+// CyclotomicSquare sets z <- z * z, returning z, assuming that z lies in the cyclotomic subgroup.
+//
+// This is the case after the easy part of the final exponentiation. This uses the
+// formulas from section 3.2 of https://eprint.iacr.org/2009/565, which only need
+// to square three elements of Fp4 = Fp2[w^3], and is much faster than Square.
+// The result is wrong if z lies outside of the cyclotomic subgroup.
+func (z *fp12) CyclotomicSquare() *fp12 {
+	z0, z4, z3 := &z.c0.c0, &z.c0.c1, &z.c0.c2
+	z2, z1, z5 := &z.c1.c0, &z.c1.c1, &z.c1.c2
+
+	// The pairs (z0, z1), (z2, z3), and (z4, z5) are elements of Fp4, which we square.
+	t0, t1 := fp4Square(z0, z1)
+	// z0 <- 3 t0 - 2 z0, z1 <- 3 t1 + 2 z1
+	z0.Set(new(fp2).Set(&t0).Sub(z0).Double().Add(&t0))
+	z1.Set(new(fp2).Set(&t1).Add(z1).Double().Add(&t1))
+
+	t0, t1 = fp4Square(z2, z3)
+	t2, t3 := fp4Square(z4, z5)
+	// z4 <- 3 t0 - 2 z4, z5 <- 3 t1 + 2 z5
+	z4.Set(new(fp2).Set(&t0).Sub(z4).Double().Add(&t0))
+	z5.Set(new(fp2).Set(&t1).Add(z5).Double().Add(&t1))
+	// z2 <- 3 xi t3 + 2 z2, z3 <- 3 t2 - 2 z3
+	t3.MulByNonResidue()
+	z2.Set(new(fp2).Set(&t3).Add(z2).Double().Add(&t3))
+	z3.Set(new(fp2).Set(&t2).Sub(z3).Double().Add(&t2))
+	return z
+}
+
+// mulByLine sets z <- z * (l0 + l1 v + l4 v w), returning z.
+//
+// This is the shape of the lines in the Miller loop, and skipping the zero
+// coefficients makes this much faster than Mul.
+func (z *fp12) mulByLine(l0, l1, l4 *fp2) *fp12 {
+	// (a0 + a1 w)(b0 + b1 w) = (a0 b0 + a1 b1 v) + ((a0 + a1)(b0 + b1) - a0 b0 - a1 b1) w,
+	// with b0 = l0 + l1 v and b1 = l4 v.
+	v0 := new(fp6).Set(&z.c0).mulBy01(l0, l1)
+	v1 := new(fp6).Set(&z.c1).mulBy1(l4)
+	z.c1.Add(&z.c0).mulBy01(l0, new(fp2).Set(l1).Add(l4)).Sub(v0).Sub(v1)
+	z.c0.Set(v1).MulByV().Add(v0)
+	return z
+}
+
+// Eq checks if z = x, in constant-time.
+func (z *fp12) Eq(x *fp12) saferith.Choice {
+	return z.c0.Eq(&x.c0) & z.c1.Eq(&x.c1)
+}
+
+// fp12Bytes is the size of the encoding of an element of Fp12.
+const fp12Bytes = 6 * fp2Bytes
+
+// coefficients returns pointers to the coefficients of z over Fp2, from the highest to the lowest.
+func (z *fp12) coefficients() [6]*fp2 {
+	return [6]*fp2{&z.c1.c2, &z.c1.c1, &z.c1.c0, &z.c0.c2, &z.c0.c1, &z.c0.c0}
+}
+
+// Bytes encodes z by concatenating the encodings of its coefficients over Fp2, from the highest to the lowest.
+func (z *fp12) Bytes() []byte {
+	out := make([]byte, 0, fp12Bytes)
+	for _, c := range z.coefficients() {
+		out = append(out, c.Bytes()...)
+	}
+	return out
+}
+
+// SetBytes sets z from the encoding produced by Bytes.
+//
+// This returns an error if any coefficient isn't canonical.
+func (z *fp12) SetBytes(data []byte) error {
+	if len(data) != fp12Bytes {
+		return errors.New("invalid data length")
+	}
+	var out fp12
+	for i, c := range out.coefficients() {
+		if err := c.SetBytes(data[i*fp2Bytes : (i+1)*fp2Bytes]); err != nil {
+			return errors.New("invalid coefficient")
+		}
+	}
+	*z = out
+	return nil
+}
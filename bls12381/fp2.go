@@ -0,0 +1,207 @@
+package bls12381
+
+import (
+	"errors"
+
+	"github.com/cronokirby/saferith"
+)
+
+// fp2 represents an element c0 + c1 * u of the quadratic extension Fp[u] / (u^2 + 1).
+type fp2 struct {
+	c0, c1 fp
+}
+
+// fp2One returns the element 1.
+func fp2One() fp2 {
+	return fp2{c0: fpOne}
+}
+
+// Set sets z <- x, returning z.
+func (z *fp2) Set(x *fp2) *fp2 {
+	*z = *x
+	return z
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *fp2) CondAssign(yes saferith.Choice, x *fp2) *fp2 {
+	z.c0.CondAssign(yes, &x.c0)
+	z.c1.CondAssign(yes, &x.c1)
+	return z
+}
+
+// Add sets z <- z + a, returning z.
+func (z *fp2) Add(a *fp2) *fp2 {
+	z.c0.Add(&a.c0)
+	z.c1.Add(&a.c1)
+	return z
+}
+
+// Double sets z <- 2 * z, returning z.
+func (z *fp2) Double() *fp2 {
+	return z.Add(z)
+}
+
+// Sub sets z <- z - a, returning z.
+func (z *fp2) Sub(a *fp2) *fp2 {
+	z.c0.Sub(&a.c0)
+	z.c1.Sub(&a.c1)
+	return z
+}
+
+// Negate sets z <- -z, returning z.
+func (z *fp2) Negate() *fp2 {
+	z.c0.Negate()
+	z.c1.Negate()
+	return z
+}
+
+// Conjugate sets z <- c0 - c1 * u, returning z.
+//
+// This is also the Frobenius map, z^p.
+func (z *fp2) Conjugate() *fp2 {
+	z.c1.Negate()
+	return z
+}
+
+// Mul sets z <- z * a, returning z.
+func (z *fp2) Mul(a *fp2) *fp2 {
+	// Karatsuba: (a0 + a1 u)(b0 + b1 u) = (a0 b0 - a1 b1) + ((a0 + a1)(b0 + b1) - a0 b0 - a1 b1) u
+	v0 := new(fp).Set(&z.c0).Mul(&a.c0)
+	v1 := new(fp).Set(&z.c1).Mul(&a.c1)
+	cross := new(fp).Set(&z.c0).Add(&z.c1)
+	cross.Mul(new(fp).Set(&a.c0).Add(&a.c1))
+	z.c1.Set(cross).Sub(v0).Sub(v1)
+	z.c0.Set(v0).Sub(v1)
+	return z
+}
+
+// MulFp sets z <- z * a, for a in the base field, returning z.
+func (z *fp2) MulFp(a *fp) *fp2 {
+	z.c0.Mul(a)
+	z.c1.Mul(a)
+	return z
+}
+
+// Square sets z <- z * z, returning z.
+func (z *fp2) Square() *fp2 {
+	// (a0 + a1 u)^2 = (a0 + a1)(a0 - a1) + 2 a0 a1 u
+	sum := new(fp).Set(&z.c0).Add(&z.c1)
+	diff := new(fp).Set(&z.c0).Sub(&z.c1)
+	z.c1.Mul(&z.c0).Double()
+	z.c0.Set(sum).Mul(diff)
+	return z
+}
+
+// MulByNonResidue sets z <- z * (1 + u), returning z.
+//
+// This is the non-residue used to build the higher extensions.
+func (z *fp2) MulByNonResidue() *fp2 {
+	c0 := new(fp).Set(&z.c0).Sub(&z.c1)
+	z.c1.Add(&z.c0)
+	z.c0.Set(c0)
+	return z
+}
+
+// Invert sets z <- z^-1, returning z.
+//
+// If z = 0, the result is 0.
+func (z *fp2) Invert() *fp2 {
+	// 1 / (a0 + a1 u) = (a0 - a1 u) / (a0^2 + a1^2)
+	norm := new(fp).Set(&z.c0).Square()
+	norm.Add(new(fp).Set(&z.c1).Square())
+	norm.Invert()
+	return z.Conjugate().MulFp(norm)
+}
+
+// pow sets z <- z^e, returning z, where e is given as little endian limbs.
+//
+// The exponent is assumed to be public.
+func (z *fp2) pow(e []uint64) *fp2 {
+	x := *z
+	*z = fp2One()
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			z.Square()
+			if (e[i]>>j)&1 == 1 {
+				z.Mul(&x)
+			}
+		}
+	}
+	return z
+}
+
+// pMinusThreeQuarter is (p - 3) / 4.
+var pMinusThreeQuarter = [6]uint64{0xEE7FBFFFFFFFEAAA, 0x07AAFFFFAC54FFFF, 0xD9CC34A83DAC3D89, 0xD91DD2E13CE144AF, 0x92C6E9ED90D2EB35, 0x0680447A8E5FF9A6}
+
+// Sqrt sets z <- sqrt(z), returning a choice indicating if z was actually square.
+//
+// This follows algorithm 9 of https://eprint.iacr.org/2012/685. If z isn't
+// square, then z is left unmodified.
+func (z *fp2) Sqrt() saferith.Choice {
+	a1 := new(fp2).Set(z).pow(pMinusThreeQuarter[:])
+	// alpha = z^((p - 1) / 2), x0 = z^((p + 1) / 4)
+	alpha := new(fp2).Set(a1).Square().Mul(z)
+	x0 := new(fp2).Set(a1).Mul(z)
+
+	// If alpha = -1, then the root is u * x0.
+	minusOne := fp2One()
+	minusOne.Negate()
+	isMinusOne := alpha.Eq(&minusOne)
+	ux0 := fp2{c0: x0.c1, c1: x0.c0}
+	ux0.c0.Negate()
+
+	// Otherwise, the root is (1 + alpha)^((p - 1) / 2) * x0.
+	one := fp2One()
+	b := new(fp2).Set(alpha).Add(&one).pow(pMinusOneHalf[:])
+	root := b.Mul(x0)
+	root.CondAssign(isMinusOne, &ux0)
+
+	wasSquare := new(fp2).Set(root).Square().Eq(z)
+	z.CondAssign(wasSquare, root)
+	return wasSquare
+}
+
+// Eq checks if z = x, in constant-time.
+func (z *fp2) Eq(x *fp2) saferith.Choice {
+	return z.c0.Eq(&x.c0) & z.c1.Eq(&x.c1)
+}
+
+// EqZero checks if z = 0, in constant-time.
+func (z *fp2) EqZero() saferith.Choice {
+	return z.c0.EqZero() & z.c1.EqZero()
+}
+
+// IsLexicographicallyLargest checks if z is larger than -z.
+//
+// We compare c1 first, and only use c0 if c1 = 0, following the ZCash encoding.
+func (z *fp2) IsLexicographicallyLargest() saferith.Choice {
+	return z.c1.IsLexicographicallyLargest() | (z.c1.EqZero() & z.c0.IsLexicographicallyLargest())
+}
+
+// fp2Bytes is the number of bytes in the encoding of an element of fp2.
+const fp2Bytes = 2 * fpBytes
+
+// Bytes returns the encoding of z, as c1 || c0, with each part encoded as 48 Big Endian bytes.
+//
+// This order is the one used by the ZCash encoding of points.
+func (z *fp2) Bytes() []byte {
+	return append(z.c1.Bytes(), z.c0.Bytes()...)
+}
+
+// SetBytes sets z from its encoding as c1 || c0.
+//
+// This returns an error if either part isn't less than p.
+func (z *fp2) SetBytes(data []byte) error {
+	if len(data) != fp2Bytes {
+		return errors.New("bls12381.fp2.SetBytes: invalid data length")
+	}
+	var out fp2
+	if err := out.c1.SetBytes(data[:fpBytes]); err != nil {
+		return err
+	}
+	if err := out.c0.SetBytes(data[fpBytes:]); err != nil {
+		return err
+	}
+	*z = out
+	return nil
+}
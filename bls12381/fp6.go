@@ -0,0 +1,143 @@
+package bls12381
+
+import "github.com/cronokirby/saferith"
+
+// fp6 represents an element c0 + c1 * v + c2 * v^2 of the cubic extension Fp2[v] / (v^3 - (1 + u)).
+type fp6 struct {
+	c0, c1, c2 fp2
+}
+
+// fp6One returns the element 1.
+func fp6One() fp6 {
+	return fp6{c0: fp2One()}
+}
+
+// Set sets z <- x, returning z.
+func (z *fp6) Set(x *fp6) *fp6 {
+	*z = *x
+	return z
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *fp6) CondAssign(yes saferith.Choice, x *fp6) *fp6 {
+	z.c0.CondAssign(yes, &x.c0)
+	z.c1.CondAssign(yes, &x.c1)
+	z.c2.CondAssign(yes, &x.c2)
+	return z
+}
+
+// Add sets z <- z + a, returning z.
+func (z *fp6) Add(a *fp6) *fp6 {
+	z.c0.Add(&a.c0)
+	z.c1.Add(&a.c1)
+	z.c2.Add(&a.c2)
+	return z
+}
+
+// Sub sets z <- z - a, returning z.
+func (z *fp6) Sub(a *fp6) *fp6 {
+	z.c0.Sub(&a.c0)
+	z.c1.Sub(&a.c1)
+	z.c2.Sub(&a.c2)
+	return z
+}
+
+// Negate sets z <- -z, returning z.
+func (z *fp6) Negate() *fp6 {
+	z.c0.Negate()
+	z.c1.Negate()
+	z.c2.Negate()
+	return z
+}
+
+// Mul sets z <- z * a, returning z.
+func (z *fp6) Mul(a *fp6) *fp6 {
+	// This uses the Karatsuba-like formulas from section 4 of https://eprint.iacr.org/2006/471.
+	v0 := new(fp2).Set(&z.c0).Mul(&a.c0)
+	v1 := new(fp2).Set(&z.c1).Mul(&a.c1)
+	v2 := new(fp2).Set(&z.c2).Mul(&a.c2)
+
+	// c0 = v0 + ((a1 + a2)(b1 + b2) - v1 - v2) * xi
+	c0 := new(fp2).Set(&z.c1).Add(&z.c2)
+	c0.Mul(new(fp2).Set(&a.c1).Add(&a.c2)).Sub(v1).Sub(v2).MulByNonResidue().Add(v0)
+	// c1 = (a0 + a1)(b0 + b1) - v0 - v1 + v2 * xi
+	c1 := new(fp2).Set(&z.c0).Add(&z.c1)
+	c1.Mul(new(fp2).Set(&a.c0).Add(&a.c1)).Sub(v0).Sub(v1)
+	c1.Add(new(fp2).Set(v2).MulByNonResidue())
+	// c2 = (a0 + a2)(b0 + b2) - v0 - v2 + v1
+	c2 := new(fp2).Set(&z.c0).Add(&z.c2)
+	c2.Mul(new(fp2).Set(&a.c0).Add(&a.c2)).Sub(v0).Sub(v2).Add(v1)
+
+	z.c0.Set(c0)
+	z.c1.Set(c1)
+	z.c2.Set(c2)
+	return z
+}
+
+// Square sets z <- z * z, returning z.
+func (z *fp6) Square() *fp6 {
+	return z.Mul(z)
+}
+
+// mulBy01 sets z <- z * (b0 + b1 v), returning z.
+func (z *fp6) mulBy01(b0, b1 *fp2) *fp6 {
+	// (a0 + a1 v + a2 v^2)(b0 + b1 v) = (a0 b0 + a2 b1 xi) + (a0 b1 + a1 b0) v + (a1 b1 + a2 b0) v^2
+	c0 := new(fp2).Set(&z.c2).Mul(b1).MulByNonResidue()
+	c0.Add(new(fp2).Set(&z.c0).Mul(b0))
+	c1 := new(fp2).Set(&z.c0).Mul(b1)
+	c1.Add(new(fp2).Set(&z.c1).Mul(b0))
+	z.c2.Mul(b0).Add(z.c1.Mul(b1))
+	z.c0.Set(c0)
+	z.c1.Set(c1)
+	return z
+}
+
+// mulBy1 sets z <- z * b1 v, returning z.
+func (z *fp6) mulBy1(b1 *fp2) *fp6 {
+	// (a0 + a1 v + a2 v^2) b1 v = a2 b1 xi + a0 b1 v + a1 b1 v^2
+	c0 := new(fp2).Set(&z.c2).Mul(b1).MulByNonResidue()
+	z.c2.Set(&z.c1).Mul(b1)
+	z.c1.Set(&z.c0).Mul(b1)
+	z.c0.Set(c0)
+	return z
+}
+
+// MulByV sets z <- z * v, returning z.
+func (z *fp6) MulByV() *fp6 {
+	c2 := z.c2
+	z.c2.Set(&z.c1)
+	z.c1.Set(&z.c0)
+	z.c0.Set(&c2).MulByNonResidue()
+	return z
+}
+
+// Invert sets z <- z^-1, returning z.
+//
+// If z = 0, the result is 0.
+func (z *fp6) Invert() *fp6 {
+	// This follows algorithm 17 of https://eprint.iacr.org/2010/354.
+	// t0 = c0^2 - xi c1 c2
+	t0 := new(fp2).Set(&z.c0).Square()
+	t0.Sub(new(fp2).Set(&z.c1).Mul(&z.c2).MulByNonResidue())
+	// t1 = xi c2^2 - c0 c1
+	t1 := new(fp2).Set(&z.c2).Square().MulByNonResidue()
+	t1.Sub(new(fp2).Set(&z.c0).Mul(&z.c1))
+	// t2 = c1^2 - c0 c2
+	t2 := new(fp2).Set(&z.c1).Square()
+	t2.Sub(new(fp2).Set(&z.c0).Mul(&z.c2))
+	// norm = c0 t0 + xi (c2 t1 + c1 t2)
+	norm := new(fp2).Set(&z.c2).Mul(t1)
+	norm.Add(new(fp2).Set(&z.c1).Mul(t2)).MulByNonResidue()
+	norm.Add(new(fp2).Set(&z.c0).Mul(t0))
+	norm.Invert()
+
+	z.c0.Set(t0).Mul(norm)
+	z.c1.Set(t1).Mul(norm)
+	z.c2.Set(t2).Mul(norm)
+	return z
+}
+
+// Eq checks if z = x, in constant-time.
+func (z *fp6) Eq(x *fp6) saferith.Choice {
+	return z.c0.Eq(&x.c0) & z.c1.Eq(&x.c1) & z.c2.Eq(&x.c2)
+}
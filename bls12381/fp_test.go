@@ -0,0 +1,198 @@
+package bls12381
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen/internal/pairing"
+	"github.com/cronokirby/saferith"
+)
+
+func randomFp(r *rand.Rand) fp {
+	data := make([]byte, fpBytes)
+	r.Read(data)
+	var out fp
+	out.setNat(new(saferith.Nat).SetBytes(data))
+	return out
+}
+
+func (fp) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomFp(r))
+}
+
+func (fp2) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(fp2{c0: randomFp(r), c1: randomFp(r)})
+}
+
+func randomFp6(r *rand.Rand) fp6 {
+	var out fp6
+	for _, c := range []*fp2{&out.c0, &out.c1, &out.c2} {
+		*c = fp2{c0: randomFp(r), c1: randomFp(r)}
+	}
+	return out
+}
+
+func (fp12) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(fp12{c0: randomFp6(r), c1: randomFp6(r)})
+}
+
+// fpBig converts a field element to a big.Int, for comparison with math/big.
+func fpBig(a *fp) *big.Int {
+	return new(big.Int).SetBytes(a.Bytes())
+}
+
+var pBig = new(big.Int).SetBytes(pModulus.Bytes())
+
+func TestFpMatchesReference(t *testing.T) {
+	cases := map[string]func(a, b fp) bool{
+		"Add": func(a, b fp) bool {
+			expected := new(big.Int).Add(fpBig(&a), fpBig(&b))
+			return fpBig(new(fp).Set(&a).Add(&b)).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Sub": func(a, b fp) bool {
+			expected := new(big.Int).Sub(fpBig(&a), fpBig(&b))
+			return fpBig(new(fp).Set(&a).Sub(&b)).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Negate": func(a, _ fp) bool {
+			expected := new(big.Int).Neg(fpBig(&a))
+			return fpBig(new(fp).Set(&a).Negate()).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Mul": func(a, b fp) bool {
+			expected := new(big.Int).Mul(fpBig(&a), fpBig(&b))
+			return fpBig(new(fp).Set(&a).Mul(&b)).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Invert": func(a, _ fp) bool {
+			expected := new(big.Int).ModInverse(fpBig(&a), pBig)
+			return fpBig(new(fp).Set(&a).Invert()).Cmp(expected) == 0
+		},
+		"Sqrt": func(a, _ fp) bool {
+			root := new(fp).Set(&a)
+			ok := root.Sqrt()
+			if (big.Jacobi(fpBig(&a), pBig) >= 0) != (ok == 1) {
+				return false
+			}
+			return ok == 0 || new(fp).Set(root).Square().Eq(&a) == 1
+		},
+	}
+	for name, f := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestFpRejectsUnreduced(t *testing.T) {
+	if new(fp).SetBytes(pModulus.Bytes()) == nil {
+		t.Error("p should be rejected as a field element")
+	}
+}
+
+func TestFp2MultiplyInverse(t *testing.T) {
+	err := quick.Check(func(a fp2) bool {
+		one := fp2One()
+		return new(fp2).Set(&a).Invert().Mul(&a).Eq(&one) == 1
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp2SqrtOfSquare(t *testing.T) {
+	err := quick.Check(func(a fp2) bool {
+		square := new(fp2).Set(&a).Square()
+		root := new(fp2).Set(square)
+		if root.Sqrt() != 1 {
+			return false
+		}
+		return root.Square().Eq(square) == 1
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp2SqrtOfNonSquare(t *testing.T) {
+	// 1 + u is not a square in Fp2, since it's used to build Fp6 as a cubic extension.
+	nonResidue := fp2One()
+	nonResidue.MulByNonResidue()
+	err := quick.Check(func(a fp2) bool {
+		if a.EqZero() == 1 {
+			return true
+		}
+		nonSquare := new(fp2).Set(&a).Square().Mul(&nonResidue)
+		return new(fp2).Set(nonSquare).Sqrt() == 0
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12MultiplyInverse(t *testing.T) {
+	err := quick.Check(func(a fp12) bool {
+		one := fp12One()
+		return new(fp12).Set(&a).Invert().Mul(&a).Eq(&one) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12SquareMatchesMul(t *testing.T) {
+	err := quick.Check(func(a fp12) bool {
+		return new(fp12).Set(&a).Square().Eq(new(fp12).Set(&a).Mul(&a)) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12MulAssociative(t *testing.T) {
+	err := quick.Check(func(a, b, c fp12) bool {
+		way1 := new(fp12).Set(&a).Mul(&b)
+		way1.Mul(&c)
+		way2 := new(fp12).Set(&b).Mul(&c)
+		way2.Mul(&a)
+		return way1.Eq(way2) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12FrobeniusMatchesExp(t *testing.T) {
+	err := quick.Check(func(a fp12) bool {
+		return new(fp12).Set(&a).Frobenius().Eq(new(fp12).Set(&a).Exp(pModulus.Bytes())) == 1
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12CyclotomicSquareMatchesSquare(t *testing.T) {
+	err := quick.Check(func(a fp12) bool {
+		// The easy part of the final exponentiation sends a into the cyclotomic subgroup.
+		f := pairing.EasyPart[fp12](&a)
+		t := *f
+		f.Frobenius().Frobenius().Mul(&t)
+		return new(fp12).Set(f).CyclotomicSquare().Eq(new(fp12).Set(f).Square()) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12MulByLineMatchesMul(t *testing.T) {
+	err := quick.Check(func(a fp12, l0, l1, l4 fp2) bool {
+		var line fp12
+		line.c0.c0, line.c0.c1, line.c1.c1 = l0, l1, l4
+		return new(fp12).Set(&a).mulByLine(&l0, &l1, &l4).Eq(new(fp12).Set(&a).Mul(&line)) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,249 @@
+package bls12381
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/pairing"
+	"github.com/cronokirby/saferith"
+)
+
+// G1CompressedBytes is the size of the compressed encoding of a G1 point.
+const G1CompressedBytes = fpBytes
+
+// G1UncompressedBytes is the size of the uncompressed encoding of a G1 point.
+const G1UncompressedBytes = 2 * fpBytes
+
+// The flags stored in the top 3 bits of an encoded point, following the ZCash format.
+const (
+	flagCompressed = 0x80
+	flagInfinity   = 0x40
+	flagSign       = 0x20
+	flagMask       = flagCompressed | flagInfinity | flagSign
+)
+
+// g1Curve holds the constants of y^2 = x^3 + 4, the curve containing G1.
+var g1Curve = &pairing.Curve[fp, *fp]{B3: *new(fp).SetUint64(12), One: fpOne}
+
+// g1Cofactor is the cofactor of the curve containing G1, as Big Endian bytes.
+var g1Cofactor = []byte{0x39, 0x6C, 0x8C, 0x00, 0x55, 0x55, 0xE1, 0x56, 0x8C, 0x00, 0xAA, 0xAB, 0x00, 0x00, 0xAA, 0xAB}
+
+// rBytes is the order of the groups, as Big Endian bytes.
+var rBytes = r.Nat().FillBytes(make([]byte, ScalarBytes))
+
+// G1Point represents a point on the curve containing G1.
+//
+// Points decoded from bytes always lie in G1, but points created in other ways,
+// for example by hashing, might need to have their cofactor cleared first.
+type G1Point struct {
+	inner pairing.Point[fp, *fp]
+}
+
+// g1Generator is the standard generator of G1.
+var g1Generator = &G1Point{inner: pairing.Point[fp, *fp]{
+	X: fpFromHex("17F1D3A73197D7942695638C4FA9AC0FC3688C4F9774B905A14E3A3F171BAC586C55E83FF97A1AEFFB3AF00ADB22C6BB"),
+	Y: fpFromHex("08B3F481E3AAA0F1A09E30ED741D8AE4FCF5E095D5D00AF600DB18CB2C04B3EDD03CC744A2888AE40CAA232946C5E7E1"),
+	Z: fpOne,
+}}
+
+// NewG1Point returns the identity point of G1.
+func NewG1Point() *G1Point {
+	return &G1Point{inner: g1Curve.Identity()}
+}
+
+// NewG1BasePoint returns the standard generator of G1.
+func NewG1BasePoint() *G1Point {
+	out := *g1Generator
+	return &out
+}
+
+// castG1Point converts a point implementing the generic interface to this specific type.
+func castG1Point(p kyokusen.Point) *G1Point {
+	casted, ok := p.(*G1Point)
+	if !ok {
+		panic("failed to cast type to *bls12381.G1Point")
+	}
+	return casted
+}
+
+func (p *G1Point) String() string {
+	data, _ := p.MarshalBinary()
+	return fmt.Sprintf("%x", data)
+}
+
+// affine returns the affine coordinates of this point, or (0, 0) for the identity.
+func (p *G1Point) affine() (x, y fp) {
+	return p.inner.ToAffine()
+}
+
+// MarshalBinary encodes this point using the 48 byte compressed ZCash format.
+func (p *G1Point) MarshalBinary() ([]byte, error) {
+	x, y := p.affine()
+	out := x.Bytes()
+	out[0] |= flagCompressed
+	if p.IsIdentity() {
+		out[0] |= flagInfinity
+	} else if y.IsLexicographicallyLargest() == 1 {
+		out[0] |= flagSign
+	}
+	return out, nil
+}
+
+// MarshalUncompressed encodes this point using the 96 byte uncompressed ZCash format.
+func (p *G1Point) MarshalUncompressed() ([]byte, error) {
+	x, y := p.affine()
+	out := append(x.Bytes(), y.Bytes()...)
+	if p.IsIdentity() {
+		out[0] |= flagInfinity
+	}
+	return out, nil
+}
+
+// g1Rhs calculates x^3 + 4.
+func g1Rhs(x *fp) *fp {
+	four := new(fp).SetUint64(4)
+	return new(fp).Set(x).Square().Mul(x).Add(four)
+}
+
+// UnmarshalBinary decodes a point in either the compressed or uncompressed ZCash format.
+//
+// This returns an error if the point doesn't lie in G1.
+func (p *G1Point) UnmarshalBinary(data []byte) error {
+	if len(data) != G1CompressedBytes && len(data) != G1UncompressedBytes {
+		return errors.New("bls12381.G1Point.UnmarshalBinary: invalid data length")
+	}
+	compressed := len(data) == G1CompressedBytes
+	flags := data[0] & flagMask
+	if ((flags & flagCompressed) != 0) != compressed {
+		return errors.New("bls12381.G1Point.UnmarshalBinary: invalid compression flag")
+	}
+	xBytes := append([]byte{}, data[:fpBytes]...)
+	xBytes[0] &^= flagMask
+
+	if flags&flagInfinity != 0 {
+		if flags&flagSign != 0 || !allZero(xBytes) || !allZero(data[fpBytes:]) {
+			return errors.New("bls12381.G1Point.UnmarshalBinary: invalid encoding of infinity")
+		}
+		*p = *NewG1Point()
+		return nil
+	}
+
+	var x, y fp
+	if err := x.SetBytes(xBytes); err != nil {
+		return errors.New("bls12381.G1Point.UnmarshalBinary: invalid x coordinate")
+	}
+	if compressed {
+		y.Set(g1Rhs(&x))
+		if y.Sqrt() != 1 {
+			return errors.New("bls12381.G1Point.UnmarshalBinary: point is not on the curve")
+		}
+		negY := new(fp).Set(&y).Negate()
+		y.CondAssign(y.IsLexicographicallyLargest()^saferith.Choice((flags&flagSign)>>5), negY)
+	} else {
+		if flags&flagSign != 0 {
+			return errors.New("bls12381.G1Point.UnmarshalBinary: invalid sign flag")
+		}
+		if err := y.SetBytes(data[fpBytes:]); err != nil {
+			return errors.New("bls12381.G1Point.UnmarshalBinary: invalid y coordinate")
+		}
+		if new(fp).Set(&y).Square().Eq(g1Rhs(&x)) != 1 {
+			return errors.New("bls12381.G1Point.UnmarshalBinary: point is not on the curve")
+		}
+	}
+	decoded := G1Point{inner: g1Curve.Affine(&x, &y)}
+	if !decoded.IsTorsionFree() {
+		return errors.New("bls12381.G1Point.UnmarshalBinary: point is not in G1")
+	}
+	*p = decoded
+	return nil
+}
+
+// mustDecodeHex decodes a hex string, panicking on failure.
+func mustDecodeHex(s string) []byte {
+	out, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// allZero checks if every byte in a slice is zero.
+func allZero(data []byte) bool {
+	var acc byte
+	for _, b := range data {
+		acc |= b
+	}
+	return acc == 0
+}
+
+func (*G1Point) Curve() kyokusen.Curve {
+	return G1Curve{}
+}
+
+// add sets p <- a + b, returning p.
+func (p *G1Point) add(a, b *G1Point) *G1Point {
+	g1Curve.Add(&p.inner, &a.inner, &b.inner)
+	return p
+}
+
+func (p1 *G1Point) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := castG1Point(other)
+	return new(G1Point).add(p1, p2)
+}
+
+func (p1 *G1Point) Sub(other kyokusen.Point) kyokusen.Point {
+	return p1.Add(other.Negate())
+}
+
+func (p *G1Point) Negate() kyokusen.Point {
+	out := *p
+	out.inner.Negate()
+	return &out
+}
+
+func (p1 *G1Point) Equal(other kyokusen.Point) bool {
+	p2 := castG1Point(other)
+	return p1.inner.Equal(&p2.inner) == 1
+}
+
+func (p *G1Point) IsIdentity() bool {
+	return p.inner.IsIdentity() == 1
+}
+
+// XScalar returns nil, since BLS12-381 is used for pairings and BLS signatures, not ECDSA.
+func (p *G1Point) XScalar() kyokusen.Scalar {
+	return nil
+}
+
+// CondAssign sets p <- other, only if yes = 1, in constant-time.
+func (p *G1Point) CondAssign(yes saferith.Choice, other *G1Point) *G1Point {
+	p.inner.CondAssign(yes, &other.inner)
+	return p
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *G1Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	out := *p
+	return out.CondAssign(yes, castG1Point(other))
+}
+
+// mul calculates k * p, where k is given as Big Endian bytes, in constant-time.
+func (p *G1Point) mul(k []byte) *G1Point {
+	return &G1Point{inner: g1Curve.Mul(&p.inner, k)}
+}
+
+// ClearCofactor returns a new point, equal to this point multiplied by the cofactor.
+//
+// The result always lies in G1. This implements the kyokusen.CofactorPoint interface.
+func (p *G1Point) ClearCofactor() kyokusen.Point {
+	return p.mul(g1Cofactor)
+}
+
+// IsTorsionFree checks if this point lies in G1, by checking that r * P is the identity.
+func (p *G1Point) IsTorsionFree() bool {
+	return p.mul(rBytes).IsIdentity()
+}
@@ -0,0 +1,179 @@
+package bls12381
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+)
+
+func randomG1Point(r *rand.Rand, size int) *G1Point {
+	return randomScalar(r, ScalarBytes).Act(g1Generator).(*G1Point)
+}
+
+func (*G1Point) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomG1Point(r, size))
+}
+
+// readVectors reads a file containing encodings of a fixed size, one after the other.
+func readVectors(t *testing.T, path string, size int) [][]byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data)%size != 0 {
+		t.Fatalf("%s: invalid length", path)
+	}
+	var out [][]byte
+	for ; len(data) > 0; data = data[size:] {
+		out = append(out, data[:size])
+	}
+	return out
+}
+
+func TestG1PointAdditionCommutative(t *testing.T) {
+	err := quick.Check(func(a, b *G1Point) bool {
+		return a.Add(b).Equal(b.Add(a))
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG1PointAddIdentityDoesNothing(t *testing.T) {
+	err := quick.Check(func(a *G1Point) bool {
+		return a.Add(NewG1Point()).Equal(a) && NewG1Point().Add(a).Equal(a)
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG1PointSubSelfIsIdentity(t *testing.T) {
+	err := quick.Check(func(a *G1Point) bool {
+		return a.Sub(a).IsIdentity()
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG1PointMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(a *G1Point) bool {
+		compressed, err := a.MarshalBinary()
+		if err != nil || len(compressed) != G1CompressedBytes {
+			return false
+		}
+		uncompressed, err := a.MarshalUncompressed()
+		if err != nil || len(uncompressed) != G1UncompressedBytes {
+			return false
+		}
+		decoded1, decoded2 := NewG1Point(), NewG1Point()
+		if decoded1.UnmarshalBinary(compressed) != nil || decoded2.UnmarshalBinary(uncompressed) != nil {
+			return false
+		}
+		return decoded1.Equal(a) && decoded2.Equal(a)
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// TestG1Vectors checks against the encoding test vectors from the zkcrypto implementation.
+//
+// The i-th entry of each file is the encoding of i * G, starting with the identity.
+func TestG1Vectors(t *testing.T) {
+	compressed := readVectors(t, "testdata/g1_compressed_valid_test_vectors.dat", G1CompressedBytes)
+	uncompressed := readVectors(t, "testdata/g1_uncompressed_valid_test_vectors.dat", G1UncompressedBytes)
+	if len(compressed) != len(uncompressed) {
+		t.Fatal("mismatched number of vectors")
+	}
+	var acc kyokusen.Point = NewG1Point()
+	for i := range compressed {
+		if actual, _ := acc.MarshalBinary(); !bytes.Equal(actual, compressed[i]) {
+			t.Errorf("%d: incorrect compressed encoding", i)
+		}
+		if actual, _ := acc.(*G1Point).MarshalUncompressed(); !bytes.Equal(actual, uncompressed[i]) {
+			t.Errorf("%d: incorrect uncompressed encoding", i)
+		}
+		for _, data := range [][]byte{compressed[i], uncompressed[i]} {
+			decoded := NewG1Point()
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				t.Errorf("%d: %v", i, err)
+			} else if !decoded.Equal(acc) {
+				t.Errorf("%d: incorrect decoded point", i)
+			}
+		}
+		acc = acc.Add(g1Generator)
+	}
+}
+
+// g1NonSubgroupPoint returns a point on the curve which isn't in G1.
+func g1NonSubgroupPoint() *G1Point {
+	for x := uint64(1); ; x++ {
+		var px, py fp
+		px.SetUint64(x)
+		py.Set(g1Rhs(&px))
+		if py.Sqrt() != 1 {
+			continue
+		}
+		p := G1Point{inner: g1Curve.Affine(&px, &py)}
+		if !p.IsTorsionFree() {
+			return &p
+		}
+	}
+}
+
+func TestG1BadEncodings(t *testing.T) {
+	generator, _ := g1Generator.MarshalBinary()
+	generatorUncompressed, _ := g1Generator.MarshalUncompressed()
+	nonSubgroup, _ := g1NonSubgroupPoint().MarshalBinary()
+	modify := func(data []byte, f func([]byte)) []byte {
+		out := append([]byte{}, data...)
+		f(out)
+		return out
+	}
+	cases := map[string][]byte{
+		"empty":                  {},
+		"wrong length":           generator[1:],
+		"missing compression":    modify(generator, func(b []byte) { b[0] &^= flagCompressed }),
+		"unexpected compression": modify(generatorUncompressed, func(b []byte) { b[0] |= flagCompressed }),
+		"sign on uncompressed":   modify(generatorUncompressed, func(b []byte) { b[0] |= flagSign }),
+		"infinity with x":        modify(generator, func(b []byte) { b[0] |= flagInfinity }),
+		"infinity with sign":     modify(make([]byte, G1CompressedBytes), func(b []byte) { b[0] = flagCompressed | flagInfinity | flagSign }),
+		"x not reduced":          modify(pModulus.Bytes(), func(b []byte) { b[0] |= flagCompressed }),
+		"not on curve":           modify(generatorUncompressed, func(b []byte) { b[len(b)-1] ^= 1 }),
+		"not in subgroup":        nonSubgroup,
+		"zero without infinity":  make([]byte, G1UncompressedBytes),
+	}
+	for name, data := range cases {
+		if NewG1Point().UnmarshalBinary(data) == nil {
+			t.Errorf("%s: encoding should be rejected", name)
+		}
+	}
+}
+
+func TestG1ClearCofactor(t *testing.T) {
+	p := g1NonSubgroupPoint()
+	if p.IsTorsionFree() {
+		t.Fatal("point should not be torsion free")
+	}
+	if !p.ClearCofactor().(*G1Point).IsTorsionFree() {
+		t.Error("clearing the cofactor should produce a point in G1")
+	}
+	if !g1Generator.IsTorsionFree() {
+		t.Error("generator should be torsion free")
+	}
+}
+
+func BenchmarkG1Add(b *testing.B) {
+	p := randomG1Point(rand.New(rand.NewSource(0)), 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Add(p)
+	}
+}
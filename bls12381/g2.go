@@ -0,0 +1,227 @@
+package bls12381
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/pairing"
+	"github.com/cronokirby/saferith"
+)
+
+// G2CompressedBytes is the size of the compressed encoding of a G2 point.
+const G2CompressedBytes = fp2Bytes
+
+// G2UncompressedBytes is the size of the uncompressed encoding of a G2 point.
+const G2UncompressedBytes = 2 * fp2Bytes
+
+// g2B is b, where y^2 = x^3 + b is the equation of the twisted curve containing G2, with b = 4 (1 + u).
+var g2B = fp2{c0: fpFromHex("04"), c1: fpFromHex("04")}
+
+// g2Curve holds the constants of the twisted curve containing G2, with B3 = 3 * b = 12 (1 + u).
+var g2Curve = &pairing.Curve[fp2, *fp2]{B3: fp2{c0: fpFromHex("0C"), c1: fpFromHex("0C")}, One: fp2One()}
+
+// g2Cofactor is the cofactor of the curve containing G2, as Big Endian bytes.
+var g2Cofactor = mustDecodeHex("05D543A95414E7F1091D50792876A202CD91DE4547085ABAA68A205B2E5A7DDFA628F1CB4D9E82EF21537E293A6691AE1616EC6E786F0C70CF1C38E31C7238E5")
+
+// G2Point represents a point on the twisted curve containing G2.
+//
+// Points decoded from bytes always lie in G2, but points created in other ways,
+// for example by hashing, might need to have their cofactor cleared first.
+type G2Point struct {
+	inner pairing.Point[fp2, *fp2]
+}
+
+// g2Generator is the standard generator of G2.
+var g2Generator = &G2Point{inner: pairing.Point[fp2, *fp2]{
+	X: fp2{
+		c0: fpFromHex("024AA2B2F08F0A91260805272DC51051C6E47AD4FA403B02B4510B647AE3D1770BAC0326A805BBEFD48056C8C121BDB8"),
+		c1: fpFromHex("13E02B6052719F607DACD3A088274F65596BD0D09920B61AB5DA61BBDC7F5049334CF11213945D57E5AC7D055D042B7E"),
+	},
+	Y: fp2{
+		c0: fpFromHex("0CE5D527727D6E118CC9CDC6DA2E351AADFD9BAA8CBDD3A76D429A695160D12C923AC9CC3BACA289E193548608B82801"),
+		c1: fpFromHex("0606C4A02EA734CC32ACD2B02BC28B99CB3E287E85A763AF267492AB572E99AB3F370D275CEC1DA1AAA9075FF05F79BE"),
+	},
+	Z: fp2One(),
+}}
+
+// NewG2Point returns the identity point of G2.
+func NewG2Point() *G2Point {
+	return &G2Point{inner: g2Curve.Identity()}
+}
+
+// NewG2BasePoint returns the standard generator of G2.
+func NewG2BasePoint() *G2Point {
+	out := *g2Generator
+	return &out
+}
+
+// castG2Point converts a point implementing the generic interface to this specific type.
+func castG2Point(p kyokusen.Point) *G2Point {
+	casted, ok := p.(*G2Point)
+	if !ok {
+		panic("failed to cast type to *bls12381.G2Point")
+	}
+	return casted
+}
+
+func (p *G2Point) String() string {
+	data, _ := p.MarshalBinary()
+	return fmt.Sprintf("%x", data)
+}
+
+// affine returns the affine coordinates of this point, or (0, 0) for the identity.
+func (p *G2Point) affine() (x, y fp2) {
+	return p.inner.ToAffine()
+}
+
+// MarshalBinary encodes this point using the 96 byte compressed ZCash format.
+func (p *G2Point) MarshalBinary() ([]byte, error) {
+	x, y := p.affine()
+	out := x.Bytes()
+	out[0] |= flagCompressed
+	if p.IsIdentity() {
+		out[0] |= flagInfinity
+	} else if y.IsLexicographicallyLargest() == 1 {
+		out[0] |= flagSign
+	}
+	return out, nil
+}
+
+// MarshalUncompressed encodes this point using the 192 byte uncompressed ZCash format.
+func (p *G2Point) MarshalUncompressed() ([]byte, error) {
+	x, y := p.affine()
+	out := append(x.Bytes(), y.Bytes()...)
+	if p.IsIdentity() {
+		out[0] |= flagInfinity
+	}
+	return out, nil
+}
+
+// g2Rhs calculates x^3 + 4 (1 + u).
+func g2Rhs(x *fp2) *fp2 {
+	return new(fp2).Set(x).Square().Mul(x).Add(&g2B)
+}
+
+// UnmarshalBinary decodes a point in either the compressed or uncompressed ZCash format.
+//
+// This returns an error if the point doesn't lie in G2.
+func (p *G2Point) UnmarshalBinary(data []byte) error {
+	if len(data) != G2CompressedBytes && len(data) != G2UncompressedBytes {
+		return errors.New("bls12381.G2Point.UnmarshalBinary: invalid data length")
+	}
+	compressed := len(data) == G2CompressedBytes
+	flags := data[0] & flagMask
+	if ((flags & flagCompressed) != 0) != compressed {
+		return errors.New("bls12381.G2Point.UnmarshalBinary: invalid compression flag")
+	}
+	xBytes := append([]byte{}, data[:fp2Bytes]...)
+	xBytes[0] &^= flagMask
+
+	if flags&flagInfinity != 0 {
+		if flags&flagSign != 0 || !allZero(xBytes) || !allZero(data[fp2Bytes:]) {
+			return errors.New("bls12381.G2Point.UnmarshalBinary: invalid encoding of infinity")
+		}
+		*p = *NewG2Point()
+		return nil
+	}
+
+	var x, y fp2
+	if err := x.SetBytes(xBytes); err != nil {
+		return errors.New("bls12381.G2Point.UnmarshalBinary: invalid x coordinate")
+	}
+	if compressed {
+		y.Set(g2Rhs(&x))
+		if y.Sqrt() != 1 {
+			return errors.New("bls12381.G2Point.UnmarshalBinary: point is not on the curve")
+		}
+		negY := new(fp2).Set(&y).Negate()
+		y.CondAssign(y.IsLexicographicallyLargest()^saferith.Choice((flags&flagSign)>>5), negY)
+	} else {
+		if flags&flagSign != 0 {
+			return errors.New("bls12381.G2Point.UnmarshalBinary: invalid sign flag")
+		}
+		if err := y.SetBytes(data[fp2Bytes:]); err != nil {
+			return errors.New("bls12381.G2Point.UnmarshalBinary: invalid y coordinate")
+		}
+		if new(fp2).Set(&y).Square().Eq(g2Rhs(&x)) != 1 {
+			return errors.New("bls12381.G2Point.UnmarshalBinary: point is not on the curve")
+		}
+	}
+	decoded := G2Point{inner: g2Curve.Affine(&x, &y)}
+	if !decoded.IsTorsionFree() {
+		return errors.New("bls12381.G2Point.UnmarshalBinary: point is not in G2")
+	}
+	*p = decoded
+	return nil
+}
+
+func (*G2Point) Curve() kyokusen.Curve {
+	return G2Curve{}
+}
+
+// add sets p <- a + b, returning p.
+func (p *G2Point) add(a, b *G2Point) *G2Point {
+	g2Curve.Add(&p.inner, &a.inner, &b.inner)
+	return p
+}
+
+func (p1 *G2Point) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := castG2Point(other)
+	return new(G2Point).add(p1, p2)
+}
+
+func (p1 *G2Point) Sub(other kyokusen.Point) kyokusen.Point {
+	return p1.Add(other.Negate())
+}
+
+func (p *G2Point) Negate() kyokusen.Point {
+	out := *p
+	out.inner.Negate()
+	return &out
+}
+
+func (p1 *G2Point) Equal(other kyokusen.Point) bool {
+	p2 := castG2Point(other)
+	return p1.inner.Equal(&p2.inner) == 1
+}
+
+func (p *G2Point) IsIdentity() bool {
+	return p.inner.IsIdentity() == 1
+}
+
+// XScalar returns nil, since the x coordinate of a point in G2 isn't an element of the base field.
+func (p *G2Point) XScalar() kyokusen.Scalar {
+	return nil
+}
+
+// CondAssign sets p <- other, only if yes = 1, in constant-time.
+func (p *G2Point) CondAssign(yes saferith.Choice, other *G2Point) *G2Point {
+	p.inner.CondAssign(yes, &other.inner)
+	return p
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *G2Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	out := *p
+	return out.CondAssign(yes, castG2Point(other))
+}
+
+// mul calculates k * p, where k is given as Big Endian bytes, in constant-time.
+func (p *G2Point) mul(k []byte) *G2Point {
+	return &G2Point{inner: g2Curve.Mul(&p.inner, k)}
+}
+
+// ClearCofactor returns a new point, equal to this point multiplied by the cofactor.
+//
+// The result always lies in G2. This implements the kyokusen.CofactorPoint interface.
+func (p *G2Point) ClearCofactor() kyokusen.Point {
+	return p.mul(g2Cofactor)
+}
+
+// IsTorsionFree checks if this point lies in G2, by checking that r * P is the identity.
+func (p *G2Point) IsTorsionFree() bool {
+	return p.mul(rBytes).IsIdentity()
+}
@@ -0,0 +1,162 @@
+package bls12381
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+)
+
+func randomG2Point(r *rand.Rand, size int) *G2Point {
+	return randomScalar(r, ScalarBytes).Act(g2Generator).(*G2Point)
+}
+
+func (*G2Point) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomG2Point(r, size))
+}
+
+func TestG2PointAdditionCommutative(t *testing.T) {
+	err := quick.Check(func(a, b *G2Point) bool {
+		return a.Add(b).Equal(b.Add(a))
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG2PointAddIdentityDoesNothing(t *testing.T) {
+	err := quick.Check(func(a *G2Point) bool {
+		return a.Add(NewG2Point()).Equal(a) && NewG2Point().Add(a).Equal(a)
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG2PointSubSelfIsIdentity(t *testing.T) {
+	err := quick.Check(func(a *G2Point) bool {
+		return a.Sub(a).IsIdentity()
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG2PointMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(a *G2Point) bool {
+		compressed, err := a.MarshalBinary()
+		if err != nil || len(compressed) != G2CompressedBytes {
+			return false
+		}
+		uncompressed, err := a.MarshalUncompressed()
+		if err != nil || len(uncompressed) != G2UncompressedBytes {
+			return false
+		}
+		decoded1, decoded2 := NewG2Point(), NewG2Point()
+		if decoded1.UnmarshalBinary(compressed) != nil || decoded2.UnmarshalBinary(uncompressed) != nil {
+			return false
+		}
+		return decoded1.Equal(a) && decoded2.Equal(a)
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// TestG2Vectors checks against the encoding test vectors from the zkcrypto implementation.
+//
+// The i-th entry of each file is the encoding of i * G, starting with the identity.
+func TestG2Vectors(t *testing.T) {
+	compressed := readVectors(t, "testdata/g2_compressed_valid_test_vectors.dat", G2CompressedBytes)
+	uncompressed := readVectors(t, "testdata/g2_uncompressed_valid_test_vectors.dat", G2UncompressedBytes)
+	if len(compressed) != len(uncompressed) {
+		t.Fatal("mismatched number of vectors")
+	}
+	var acc kyokusen.Point = NewG2Point()
+	for i := range compressed {
+		if actual, _ := acc.MarshalBinary(); !bytes.Equal(actual, compressed[i]) {
+			t.Errorf("%d: incorrect compressed encoding", i)
+		}
+		if actual, _ := acc.(*G2Point).MarshalUncompressed(); !bytes.Equal(actual, uncompressed[i]) {
+			t.Errorf("%d: incorrect uncompressed encoding", i)
+		}
+		for _, data := range [][]byte{compressed[i], uncompressed[i]} {
+			decoded := NewG2Point()
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				t.Errorf("%d: %v", i, err)
+			} else if !decoded.Equal(acc) {
+				t.Errorf("%d: incorrect decoded point", i)
+			}
+		}
+		acc = acc.Add(g2Generator)
+	}
+}
+
+// g2NonSubgroupPoint returns a point on the curve which isn't in G2.
+func g2NonSubgroupPoint() *G2Point {
+	for x := uint64(1); ; x++ {
+		var px, py fp2
+		px.c0.SetUint64(x)
+		py.Set(g2Rhs(&px))
+		if py.Sqrt() != 1 {
+			continue
+		}
+		p := G2Point{inner: g2Curve.Affine(&px, &py)}
+		if !p.IsTorsionFree() {
+			return &p
+		}
+	}
+}
+
+func TestG2BadEncodings(t *testing.T) {
+	generator, _ := g2Generator.MarshalBinary()
+	generatorUncompressed, _ := g2Generator.MarshalUncompressed()
+	nonSubgroup, _ := g2NonSubgroupPoint().MarshalBinary()
+	modify := func(data []byte, f func([]byte)) []byte {
+		out := append([]byte{}, data...)
+		f(out)
+		return out
+	}
+	cases := map[string][]byte{
+		"empty":                  {},
+		"wrong length":           generator[1:],
+		"missing compression":    modify(generator, func(b []byte) { b[0] &^= flagCompressed }),
+		"unexpected compression": modify(generatorUncompressed, func(b []byte) { b[0] |= flagCompressed }),
+		"sign on uncompressed":   modify(generatorUncompressed, func(b []byte) { b[0] |= flagSign }),
+		"infinity with x":        modify(generator, func(b []byte) { b[0] |= flagInfinity }),
+		"infinity with sign":     modify(make([]byte, G2CompressedBytes), func(b []byte) { b[0] = flagCompressed | flagInfinity | flagSign }),
+		"x not reduced":          modify(append(pModulus.Bytes(), make([]byte, fpBytes)...), func(b []byte) { b[0] |= flagCompressed }),
+		"not on curve":           modify(generatorUncompressed, func(b []byte) { b[len(b)-1] ^= 1 }),
+		"not in subgroup":        nonSubgroup,
+		"zero without infinity":  make([]byte, G2UncompressedBytes),
+	}
+	for name, data := range cases {
+		if NewG2Point().UnmarshalBinary(data) == nil {
+			t.Errorf("%s: encoding should be rejected", name)
+		}
+	}
+}
+
+func TestG2ClearCofactor(t *testing.T) {
+	p := g2NonSubgroupPoint()
+	if p.IsTorsionFree() {
+		t.Fatal("point should not be torsion free")
+	}
+	if !p.ClearCofactor().(*G2Point).IsTorsionFree() {
+		t.Error("clearing the cofactor should produce a point in G2")
+	}
+	if !g2Generator.IsTorsionFree() {
+		t.Error("generator should be torsion free")
+	}
+}
+
+func BenchmarkG2Add(b *testing.B) {
+	p := randomG2Point(rand.New(rand.NewSource(0)), 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Add(p)
+	}
+}
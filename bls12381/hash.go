@@ -0,0 +1,136 @@
+package bls12381
+
+import (
+	"crypto/sha256"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/rfc9380"
+	"github.com/cronokirby/saferith"
+)
+
+// hashToFieldL is the number of bytes used to produce each element of the base field.
+//
+// This is ceil((ceil(log2(p)) + k) / 8), with p having 381 bits, and k = 128.
+const hashToFieldL = 64
+
+// hashToFp implements hash_to_field from RFC 9380, producing count elements of Fp.
+func hashToFp(msg, dst []byte, count int) ([]fp, error) {
+	bytes, err := rfc9380.ExpandMessageXMD(sha256.New, msg, dst, count*hashToFieldL)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]fp, count)
+	for i := range out {
+		out[i].setNat(new(saferith.Nat).SetBytes(bytes[i*hashToFieldL : (i+1)*hashToFieldL]))
+	}
+	return out, nil
+}
+
+// hashToFp2 implements hash_to_field from RFC 9380, producing count elements of Fp2.
+func hashToFp2(msg, dst []byte, count int) ([]fp2, error) {
+	elements, err := hashToFp(msg, dst, 2*count)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]fp2, count)
+	for i := range out {
+		out[i] = fp2{c0: elements[2*i], c1: elements[2*i+1]}
+	}
+	return out, nil
+}
+
+// HashToG1 hashes a message to a point in G1, using a domain separation tag.
+//
+// This implements the BLS12381G1_XMD:SHA-256_SSWU_RO_ suite from RFC 9380.
+// The result is uniformly distributed, with no known discrete logarithm.
+func HashToG1(msg, dst []byte) (*G1Point, error) {
+	u, err := hashToFp(msg, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	q := mapToG1(&u[0])
+	q.add(q, mapToG1(&u[1]))
+	return q.mul(g1EffectiveCofactor), nil
+}
+
+// EncodeToG1 encodes a message as a point in G1, using a domain separation tag.
+//
+// This implements the BLS12381G1_XMD:SHA-256_SSWU_NU_ suite from RFC 9380.
+// Unlike HashToG1, the result isn't uniformly distributed, but this is faster.
+func EncodeToG1(msg, dst []byte) (*G1Point, error) {
+	u, err := hashToFp(msg, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return mapToG1(&u[0]).mul(g1EffectiveCofactor), nil
+}
+
+// HashToG2 hashes a message to a point in G2, using a domain separation tag.
+//
+// This implements the BLS12381G2_XMD:SHA-256_SSWU_RO_ suite from RFC 9380.
+// The result is uniformly distributed, with no known discrete logarithm.
+func HashToG2(msg, dst []byte) (*G2Point, error) {
+	u, err := hashToFp2(msg, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	q := mapToG2(&u[0])
+	q.add(q, mapToG2(&u[1]))
+	return q.mul(g2EffectiveCofactor), nil
+}
+
+// EncodeToG2 encodes a message as a point in G2, using a domain separation tag.
+//
+// This implements the BLS12381G2_XMD:SHA-256_SSWU_NU_ suite from RFC 9380.
+// Unlike HashToG2, the result isn't uniformly distributed, but this is faster.
+func EncodeToG2(msg, dst []byte) (*G2Point, error) {
+	u, err := hashToFp2(msg, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return mapToG2(&u[0]).mul(g2EffectiveCofactor), nil
+}
+
+// HashToCurve hashes a message to a point in G1, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using HashToG1.
+func (G1Curve) HashToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	p, err := HashToG1(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// EncodeToCurve encodes a message as a point in G1, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using EncodeToG1.
+func (G1Curve) EncodeToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	p, err := EncodeToG1(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// HashToCurve hashes a message to a point in G2, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using HashToG2.
+func (G2Curve) HashToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	p, err := HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// EncodeToCurve encodes a message as a point in G2, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using EncodeToG2.
+func (G2Curve) EncodeToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	p, err := EncodeToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,136 @@
+package bls12381
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// hashVectors holds the test vectors for a suite from appendix J of RFC 9380.
+//
+// The random oracle suites have two intermediate points, Q0 and Q1, while
+// the non-uniform suites have a single one, Q.
+type hashVectors struct {
+	Ciphersuite string
+	Dst         string
+	Vectors     []struct {
+		P   struct{ X, Y string }
+		Q   struct{ X, Y string }
+		Q0  struct{ X, Y string }
+		Q1  struct{ X, Y string }
+		Msg string
+		U   []string
+	}
+}
+
+func readHashVectors(t *testing.T, path string) *hashVectors {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out hashVectors
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return &out
+}
+
+// parseFp parses an element of Fp, written in hex with a 0x prefix.
+func parseFp(s string) fp {
+	return fpFromHex(strings.ToUpper(strings.TrimPrefix(s, "0x")))
+}
+
+// parseFp2 parses an element of Fp2, written as c0,c1.
+func parseFp2(s string) fp2 {
+	parts := strings.Split(s, ",")
+	return fp2{c0: parseFp(parts[0]), c1: parseFp(parts[1])}
+}
+
+func checkG1(t *testing.T, name string, p *G1Point, x, y string) {
+	xP, yP := p.affine()
+	expectedX, expectedY := parseFp(x), parseFp(y)
+	if xP.Eq(&expectedX) != 1 || yP.Eq(&expectedY) != 1 {
+		t.Errorf("%s: incorrect point", name)
+	}
+}
+
+func checkG2(t *testing.T, name string, p *G2Point, x, y string) {
+	xP, yP := p.affine()
+	expectedX, expectedY := parseFp2(x), parseFp2(y)
+	if xP.Eq(&expectedX) != 1 || yP.Eq(&expectedY) != 1 {
+		t.Errorf("%s: incorrect point", name)
+	}
+}
+
+func TestHashToG1Vectors(t *testing.T) {
+	for _, suite := range []string{"RO", "NU"} {
+		vectors := readHashVectors(t, "testdata/BLS12381G1_XMD-SHA-256_SSWU_"+suite+"_.json")
+		for i, v := range vectors.Vectors {
+			u, err := hashToFp([]byte(v.Msg), []byte(vectors.Dst), len(v.U))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j := range u {
+				expected := parseFp(v.U[j])
+				if u[j].Eq(&expected) != 1 {
+					t.Errorf("%s %d: incorrect u[%d]", suite, i, j)
+				}
+			}
+			hash := HashToG1
+			if suite == "NU" {
+				hash = EncodeToG1
+				checkG1(t, suite, mapToG1(&u[0]), v.Q.X, v.Q.Y)
+			} else {
+				checkG1(t, suite, mapToG1(&u[0]), v.Q0.X, v.Q0.Y)
+				checkG1(t, suite, mapToG1(&u[1]), v.Q1.X, v.Q1.Y)
+			}
+			p, err := hash([]byte(v.Msg), []byte(vectors.Dst))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkG1(t, suite, p, v.P.X, v.P.Y)
+		}
+	}
+}
+
+func TestHashToG2Vectors(t *testing.T) {
+	for _, suite := range []string{"RO", "NU"} {
+		vectors := readHashVectors(t, "testdata/BLS12381G2_XMD-SHA-256_SSWU_"+suite+"_.json")
+		for i, v := range vectors.Vectors {
+			u, err := hashToFp2([]byte(v.Msg), []byte(vectors.Dst), len(v.U))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j := range u {
+				expected := parseFp2(v.U[j])
+				if u[j].Eq(&expected) != 1 {
+					t.Errorf("%s %d: incorrect u[%d]", suite, i, j)
+				}
+			}
+			hash := HashToG2
+			if suite == "NU" {
+				hash = EncodeToG2
+				checkG2(t, suite, mapToG2(&u[0]), v.Q.X, v.Q.Y)
+			} else {
+				checkG2(t, suite, mapToG2(&u[0]), v.Q0.X, v.Q0.Y)
+				checkG2(t, suite, mapToG2(&u[1]), v.Q1.X, v.Q1.Y)
+			}
+			p, err := hash([]byte(v.Msg), []byte(vectors.Dst))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkG2(t, suite, p, v.P.X, v.P.Y)
+		}
+	}
+}
+
+func TestHashIsInSubgroup(t *testing.T) {
+	for _, msg := range []string{"", "abc", "a longer message to hash"} {
+		p1, _ := HashToG1([]byte(msg), []byte("test"))
+		p2, _ := HashToG2([]byte(msg), []byte("test"))
+		if !p1.IsTorsionFree() || !p2.IsTorsionFree() {
+			t.Errorf("%q: hash should lie in the prime order subgroup", msg)
+		}
+	}
+}
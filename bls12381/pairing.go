@@ -0,0 +1,200 @@
+package bls12381
+
+import "github.com/cronokirby/kyokusen/internal/pairing"
+
+// xAbs is the absolute value of the BLS parameter x = -0xD201000000010000.
+const xAbs uint64 = 0xD201000000010000
+
+// GTBytes is the number of bytes in the encoding of an element of GT.
+//
+// Each element is written as its 12 coefficients over the base field, from
+// the highest to the lowest, with each coefficient taking 48 Big Endian bytes.
+const GTBytes = fp12Bytes
+
+// GT represents an element of the target group of the pairing.
+//
+// This is the subgroup of order r of the multiplicative group of Fp12.
+type GT = pairing.GT[fp12, *fp12, *Scalar, gtParams]
+
+// gtParams holds the constants needed by GT.
+type gtParams struct{}
+
+func (gtParams) One() fp12 {
+	return fp12One()
+}
+
+func (gtParams) Order() []byte {
+	return rBytes
+}
+
+func (gtParams) Prefix() string {
+	return "bls12381"
+}
+
+// NewGT returns the identity element of GT, 1.
+func NewGT() *GT {
+	return pairing.NewGT[fp12, *fp12, *Scalar, gtParams]()
+}
+
+// The lines in the Miller loop pass through points on the twist, and are evaluated at a point P = (xP, yP).
+//
+// Mapping the twist back onto the curve over Fp12 sends (x, y) to (x / w^2, y / w^3),
+// and the line yP - y - lambda (xP - x) with slope lambda through (x, y) becomes,
+// after scaling by w^3, (lambda x - y) - lambda xP v + yP v w. Multiplying this
+// by the denominator of lambda lets us avoid inversions. All of these scaling factors
+// lie in a subfield, so they disappear after the final exponentiation.
+
+// doubleStep sets T <- 2 T, multiplying f by the tangent line at T, evaluated at P.
+//
+// T is given in Jacobian coordinates (X : Y : Z), corresponding to the affine point
+// (X / Z^2, Y / Z^3), and this uses the "dbl-2009-l" formulas from
+// https://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html.
+func doubleStep(f *fp12, t *[3]fp2, p *[2]fp) {
+	x, y, z := &t[0], &t[1], &t[2]
+	a := new(fp2).Set(x).Square()
+	b := new(fp2).Set(y).Square()
+	c := new(fp2).Set(b).Square()
+	d := new(fp2).Set(x).Add(b).Square().Sub(a).Sub(c).Double()
+	e := new(fp2).Set(a).Double().Add(a)
+	zz := new(fp2).Set(z).Square()
+
+	// The slope is 3 x^2 / 2 y = E / 2 Y Z, and we scale the line by 2 Y Z^3.
+	l0 := new(fp2).Set(e).Mul(x).Sub(new(fp2).Set(b).Double())
+	l1 := new(fp2).Set(e).Mul(zz).MulFp(&p[0]).Negate()
+	z.Mul(y).Double()
+	l4 := new(fp2).Set(z).Mul(zz).MulFp(&p[1])
+	f.mulByLine(l0, l1, l4)
+
+	x.Set(e).Square().Sub(d).Sub(d)
+	c.Double().Double().Double()
+	y.Set(d).Sub(x).Mul(e).Sub(c)
+}
+
+// addStep sets T <- T + Q, multiplying f by the line through T and Q, evaluated at P.
+//
+// T is given in Jacobian coordinates, and Q in affine coordinates. This uses the
+// "madd-2007-bl" formulas from https://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html,
+// which require that T != Q, and T != -Q.
+func addStep(f *fp12, t *[3]fp2, q *[2]fp2, p *[2]fp) {
+	x, y, z := &t[0], &t[1], &t[2]
+	xQ, yQ := &q[0], &q[1]
+	zz := new(fp2).Set(z).Square()
+	h := new(fp2).Set(xQ).Mul(zz).Sub(x)
+	r := new(fp2).Set(yQ).Mul(z).Mul(zz).Sub(y)
+	z.Mul(h)
+
+	// The slope is R / Z H, and we scale the line through Q by Z H.
+	l0 := new(fp2).Set(r).Mul(xQ).Sub(new(fp2).Set(yQ).Mul(z))
+	l1 := new(fp2).Set(r).MulFp(&p[0]).Negate()
+	l4 := new(fp2).Set(z).MulFp(&p[1])
+	f.mulByLine(l0, l1, l4)
+
+	hh := new(fp2).Set(h).Square()
+	hhh := new(fp2).Set(hh).Mul(h)
+	v := new(fp2).Set(x).Mul(hh)
+	x.Set(r).Square().Sub(hhh).Sub(v).Sub(v)
+	y.Mul(hhh)
+	y.Set(v.Sub(x).Mul(r).Sub(y))
+}
+
+// millerLoop calculates the product of the Miller loops of the optimal ate pairing, for several pairs of points.
+//
+// The points are given in affine coordinates, and none of them may be the identity.
+// Since every point in G2 has order r > |x|, we never hit any exceptional cases.
+func millerLoop(ps [][2]fp, qs [][2]fp2) fp12 {
+	f := fp12One()
+	ts := make([][3]fp2, len(qs))
+	for j := range qs {
+		ts[j] = [3]fp2{qs[j][0], qs[j][1], fp2One()}
+	}
+	for i := 62; i >= 0; i-- {
+		f.Square()
+		for j := range ts {
+			doubleStep(&f, &ts[j], &ps[j])
+		}
+		if (xAbs>>i)&1 == 0 {
+			continue
+		}
+		for j := range ts {
+			addStep(&f, &ts[j], &qs[j], &ps[j])
+		}
+	}
+	// Since x is negative, we need to invert the result. After the final exponentiation,
+	// this is the same as conjugating, which is much cheaper.
+	return *f.Conjugate()
+}
+
+// expByX sets f <- f^x, returning f, assuming that f lies in the cyclotomic subgroup.
+func expByX(f *fp12) *fp12 {
+	base := *f
+	for i := 62; i >= 0; i-- {
+		f.CyclotomicSquare()
+		if (xAbs>>i)&1 == 1 {
+			f.Mul(&base)
+		}
+	}
+	// Since x is negative, we conjugate, which is the same as inverting.
+	return f.Conjugate()
+}
+
+// finalExponentiation sets f <- f^(3 (p^12 - 1) / r), returning f.
+//
+// The factor of 3 comes from the fast hard part formulas, also used by other
+// implementations, such as zkcrypto and blst: since it's coprime to r, this is
+// still a valid pairing, and including it means that our elements of GT match theirs.
+func finalExponentiation(f *fp12) *fp12 {
+	// The easy part is raising to (p^6 - 1)(p^2 + 1), which sends f into the cyclotomic subgroup.
+	pairing.EasyPart[fp12](f)
+	t := *f
+	f.Frobenius().Frobenius().Mul(&t)
+
+	// The hard part is raising to 3 (p^4 - p^2 + 1) / r = (x - 1)^2 (x + p)(x^2 + p^2 - 1) + 3.
+	// This follows algorithm 5.5.4 of "Guide to Pairing-Based Cryptography", with
+	// the decomposition from https://eprint.iacr.org/2016/130.
+	t2 := *f
+	t1 := *new(fp12).Set(&t2).CyclotomicSquare().Conjugate()
+	t3 := *expByX(new(fp12).Set(&t2))
+	t4 := *new(fp12).Set(&t3).CyclotomicSquare()
+	t5 := *t1.Mul(&t3)
+	t1 = *expByX(new(fp12).Set(&t5))
+	t0 := *expByX(new(fp12).Set(&t1))
+	t6 := *expByX(new(fp12).Set(&t0))
+	t6.Mul(&t4)
+	t4 = *expByX(new(fp12).Set(&t6))
+	t4.Mul(t5.Conjugate()).Mul(&t2)
+	t5 = *new(fp12).Set(&t2).Conjugate()
+	t1.Mul(&t2).Frobenius().Frobenius().Frobenius()
+	t6.Mul(&t5).Frobenius()
+	t3.Mul(&t0).Frobenius().Frobenius()
+	t3.Mul(&t1).Mul(&t6)
+	return f.Set(&t3).Mul(&t4)
+}
+
+// Pairing calculates the optimal ate pairing e(P, Q).
+func Pairing(P *G1Point, Q *G2Point) *GT {
+	return MultiPairing([]*G1Point{P}, []*G2Point{Q})
+}
+
+// MultiPairing calculates the product of the pairings e(P_i, Q_i).
+//
+// This is much faster than calculating each pairing separately, since the
+// final exponentiation is shared. Pairs where either point is the identity
+// are skipped, since they don't contribute to the product.
+func MultiPairing(ps []*G1Point, qs []*G2Point) *GT {
+	if len(ps) != len(qs) {
+		panic("bls12381.MultiPairing: mismatched number of points")
+	}
+	affineP := make([][2]fp, 0, len(ps))
+	affineQ := make([][2]fp2, 0, len(qs))
+	for i := range ps {
+		if ps[i].IsIdentity() || qs[i].IsIdentity() {
+			continue
+		}
+		xP, yP := ps[i].affine()
+		xQ, yQ := qs[i].affine()
+		affineP = append(affineP, [2]fp{xP, yP})
+		affineQ = append(affineQ, [2]fp2{xQ, yQ})
+	}
+	f := millerLoop(affineP, affineQ)
+	return NewGT().SetFp12(finalExponentiation(&f))
+}
@@ -0,0 +1,182 @@
+package bls12381
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen/internal/pairing"
+)
+
+// gtGenerator is the encoding of e(G1, G2), matching other implementations, such as zkcrypto.
+var gtGenerator = mustDecodeHex("0f41e58663bf08cf068672cbd01a7ec73baca4d72ca93544deff686bfd6df543d48eaa24afe47e1efde449383b67663104c581234d086a9902249b64728ffd21a189e87935a954051c7cdba7b3872629a4fafc05066245cb9108f0242d0fe3ef03350f55a7aefcd3c31b4fcb6ce5771cc6a0e9786ab5973320c806ad360829107ba810c5a09ffdd9be2291a0c25a99a211b8b424cd48bf38fcef68083b0b0ec5c81a93b330ee1a677d0d15ff7b984e8978ef48881e32fac91b93b47333e2ba5706fba23eb7c5af0d9f80940ca771b6ffd5857baaf222eb95a7d2809d61bfe02e1bfd1b68ff02f0b8102ae1c2d5d5ab1a19f26337d205fb469cd6bd15c3d5a04dc88784fbb3d0b2dbdea54d43b2b73f2cbb12d58386a8703e0f948226e47ee89d018107154f25a764bd3c79937a45b84546da634b8f6be14a8061e55cceba478b23f7dacaa35c8ca78beae9624045b4b601b2f522473d171391125ba84dc4007cfbf2f8da752f7c74185203fcca589ac719c34dffbbaad8431dad1c1fb597aaa5193502b86edb8857c273fa075a50512937e0794e1e65a7617c90d8bd66065b1fffe51d7a579973b1315021ec3c19934f1368bb445c7c2d209703f239689ce34c0378a68e72a6b3b216da0e22a5031b54ddff57309396b38c881c4c849ec23e87089a1c5b46e5110b86750ec6a532348868a84045483c92b7af5af689452eafabf1a8943e50439f1d59882a98eaa0170f1250ebd871fc0a92a7b2d83168d0d727272d441befa15c503dd8e90ce98db3e7b6d194f60839c508a84305aaca1789b6")
+
+func TestPairingOfGenerators(t *testing.T) {
+	actual, _ := Pairing(g1Generator, g2Generator).MarshalBinary()
+	if !bytes.Equal(actual, gtGenerator) {
+		t.Errorf("incorrect pairing: %x", actual)
+	}
+	decoded := NewGT()
+	if err := decoded.UnmarshalBinary(gtGenerator); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(Pairing(g1Generator, g2Generator)) {
+		t.Error("decoded value doesn't match")
+	}
+}
+
+func TestPairingIsBilinear(t *testing.T) {
+	err := quick.Check(func(a, b *Scalar) bool {
+		aP := a.Act(g1Generator).(*G1Point)
+		bQ := b.Act(g2Generator).(*G2Point)
+		ab := NewScalar().Set(a).Mul(b).(*Scalar)
+		expected := Pairing(g1Generator, g2Generator).Exp(ab)
+		return Pairing(aP, bQ).Equal(expected) &&
+			Pairing(ab.Act(g1Generator).(*G1Point), g2Generator).Equal(expected)
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPairingWithIdentity(t *testing.T) {
+	if !Pairing(NewG1Point(), g2Generator).IsOne() {
+		t.Error("e(O, G2) should be 1")
+	}
+	if !Pairing(g1Generator, NewG2Point()).IsOne() {
+		t.Error("e(G1, O) should be 1")
+	}
+	if Pairing(g1Generator, g2Generator).IsOne() {
+		t.Error("e(G1, G2) should not be 1")
+	}
+}
+
+func TestMultiPairingCancels(t *testing.T) {
+	err := quick.Check(func(P *G1Point, Q *G2Point) bool {
+		return MultiPairing([]*G1Point{P, P.Negate().(*G1Point)}, []*G2Point{Q, Q}).IsOne()
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGTMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(s *Scalar) bool {
+		x := Pairing(g1Generator, g2Generator).Exp(s)
+		data, err := x.MarshalBinary()
+		if err != nil || len(data) != GTBytes {
+			return false
+		}
+		decoded := NewGT()
+		return decoded.UnmarshalBinary(data) == nil && decoded.Equal(x)
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGTRejectsNonSubgroup(t *testing.T) {
+	// 2 is an element of Fp12, but it doesn't have order r.
+	data := make([]byte, GTBytes)
+	data[len(data)-1] = 2
+	if NewGT().UnmarshalBinary(data) == nil {
+		t.Error("2 should not be accepted as an element of GT")
+	}
+}
+
+// eip2537G1 converts an EIP-2537 encoding of a G1 point into a G1Point.
+//
+// Each coordinate is padded to 64 bytes, and the point at infinity is all zeros.
+func eip2537G1(data []byte) (*G1Point, error) {
+	if allZero(data) {
+		return NewG1Point(), nil
+	}
+	uncompressed := append(append([]byte{}, data[16:64]...), data[80:128]...)
+	p := NewG1Point()
+	return p, p.UnmarshalBinary(uncompressed)
+}
+
+// eip2537G2 converts an EIP-2537 encoding of a G2 point into a G2Point.
+//
+// Each element of Fp is padded to 64 bytes, with c0 before c1.
+func eip2537G2(data []byte) (*G2Point, error) {
+	if allZero(data) {
+		return NewG2Point(), nil
+	}
+	var uncompressed []byte
+	for _, i := range []int{1, 0, 3, 2} {
+		uncompressed = append(uncompressed, data[64*i+16:64*(i+1)]...)
+	}
+	p := NewG2Point()
+	return p, p.UnmarshalBinary(uncompressed)
+}
+
+// TestPairingVectors checks against the pairing check vectors for the EIP-2537 precompile.
+//
+// Each input is a list of pairs of points, and the expected output is 1
+// exactly when the product of their pairings is 1.
+func TestPairingVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/pairing.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []struct {
+		Name     string
+		Input    string
+		Expected string
+	}
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range vectors {
+		input, _ := hex.DecodeString(v.Input)
+		expected, _ := hex.DecodeString(v.Expected)
+		var ps []*G1Point
+		var qs []*G2Point
+		for ; len(input) > 0; input = input[384:] {
+			p, err := eip2537G1(input[:128])
+			if err != nil {
+				t.Fatalf("%s: %v", v.Name, err)
+			}
+			q, err := eip2537G2(input[128:384])
+			if err != nil {
+				t.Fatalf("%s: %v", v.Name, err)
+			}
+			ps = append(ps, p)
+			qs = append(qs, q)
+		}
+		if MultiPairing(ps, qs).IsOne() != (expected[len(expected)-1] == 1) {
+			t.Errorf("%s: incorrect result", v.Name)
+		}
+	}
+}
+
+func TestFinalExponentiationMatchesExp(t *testing.T) {
+	// 3 (p^12 - 1) / r = (p^6 - 1) (3 (p^2 + 1)(p^4 - p^2 + 1) / r)
+	p := new(big.Int).SetBytes(pModulus.Bytes())
+	p2 := new(big.Int).Mul(p, p)
+	p4 := new(big.Int).Mul(p2, p2)
+	hard := new(big.Int).Sub(p4, p2)
+	hard.Add(hard, big.NewInt(1))
+	hard.Div(hard, new(big.Int).SetBytes(r.Bytes()))
+	hard.Mul(hard, big.NewInt(3))
+	exponent := hard.Mul(hard, p2.Add(p2, big.NewInt(1))).Bytes()
+
+	err := quick.Check(func(f fp12) bool {
+		expected := pairing.EasyPart[fp12](new(fp12).Set(&f)).Exp(exponent)
+		return finalExponentiation(&f).Eq(expected) == 1
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkPairing(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Pairing(g1Generator, g2Generator)
+	}
+}
@@ -0,0 +1,155 @@
+package bls12381
+
+import (
+	"errors"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// r is the prime order of the groups G1, G2, and GT.
+//
+// Like p, this is initialized directly, so that other init functions can use it.
+var r, _ = saferith.ModulusFromHex("73EDA753299D7D483339D80809A1D80553BDA402FFFE5BFEFFFFFFFF00000001")
+
+// ScalarBytes is the number of bytes in the encoding of a scalar.
+const ScalarBytes = 32
+
+// Scalar represents an integer modulo the order of the BLS12-381 groups.
+//
+// The same type of scalar is used for both G1 and G2: Act works with points
+// from either group. Each scalar remembers which group it was created for,
+// which decides the result of Curve and ActOnBase.
+type Scalar struct {
+	nat saferith.Nat
+	g2  bool
+}
+
+func (s *Scalar) String() string {
+	return s.nat.String()
+}
+
+// NewScalar returns a new scalar, with the value 0, associated with G1.
+func NewScalar() *Scalar {
+	var nat saferith.Nat
+	nat.Mod(&nat, r)
+	return &Scalar{nat: nat}
+}
+
+// newG2Scalar returns a new scalar, with the value 0, associated with G2.
+func newG2Scalar() *Scalar {
+	s := NewScalar()
+	s.g2 = true
+	return s
+}
+
+// castScalar converts a scalar implementing the generic interface to this specific type.
+//
+// Since implementors of the Scalar interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+func castScalar(s kyokusen.Scalar) *Scalar {
+	casted, ok := s.(*Scalar)
+	if !ok {
+		panic("failed to cast type to *bls12381.Scalar")
+	}
+	return casted
+}
+
+// MarshalBinary returns the contents of this scalar as Big Endian bytes.
+func (s *Scalar) MarshalBinary() ([]byte, error) {
+	return s.nat.FillBytes(make([]byte, ScalarBytes)), nil
+}
+
+// UnmarshalBinary deserializes Big Endian bytes into this scalar.
+func (s *Scalar) UnmarshalBinary(data []byte) error {
+	if len(data) != ScalarBytes {
+		return errors.New("bls12381.Scalar.UnmarshalBinary: invalid data length")
+	}
+	var nat saferith.Nat
+	nat.SetBytes(data)
+	if _, _, lt := nat.CmpMod(r); lt != 1 {
+		return errors.New("bls12381.Scalar.UnmarshalBinary: value is greater than order")
+	}
+	s.nat.Mod(&nat, r)
+	return nil
+}
+
+// Curve returns the group this scalar is associated with, either G1 or G2.
+func (s *Scalar) Curve() kyokusen.Curve {
+	if s.g2 {
+		return G2Curve{}
+	}
+	return G1Curve{}
+}
+
+func (s1 *Scalar) Add(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar(other)
+	s1.nat.ModAdd(&s1.nat, &s2.nat, r)
+	return s1
+}
+
+func (s1 *Scalar) Sub(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar(other)
+	s1.nat.ModSub(&s1.nat, &s2.nat, r)
+	return s1
+}
+
+func (s1 *Scalar) Negate() kyokusen.Scalar {
+	s1.nat.ModNeg(&s1.nat, r)
+	return s1
+}
+
+func (s1 *Scalar) Mul(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar(other)
+	s1.nat.ModMul(&s1.nat, &s2.nat, r)
+	return s1
+}
+
+func (s1 *Scalar) Invert() kyokusen.Scalar {
+	s1.nat.ModInverse(&s1.nat, r)
+	return s1
+}
+
+func (s1 *Scalar) Equal(other kyokusen.Scalar) bool {
+	s2 := castScalar(other)
+	return s1.nat.Eq(&s2.nat) == 1
+}
+
+func (s1 *Scalar) IsZero() bool {
+	return s1.nat.EqZero() == 1
+}
+
+// Set sets the value of this scalar to that of another.
+//
+// This scalar stays associated with the same group.
+func (s1 *Scalar) Set(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar(other)
+	s1.nat.SetNat(&s2.nat)
+	return s1
+}
+
+func (s1 *Scalar) SetNat(other *saferith.Nat) kyokusen.Scalar {
+	s1.nat.Mod(other, r)
+	return s1
+}
+
+// Act calculates s * P, in constant-time, where P is either a G1Point or a G2Point.
+func (s *Scalar) Act(other kyokusen.Point) kyokusen.Point {
+	bytes, _ := s.MarshalBinary()
+	switch P := other.(type) {
+	case *G1Point:
+		return P.mul(bytes)
+	case *G2Point:
+		return P.mul(bytes)
+	default:
+		panic("failed to cast type to a bls12381 point")
+	}
+}
+
+// ActOnBase calculates s * G, where G is the generator of the group this scalar is associated with.
+func (s *Scalar) ActOnBase() kyokusen.Point {
+	if s.g2 {
+		return s.Act(g2Generator)
+	}
+	return s.Act(g1Generator)
+}
@@ -0,0 +1,205 @@
+package bls12381
+
+import (
+	"github.com/cronokirby/kyokusen/internal/pairing"
+	"github.com/cronokirby/kyokusen/rfc9380"
+	"github.com/cronokirby/saferith"
+)
+
+// This file implements the mappings from field elements to points used by
+// hash-to-curve, following sections 6.6.2 and 6.6.3 of RFC 9380.
+//
+// Since both curves have j-invariant 0, the simplified SWU map can't be used
+// directly. Instead, we map to a curve isogenous to each, with non-zero A and B,
+// and then apply an isogeny to get a point on the original curve. All of the
+// constants come from appendix E of RFC 9380.
+
+// g1EffectiveCofactor is h_eff for G1, as Big Endian bytes.
+//
+// Multiplying by this is enough to clear the cofactor, and is what RFC 9380 prescribes.
+var g1EffectiveCofactor = mustDecodeHex("D201000000010001")
+
+// g2EffectiveCofactor is h_eff for G2, as Big Endian bytes.
+//
+// This is a multiple of the cofactor of G2, chosen so that multiplication by
+// it can be done efficiently using an endomorphism. We just multiply by it directly.
+var g2EffectiveCofactor = mustDecodeHex("0BC69F08F2EE75B3584C6A0EA91B352888E2A8E9145AD7689986FF031508FFE1329C2F178731DB956D82BF015D1212B02EC0EC69D7477C1AE954CBC06689F6A359894C0ADEBBF6B4E8020005AAA95551")
+
+// fp2FromHex creates an element of Fp2 from the Big Endian hex strings of c0 and c1.
+func fp2FromHex(c0, c1 string) fp2 {
+	return fp2{c0: fpFromHex(c0), c1: fpFromHex(c1)}
+}
+
+var (
+	// g1IsoA and g1IsoB are the parameters of the curve y^2 = x^3 + A x + B, which is 11-isogenous to the curve of G1.
+	g1IsoA = fpFromHex("144698A3B8E9433D693A02C96D4982B0EA985383EE66A8D8E8981AEFD881AC98936F8DA0E0F97F5CF428082D584C1D")
+	g1IsoB = fpFromHex("12E2908D11688030018B12E8753EEE3B2016C1F0F24F4070A0B9C14FCEF35EF55A23215A316CEAA5D1CC48E98E172BE0")
+	// g1Z is the constant Z used by the simplified SWU map for G1.
+	g1Z = fpFromHex("0B")
+)
+
+// g1IsoCoeffs are the coefficients of the 11-isogeny mapping the isogenous curve to the curve of G1.
+//
+// Each polynomial is given by its coefficients, starting with the constant term.
+var g1IsoCoeffs = struct {
+	xNum, xDen, yNum, yDen []fp
+}{
+	xNum: []fp{
+		fpFromHex("11A05F2B1E833340B809101DD99815856B303E88A2D7005FF2627B56CDB4E2C85610C2D5F2E62D6EAEAC1662734649B7"),
+		fpFromHex("17294ED3E943AB2F0588BAB22147A81C7C17E75B2F6A8417F565E33C70D1E86B4838F2A6F318C356E834EEF1B3CB83BB"),
+		fpFromHex("0D54005DB97678EC1D1048C5D10A9A1BCE032473295983E56878E501EC68E25C958C3E3D2A09729FE0179F9DAC9EDCB0"),
+		fpFromHex("1778E7166FCC6DB74E0609D307E55412D7F5E4656A8DBF25F1B33289F1B330835336E25CE3107193C5B388641D9B6861"),
+		fpFromHex("0E99726A3199F4436642B4B3E4118E5499DB995A1257FB3F086EEB65982FAC18985A286F301E77C451154CE9AC8895D9"),
+		fpFromHex("1630C3250D7313FF01D1201BF7A74AB5DB3CB17DD952799B9ED3AB9097E68F90A0870D2DCAE73D19CD13C1C66F652983"),
+		fpFromHex("0D6ED6553FE44D296A3726C38AE652BFB11586264F0F8CE19008E218F9C86B2A8DA25128C1052ECADDD7F225A139ED84"),
+		fpFromHex("17B81E7701ABDBE2E8743884D1117E53356DE5AB275B4DB1A682C62EF0F2753339B7C8F8C8F475AF9CCB5618E3F0C88E"),
+		fpFromHex("080D3CF1F9A78FC47B90B33563BE990DC43B756CE79F5574A2C596C928C5D1DE4FA295F296B74E956D71986A8497E317"),
+		fpFromHex("169B1F8E1BCFA7C42E0C37515D138F22DD2ECB803A0C5C99676314BAF4BB1B7FA3190B2EDC0327797F241067BE390C9E"),
+		fpFromHex("10321DA079CE07E272D8EC09D2565B0DFA7DCCDDE6787F96D50AF36003B14866F69B771F8C285DECCA67DF3F1605FB7B"),
+		fpFromHex("06E08C248E260E70BD1E962381EDEE3D31D79D7E22C837BC23C0BF1BC24C6B68C24B1B80B64D391FA9C8BA2E8BA2D229"),
+	},
+	xDen: []fp{
+		fpFromHex("08CA8D548CFF19AE18B2E62F4BD3FA6F01D5EF4BA35B48BA9C9588617FC8AC62B558D681BE343DF8993CF9FA40D21B1C"),
+		fpFromHex("12561A5DEB559C4348B4711298E536367041E8CA0CF0800C0126C2588C48BF5713DAA8846CB026E9E5C8276EC82B3BFF"),
+		fpFromHex("0B2962FE57A3225E8137E629BFF2991F6F89416F5A718CD1FCA64E00B11ACEACD6A3D0967C94FEDCFCC239BA5CB83E19"),
+		fpFromHex("03425581A58AE2FEC83AAFEF7C40EB545B08243F16B1655154CCA8ABC28D6FD04976D5243EECF5C4130DE8938DC62CD8"),
+		fpFromHex("13A8E162022914A80A6F1D5F43E7A07DFFDFC759A12062BB8D6B44E833B306DA9BD29BA81F35781D539D395B3532A21E"),
+		fpFromHex("0E7355F8E4E667B955390F7F0506C6E9395735E9CE9CAD4D0A43BCEF24B8982F7400D24BC4228F11C02DF9A29F6304A5"),
+		fpFromHex("0772CAACF16936190F3E0C63E0596721570F5799AF53A1894E2E073062AEDE9CEA73B3538F0DE06CEC2574496EE84A3A"),
+		fpFromHex("14A7AC2A9D64A8B230B3F5B074CF01996E7F63C21BCA68A81996E1CDF9822C580FA5B9489D11E2D311F7D99BBDCC5A5E"),
+		fpFromHex("0A10ECF6ADA54F825E920B3DAFC7A3CCE07F8D1D7161366B74100DA67F39883503826692ABBA43704776EC3A79A1D641"),
+		fpFromHex("095FC13AB9E92AD4476D6E3EB3A56680F682B4EE96F7D03776DF533978F31C1593174E4B4B7865002D6384D168ECDD0A"),
+		fpOne,
+	},
+	yNum: []fp{
+		fpFromHex("090D97C81BA24EE0259D1F094980DCFA11AD138E48A869522B52AF6C956543D3CD0C7AEE9B3BA3C2BE9845719707BB33"),
+		fpFromHex("134996A104EE5811D51036D776FB46831223E96C254F383D0F906343EB67AD34D6C56711962FA8BFE097E75A2E41C696"),
+		fpFromHex("00CC786BAA966E66F4A384C86A3B49942552E2D658A31CE2C344BE4B91400DA7D26D521628B00523B8DFE240C72DE1F6"),
+		fpFromHex("01F86376E8981C217898751AD8746757D42AA7B90EEB791C09E4A3EC03251CF9DE405ABA9EC61DECA6355C77B0E5F4CB"),
+		fpFromHex("08CC03FDEFE0FF135CAF4FE2A21529C4195536FBE3CE50B879833FD221351ADC2EE7F8DC099040A841B6DAECF2E8FEDB"),
+		fpFromHex("16603FCA40634B6A2211E11DB8F0A6A074A7D0D4AFADB7BD76505C3D3AD5544E203F6326C95A807299B23AB13633A5F0"),
+		fpFromHex("04AB0B9BCFAC1BBCB2C977D027796B3CE75BB8CA2BE184CB5231413C4D634F3747A87AC2460F415EC961F8855FE9D6F2"),
+		fpFromHex("0987C8D5333AB86FDE9926BD2CA6C674170A05BFE3BDD81FFD038DA6C26C842642F64550FEDFE935A15E4CA31870FB29"),
+		fpFromHex("09FC4018BD96684BE88C9E221E4DA1BB8F3ABD16679DC26C1E8B6E6A1F20CABE69D65201C78607A360370E577BDBA587"),
+		fpFromHex("0E1BBA7A1186BDB5223ABDE7ADA14A23C42A0CA7915AF6FE06985E7ED1E4D43B9B3F7055DD4EBA6F2BAFAAEBCA731C30"),
+		fpFromHex("19713E47937CD1BE0DFD0B8F1D43FB93CD2FCBCB6CAF493FD1183E416389E61031BF3A5CCE3FBAFCE813711AD011C132"),
+		fpFromHex("18B46A908F36F6DEB918C143FED2EDCC523559B8AAF0C2462E6BFE7F911F643249D9CDF41B44D606CE07C8A4D0074D8E"),
+		fpFromHex("0B182CAC101B9399D155096004F53F447AA7B12A3426B08EC02710E807B4633F06C851C1919211F20D4C04F00B971EF8"),
+		fpFromHex("0245A394AD1ECA9B72FC00AE7BE315DC757B3B080D4C158013E6632D3C40659CC6CF90AD1C232A6442D9D3F5DB980133"),
+		fpFromHex("05C129645E44CF1102A159F748C4A3FC5E673D81D7E86568D9AB0F5D396A7CE46BA1049B6579AFB7866B1E715475224B"),
+		fpFromHex("15E6BE4E990F03CE4EA50B3B42DF2EB5CB181D8F84965A3957ADD4FA95AF01B2B665027EFEC01C7704B456BE69C8B604"),
+	},
+	yDen: []fp{
+		fpFromHex("16112C4C3A9C98B252181140FAD0EAE9601A6DE578980BE6EEC3232B5BE72E7A07F3688EF60C206D01479253B03663C1"),
+		fpFromHex("1962D75C2381201E1A0CBD6C43C348B885C84FF731C4D59CA4A10356F453E01F78A4260763529E3532F6102C2E49A03D"),
+		fpFromHex("058DF3306640DA276FAAAE7D6E8EB15778C4855551AE7F310C35A5DD279CD2ECA6757CD636F96F891E2538B53DBF67F2"),
+		fpFromHex("16B7D288798E5395F20D23BF89EDB4D1D115C5DBDDBCD30E123DA489E726AF41727364F2C28297ADA8D26D98445F5416"),
+		fpFromHex("0BE0E079545F43E4B00CC912F8228DDCC6D19C9F0F69BBB0542EDA0FC9DEC916A20B15DC0FD2EDEDDA39142311A5001D"),
+		fpFromHex("08D9E5297186DB2D9FB266EAAC783182B70152C65550D881C5ECD87B6F0F5A6449F38DB9DFA9CCE202C6477FAAF9B7AC"),
+		fpFromHex("166007C08A99DB2FC3BA8734ACE9824B5EECFDFA8D0CF8EF5DD365BC400A0051D5FA9C01A58B1FB93D1A1399126A775C"),
+		fpFromHex("16A3EF08BE3EA7EA03BCDDFABBA6FF6EE5A4375EFA1F4FD7FEB34FD206357132B920F5B00801DEE460EE415A15812ED9"),
+		fpFromHex("1866C8ED336C61231A1BE54FD1D74CC4F9FB0CE4C6AF5920ABC5750C4BF39B4852CFE2F7BB9248836B233D9D55535D4A"),
+		fpFromHex("167A55CDA70A6E1CEA820597D94A84903216F763E13D87BB5308592E7EA7D4FBC7385EA3D529B35E346EF48BB8913F55"),
+		fpFromHex("04D2F259EEA405BD48F010A01AD2911D9C6DD039BB61A6290E591B36E636A5C871A5C29F4F83060400F8B49CBA8F6AA8"),
+		fpFromHex("0ACCBB67481D033FF5852C1E48C50C477F94FF8AEFCE42D28C0F9A88CEA7913516F968986F7EBBEA9684B529E2561092"),
+		fpFromHex("0AD6B9514C767FE3C3613144B45F1496543346D98ADF02267D5CEEF9A00D9B8693000763E3B90AC11E99B138573345CC"),
+		fpFromHex("02660400EB2E4F3B628BDD0D53CD76F2BF565B94E72927C1CB748DF27942480E420517BD8714CC80D1FADC1326ED06F7"),
+		fpFromHex("0E0FA1D816DDC03E6B24255E0D7819C171C40F65E273B853324EFCD6356CAA205CA2F570F13497804415473A1D634B8F"),
+		fpOne,
+	},
+}
+
+var (
+	// g2IsoA and g2IsoB are the parameters of the curve y^2 = x^3 + A x + B, which is 3-isogenous to the curve of G2.
+	g2IsoA = fp2FromHex("00", "F0")
+	g2IsoB = fp2FromHex("03F4", "03F4")
+	// g2Z is the constant Z = -(2 + u) used by the simplified SWU map for G2.
+	g2Z = fp2FromHex("1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFAAA9", "1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFAAAA")
+)
+
+// g2IsoCoeffs are the coefficients of the 3-isogeny mapping the isogenous curve to the curve of G2.
+//
+// This has the same shape as g1IsoCoeffs, with coefficients in Fp2.
+var g2IsoCoeffs = struct {
+	xNum, xDen, yNum, yDen []fp2
+}{
+	xNum: []fp2{
+		fp2FromHex("5C759507E8E333EBB5B7A9A47D7ED8532C52D39FD3A042A88B58423C50AE15D5C2638E343D9C71C6238AAAAAAAA97D6", "5C759507E8E333EBB5B7A9A47D7ED8532C52D39FD3A042A88B58423C50AE15D5C2638E343D9C71C6238AAAAAAAA97D6"),
+		fp2FromHex("00", "11560BF17BAA99BC32126FCED787C88F984F87ADF7AE0C7F9A208C6B4F20A4181472AAA9CB8D555526A9FFFFFFFFC71A"),
+		fp2FromHex("11560BF17BAA99BC32126FCED787C88F984F87ADF7AE0C7F9A208C6B4F20A4181472AAA9CB8D555526A9FFFFFFFFC71E", "8AB05F8BDD54CDE190937E76BC3E447CC27C3D6FBD7063FCD104635A790520C0A395554E5C6AAAA9354FFFFFFFFE38D"),
+		fp2FromHex("171D6541FA38CCFAED6DEA691F5FB614CB14B4E7F4E810AA22D6108F142B85757098E38D0F671C7188E2AAAAAAAA5ED1", "00"),
+	},
+	xDen: []fp2{
+		fp2FromHex("00", "1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFAA63"),
+		fp2FromHex("0C", "1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFAA9F"),
+		fp2One(),
+	},
+	yNum: []fp2{
+		fp2FromHex("1530477C7AB4113B59A4C18B076D11930F7DA5D4A07F649BF54439D87D27E500FC8C25EBF8C92F6812CFC71C71C6D706", "1530477C7AB4113B59A4C18B076D11930F7DA5D4A07F649BF54439D87D27E500FC8C25EBF8C92F6812CFC71C71C6D706"),
+		fp2FromHex("00", "5C759507E8E333EBB5B7A9A47D7ED8532C52D39FD3A042A88B58423C50AE15D5C2638E343D9C71C6238AAAAAAAA97BE"),
+		fp2FromHex("11560BF17BAA99BC32126FCED787C88F984F87ADF7AE0C7F9A208C6B4F20A4181472AAA9CB8D555526A9FFFFFFFFC71C", "8AB05F8BDD54CDE190937E76BC3E447CC27C3D6FBD7063FCD104635A790520C0A395554E5C6AAAA9354FFFFFFFFE38F"),
+		fp2FromHex("124C9AD43B6CF79BFBF7043DE3811AD0761B0F37A1E26286B0E977C69AA274524E79097A56DC4BD9E1B371C71C718B10", "00"),
+	},
+	yDen: []fp2{
+		fp2FromHex("1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFA8FB", "1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFA8FB"),
+		fp2FromHex("00", "1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFA9D3"),
+		fp2FromHex("12", "1A0111EA397FE69A4B1BA7B6434BACD764774B84F38512BF6730D2A0F6B0F6241EABFFFEB153FFFFB9FEFFFFFFFFAA99"),
+		fp2One(),
+	},
+}
+
+// sgn0 returns the sign of a field element, as defined in section 4.1 of RFC 9380.
+func (z *fp) sgn0() saferith.Choice {
+	return z.IsOdd()
+}
+
+// sgn0 returns the sign of a field element, as defined in section 4.1 of RFC 9380.
+func (z *fp2) sgn0() saferith.Choice {
+	return z.c0.IsOdd() | (z.c0.EqZero() & z.c1.IsOdd())
+}
+
+// pointers returns a pointer to each element of a slice.
+func pointers[T any](xs []T) []*T {
+	out := make([]*T, len(xs))
+	for i := range xs {
+		out[i] = &xs[i]
+	}
+	return out
+}
+
+var (
+	// g1SSWU is the simplified SWU map to the curve isogenous to the curve of G1.
+	g1SSWU = rfc9380.NewSSWU(&g1IsoA, &g1IsoB, &g1Z, (*fp).Sqrt, (*fp).sgn0)
+	// g1Isogeny maps the isogenous curve to the curve of G1.
+	g1Isogeny = &rfc9380.Isogeny[fp, *fp]{
+		XNum: pointers(g1IsoCoeffs.xNum),
+		XDen: pointers(g1IsoCoeffs.xDen),
+		YNum: pointers(g1IsoCoeffs.yNum),
+		YDen: pointers(g1IsoCoeffs.yDen),
+	}
+	// g2SSWU is the simplified SWU map to the curve isogenous to the curve of G2.
+	g2SSWU = rfc9380.NewSSWU(&g2IsoA, &g2IsoB, &g2Z, (*fp2).Sqrt, (*fp2).sgn0)
+	// g2Isogeny maps the isogenous curve to the curve of G2.
+	g2Isogeny = &rfc9380.Isogeny[fp2, *fp2]{
+		XNum: pointers(g2IsoCoeffs.xNum),
+		XDen: pointers(g2IsoCoeffs.xDen),
+		YNum: pointers(g2IsoCoeffs.yNum),
+		YDen: pointers(g2IsoCoeffs.yDen),
+	}
+)
+
+// mapToG1 maps a field element to a point on the curve of G1, which might not lie in G1.
+func mapToG1(u *fp) *G1Point {
+	x, y, z := g1Isogeny.Map(g1SSWU.Map(u))
+	out := &G1Point{inner: pairing.Point[fp, *fp]{X: *x, Y: *y, Z: *z}}
+	// If either denominator vanishes, the isogeny sends the point to the identity.
+	return out.CondAssign(z.EqZero(), NewG1Point())
+}
+
+// mapToG2 maps a field element to a point on the curve of G2, which might not lie in G2.
+func mapToG2(u *fp2) *G2Point {
+	x, y, z := g2Isogeny.Map(g2SSWU.Map(u))
+	out := &G2Point{inner: pairing.Point[fp2, *fp2]{X: *x, Y: *y, Z: *z}}
+	// If either denominator vanishes, the isogeny sends the point to the identity.
+	return out.CondAssign(z.EqZero(), NewG2Point())
+}
@@ -0,0 +1,86 @@
+// Package bn254 implements the BN254 pairing friendly curve, also known as alt_bn128.
+//
+// This provides two groups, G1 and G2, each implementing kyokusen.Curve, and
+// sharing the same Scalar type. G1 is the curve y^2 = x^3 + 3 over the base
+// field, and G2 is a subgroup of a twist of that curve, over a quadratic extension.
+// Both have the same prime order r, and a bilinear pairing G1 x G2 -> GT is
+// provided by Pairing, MultiPairing, and PairingCheck.
+//
+// Points are encoded in the same way as the Ethereum precompiles for this curve,
+// described in EIP-196 and EIP-197. Decoding points checks that they lie in the
+// prime order subgroup.
+package bn254
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// G1Curve represents the group G1, implementing the kyokusen.Curve interface.
+type G1Curve struct{}
+
+func (G1Curve) NewPoint() kyokusen.Point {
+	return NewG1Point()
+}
+
+func (G1Curve) NewBasePoint() kyokusen.Point {
+	return NewG1BasePoint()
+}
+
+func (G1Curve) NewScalar() kyokusen.Scalar {
+	return NewScalar()
+}
+
+func (G1Curve) Name() string {
+	return "BN254 G1"
+}
+
+func (G1Curve) ScalarBits() int {
+	return 254
+}
+
+func (G1Curve) SafeScalarBytes() int {
+	return 64
+}
+
+func (G1Curve) Order() *saferith.Modulus {
+	return r
+}
+
+// G2Curve represents the group G2, implementing the kyokusen.Curve interface.
+type G2Curve struct{}
+
+func (G2Curve) NewPoint() kyokusen.Point {
+	return NewG2Point()
+}
+
+func (G2Curve) NewBasePoint() kyokusen.Point {
+	return NewG2BasePoint()
+}
+
+func (G2Curve) NewScalar() kyokusen.Scalar {
+	return newG2Scalar()
+}
+
+func (G2Curve) Name() string {
+	return "BN254 G2"
+}
+
+func (G2Curve) ScalarBits() int {
+	return 254
+}
+
+func (G2Curve) SafeScalarBytes() int {
+	return 64
+}
+
+func (G2Curve) Order() *saferith.Modulus {
+	return r
+}
+
+// Cofactor returns the cofactor of the twisted curve containing G2.
+//
+// This implements the kyokusen.CofactorCurve interface.
+func (G2Curve) Cofactor() *saferith.Nat {
+	return new(saferith.Nat).SetBytes(g2Cofactor)
+}
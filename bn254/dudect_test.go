@@ -0,0 +1,20 @@
+//go:build dudect
+
+package bn254
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/internal/dudect"
+)
+
+// These check that operations on secret values don't leak timing information:
+//
+//	go test -tags dudect -run ConstantTime -v ./bn254
+func TestG1ConstantTime(t *testing.T) {
+	dudect.TestCurve(t, G1Curve{})
+}
+
+func TestG2ConstantTime(t *testing.T) {
+	dudect.TestCurve(t, G2Curve{})
+}
@@ -0,0 +1,261 @@
+package bn254
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/cronokirby/kyokusen/internal/limbs"
+	"github.com/cronokirby/saferith"
+)
+
+// This file implements arithmetic modulo the 254 bit prime p, which the curve is defined over.
+//
+// Field elements are represented as 4 64 bit limbs, in little endian order,
+// in Montgomery form: we store x * 2^256 mod p, instead of x.
+
+// fpBytes is the number of bytes in the encoding of a field element.
+const fpBytes = 32
+
+// pLimbs is the prime p, in little endian order.
+var pLimbs = [4]uint64{0x3C208C16D87CFD47, 0x97816A916871CA8D, 0xB85045B68181585D, 0x30644E72E131A029}
+
+// pInv is -p^-1 mod 2^64, used in Montgomery reduction.
+const pInv = 0x87D20782E4866389
+
+// rSquared is 2^512 mod p, which converts numbers into Montgomery form.
+var rSquared = fp{0xF32CFC5B538AFA89, 0xB5E71911D44501FB, 0x47AB1EFF0A417FF6, 0x06D89F71CAB8351F}
+
+// fpOne is 1, in Montgomery form.
+var fpOne = fp{0xD35D438DC58F0D9D, 0x0A78EB28F5C70B3D, 0x666EA36F7879462C, 0x0E0A77C19A07DF2F}
+
+// fp represents an element of the base field.
+type fp [4]uint64
+
+// condSubtract sets z <- l mod p, assuming that l < 2p, in constant-time.
+func (z *fp) condSubtract(l [4]uint64) *fp {
+	var reduced [4]uint64
+	var borrow uint64
+	for i := range reduced {
+		reduced[i], borrow = bits.Sub64(l[i], pLimbs[i], borrow)
+	}
+	// If we borrowed, then l < p, and we keep it.
+	m := limbs.Mask(saferith.Choice(borrow))
+	for i := range z {
+		z[i] = reduced[i] ^ (m & (reduced[i] ^ l[i]))
+	}
+	return z
+}
+
+// Set sets z <- x, returning z.
+func (z *fp) Set(x *fp) *fp {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z <- x, returning z.
+func (z *fp) SetUint64(x uint64) *fp {
+	*z = fp{x}
+	return z.Mul(&rSquared)
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *fp) CondAssign(yes saferith.Choice, x *fp) *fp {
+	m := limbs.Mask(yes)
+	for i := range z {
+		z[i] ^= m & (z[i] ^ x[i])
+	}
+	return z
+}
+
+// Add sets z <- z + a, returning z.
+func (z *fp) Add(a *fp) *fp {
+	var l [4]uint64
+	var carry uint64
+	for i := range l {
+		l[i], carry = bits.Add64(z[i], a[i], carry)
+	}
+	// Since p < 2^255, there's never a carry out of the top limb.
+	return z.condSubtract(l)
+}
+
+// Double sets z <- 2 * z, returning z.
+func (z *fp) Double() *fp {
+	return z.Add(z)
+}
+
+// Sub sets z <- z - a, returning z.
+func (z *fp) Sub(a *fp) *fp {
+	var borrow uint64
+	for i := range z {
+		z[i], borrow = bits.Sub64(z[i], a[i], borrow)
+	}
+	// If we borrowed, we need to add p back.
+	m := limbs.Mask(saferith.Choice(borrow))
+	var carry uint64
+	for i := range z {
+		z[i], carry = bits.Add64(z[i], m&pLimbs[i], carry)
+	}
+	return z
+}
+
+// Negate sets z <- -z, returning z.
+func (z *fp) Negate() *fp {
+	x := *z
+	*z = fp{}
+	return z.Sub(&x)
+}
+
+// Mul sets z <- z * a, returning z.
+//
+// This uses Montgomery multiplication, interleaving the reduction with the product.
+func (z *fp) Mul(a *fp) *fp {
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		// t += z * a[i]
+		var carry, c uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(z[j], a[i])
+			lo, c = bits.Add64(lo, t[j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[j] = lo
+			carry = hi
+		}
+		t[4], c = bits.Add64(t[4], carry, 0)
+		t[5] = c
+
+		// We add a multiple of p to make the bottom limb 0, and then shift it out.
+		m := t[0] * pInv
+		hi, lo := bits.Mul64(m, pLimbs[0])
+		_, c = bits.Add64(lo, t[0], 0)
+		carry = hi + c
+		for j := 1; j < 4; j++ {
+			hi, lo := bits.Mul64(m, pLimbs[j])
+			lo, c = bits.Add64(lo, t[j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[j-1] = lo
+			carry = hi
+		}
+		t[3], c = bits.Add64(t[4], carry, 0)
+		t[4] = t[5] + c
+	}
+	// Because 4p < 2^256, the result is < 2p, and t[4] = 0.
+	return z.condSubtract([4]uint64{t[0], t[1], t[2], t[3]})
+}
+
+// Square sets z <- z * z, returning z.
+func (z *fp) Square() *fp {
+	return z.Mul(z)
+}
+
+// pow sets z <- z^e, returning z, where e is given as little endian limbs.
+//
+// This takes time depending only on the length of e, and not on the value of z.
+// The exponent is assumed to be public.
+func (z *fp) pow(e []uint64) *fp {
+	x := *z
+	z.Set(&fpOne)
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			z.Square()
+			if (e[i]>>j)&1 == 1 {
+				z.Mul(&x)
+			}
+		}
+	}
+	return z
+}
+
+// pMinusTwo is p - 2, which we use to calculate inverses.
+var pMinusTwo = [4]uint64{0x3C208C16D87CFD45, 0x97816A916871CA8D, 0xB85045B68181585D, 0x30644E72E131A029}
+
+// Invert sets z <- z^-1, returning z.
+//
+// If z = 0, the result is 0.
+func (z *fp) Invert() *fp {
+	return z.pow(pMinusTwo[:])
+}
+
+// Eq checks if z = x, in constant-time.
+func (z *fp) Eq(x *fp) saferith.Choice {
+	var diff uint64
+	for i := range z {
+		diff |= z[i] ^ x[i]
+	}
+	return limbs.IsZero(diff)
+}
+
+// EqZero checks if z = 0, in constant-time.
+func (z *fp) EqZero() saferith.Choice {
+	return z.Eq(&fp{})
+}
+
+// fromMontgomery returns the limbs of z, taken out of Montgomery form.
+func (z *fp) fromMontgomery() [4]uint64 {
+	out := *z
+	out.Mul(&fp{1})
+	return out
+}
+
+// Bytes returns the Big Endian encoding of z, using 32 bytes.
+func (z *fp) Bytes() []byte {
+	l := z.fromMontgomery()
+	out := make([]byte, fpBytes)
+	for i, limb := range l {
+		binary.BigEndian.PutUint64(out[fpBytes-8*(i+1):], limb)
+	}
+	return out
+}
+
+// SetBytes sets z to the value of 32 Big Endian bytes.
+//
+// This returns an error if the value isn't less than p.
+func (z *fp) SetBytes(data []byte) error {
+	if len(data) != fpBytes {
+		return errors.New("bn254.fp.SetBytes: invalid data length")
+	}
+	var l [4]uint64
+	for i := range l {
+		l[i] = binary.BigEndian.Uint64(data[fpBytes-8*(i+1):])
+	}
+	// The value is canonical exactly when subtracting p borrows.
+	var borrow uint64
+	for i := range l {
+		_, borrow = bits.Sub64(l[i], pLimbs[i], borrow)
+	}
+	if borrow != 1 {
+		return errors.New("bn254.fp.SetBytes: value is greater than field prime")
+	}
+	*z = l
+	z.Mul(&rSquared)
+	return nil
+}
+
+// pModulus is p, as a saferith Modulus.
+var pModulus = saferith.ModulusFromBytes(reverseLimbs(pLimbs[:]))
+
+// reverseLimbs converts little endian limbs into Big Endian bytes.
+func reverseLimbs(l []uint64) []byte {
+	out := make([]byte, 8*len(l))
+	for i, limb := range l {
+		binary.BigEndian.PutUint64(out[len(out)-8*(i+1):], limb)
+	}
+	return out
+}
+
+// fpFromHex creates a field element from a Big Endian hex string, panicking on failure.
+func fpFromHex(hex string) fp {
+	nat, err := new(saferith.Nat).SetHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	reduced := new(saferith.Nat).Mod(nat, pModulus)
+	var z fp
+	// This can't fail, since the value is reduced.
+	_ = z.SetBytes(reduced.FillBytes(make([]byte, fpBytes)))
+	return z
+}
@@ -0,0 +1,137 @@
+package bn254
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/saferith"
+)
+
+func randomFp(r *rand.Rand) fp {
+	data := make([]byte, fpBytes+16)
+	r.Read(data)
+	reduced := new(saferith.Nat).Mod(new(saferith.Nat).SetBytes(data), pModulus)
+	var out fp
+	_ = out.SetBytes(reduced.FillBytes(make([]byte, fpBytes)))
+	return out
+}
+
+func (fp) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomFp(r))
+}
+
+func (fp2) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(fp2{c0: randomFp(r), c1: randomFp(r)})
+}
+
+func randomFp6(r *rand.Rand) fp6 {
+	var out fp6
+	for _, c := range []*fp2{&out.c0, &out.c1, &out.c2} {
+		*c = fp2{c0: randomFp(r), c1: randomFp(r)}
+	}
+	return out
+}
+
+func (fp12) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(fp12{c0: randomFp6(r), c1: randomFp6(r)})
+}
+
+// fpBig converts a field element to a big.Int, for comparison with math/big.
+func fpBig(a *fp) *big.Int {
+	return new(big.Int).SetBytes(a.Bytes())
+}
+
+func TestFpMatchesReference(t *testing.T) {
+	cases := map[string]func(a, b fp) bool{
+		"Add": func(a, b fp) bool {
+			expected := new(big.Int).Add(fpBig(&a), fpBig(&b))
+			return fpBig(new(fp).Set(&a).Add(&b)).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Sub": func(a, b fp) bool {
+			expected := new(big.Int).Sub(fpBig(&a), fpBig(&b))
+			return fpBig(new(fp).Set(&a).Sub(&b)).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Negate": func(a, _ fp) bool {
+			expected := new(big.Int).Neg(fpBig(&a))
+			return fpBig(new(fp).Set(&a).Negate()).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Mul": func(a, b fp) bool {
+			expected := new(big.Int).Mul(fpBig(&a), fpBig(&b))
+			return fpBig(new(fp).Set(&a).Mul(&b)).Cmp(expected.Mod(expected, pBig)) == 0
+		},
+		"Invert": func(a, _ fp) bool {
+			expected := new(big.Int).ModInverse(fpBig(&a), pBig)
+			if expected == nil {
+				expected = new(big.Int)
+			}
+			return fpBig(new(fp).Set(&a).Invert()).Cmp(expected) == 0
+		},
+	}
+	for name, f := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestFpRejectsUnreduced(t *testing.T) {
+	if new(fp).SetBytes(pModulus.Bytes()) == nil {
+		t.Error("p should be rejected as a field element")
+	}
+}
+
+func TestFp2MultiplyInverse(t *testing.T) {
+	err := quick.Check(func(a fp2) bool {
+		one := fp2One()
+		return new(fp2).Set(&a).Invert().Mul(&a).Eq(&one) == 1
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp2MulByNonResidue(t *testing.T) {
+	err := quick.Check(func(a fp2) bool {
+		return new(fp2).Set(&a).MulByNonResidue().Eq(new(fp2).Set(&a).Mul(&xi)) == 1
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12MultiplyInverse(t *testing.T) {
+	err := quick.Check(func(a fp12) bool {
+		one := fp12One()
+		return new(fp12).Set(&a).Invert().Mul(&a).Eq(&one) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12SquareMatchesMul(t *testing.T) {
+	err := quick.Check(func(a fp12) bool {
+		return new(fp12).Set(&a).Square().Eq(new(fp12).Set(&a).Mul(&a)) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFp12MulAssociative(t *testing.T) {
+	err := quick.Check(func(a, b, c fp12) bool {
+		way1 := new(fp12).Set(&a).Mul(&b)
+		way1.Mul(&c)
+		way2 := new(fp12).Set(&b).Mul(&c)
+		way2.Mul(&a)
+		return way1.Eq(way2) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
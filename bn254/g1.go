@@ -0,0 +1,164 @@
+package bn254
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/pairing"
+	"github.com/cronokirby/saferith"
+)
+
+// G1Bytes is the size of the encoding of a G1 point.
+const G1Bytes = 2 * fpBytes
+
+// g1B is b, where y^2 = x^3 + b is the equation of the curve, with b = 3.
+var g1B = new(fp).SetUint64(3)
+
+// g1Curve holds the constants of the curve, with B3 = 3 * b = 9.
+var g1Curve = &pairing.Curve[fp, *fp]{B3: *new(fp).SetUint64(9), One: fpOne}
+
+// rBytes is the order of the groups, as Big Endian bytes.
+var rBytes = r.Nat().FillBytes(make([]byte, ScalarBytes))
+
+// G1Point represents a point in G1.
+//
+// The curve containing G1 has prime order, so every point on it lies in G1.
+type G1Point struct {
+	inner pairing.Point[fp, *fp]
+}
+
+// g1Generator is the standard generator of G1, (1, 2).
+var g1Generator = &G1Point{inner: pairing.Point[fp, *fp]{
+	X: fpFromHex("01"),
+	Y: fpFromHex("02"),
+	Z: fpOne,
+}}
+
+// NewG1Point returns the identity point of G1.
+func NewG1Point() *G1Point {
+	return &G1Point{inner: g1Curve.Identity()}
+}
+
+// NewG1BasePoint returns the standard generator of G1.
+func NewG1BasePoint() *G1Point {
+	out := *g1Generator
+	return &out
+}
+
+// castG1Point converts a point implementing the generic interface to this specific type.
+func castG1Point(p kyokusen.Point) *G1Point {
+	casted, ok := p.(*G1Point)
+	if !ok {
+		panic("failed to cast type to *bn254.G1Point")
+	}
+	return casted
+}
+
+func (p *G1Point) String() string {
+	data, _ := p.MarshalBinary()
+	return fmt.Sprintf("%x", data)
+}
+
+// affine returns the affine coordinates of this point, or (0, 0) for the identity.
+func (p *G1Point) affine() (x, y fp) {
+	return p.inner.ToAffine()
+}
+
+// MarshalBinary encodes this point as x || y, with each coordinate using 32 Big Endian bytes.
+//
+// This is the encoding used by the Ethereum precompiles, with the identity encoded as 64 zero bytes.
+func (p *G1Point) MarshalBinary() ([]byte, error) {
+	x, y := p.affine()
+	return append(x.Bytes(), y.Bytes()...), nil
+}
+
+// g1Rhs calculates x^3 + 3.
+func g1Rhs(x *fp) *fp {
+	return new(fp).Set(x).Square().Mul(x).Add(g1B)
+}
+
+// UnmarshalBinary decodes a point in the encoding used by the Ethereum precompiles.
+//
+// This returns an error if either coordinate isn't canonical, or if the point
+// doesn't lie on the curve.
+func (p *G1Point) UnmarshalBinary(data []byte) error {
+	if len(data) != G1Bytes {
+		return errors.New("bn254.G1Point.UnmarshalBinary: invalid data length")
+	}
+	var x, y fp
+	if err := x.SetBytes(data[:fpBytes]); err != nil {
+		return errors.New("bn254.G1Point.UnmarshalBinary: invalid x coordinate")
+	}
+	if err := y.SetBytes(data[fpBytes:]); err != nil {
+		return errors.New("bn254.G1Point.UnmarshalBinary: invalid y coordinate")
+	}
+	// (0, 0) isn't on the curve, so it can be used to encode the identity.
+	if x.EqZero()&y.EqZero() == 1 {
+		*p = *NewG1Point()
+		return nil
+	}
+	if new(fp).Set(&y).Square().Eq(g1Rhs(&x)) != 1 {
+		return errors.New("bn254.G1Point.UnmarshalBinary: point is not on the curve")
+	}
+	*p = G1Point{inner: g1Curve.Affine(&x, &y)}
+	return nil
+}
+
+func (*G1Point) Curve() kyokusen.Curve {
+	return G1Curve{}
+}
+
+// add sets p <- a + b, returning p.
+func (p *G1Point) add(a, b *G1Point) *G1Point {
+	g1Curve.Add(&p.inner, &a.inner, &b.inner)
+	return p
+}
+
+func (p1 *G1Point) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := castG1Point(other)
+	return new(G1Point).add(p1, p2)
+}
+
+func (p1 *G1Point) Sub(other kyokusen.Point) kyokusen.Point {
+	return p1.Add(other.Negate())
+}
+
+func (p *G1Point) Negate() kyokusen.Point {
+	out := *p
+	out.inner.Negate()
+	return &out
+}
+
+func (p1 *G1Point) Equal(other kyokusen.Point) bool {
+	p2 := castG1Point(other)
+	return p1.inner.Equal(&p2.inner) == 1
+}
+
+func (p *G1Point) IsIdentity() bool {
+	return p.inner.IsIdentity() == 1
+}
+
+// XScalar returns nil: BN254 is used for pairings and SNARK verification, never for ECDSA.
+func (p *G1Point) XScalar() kyokusen.Scalar {
+	return nil
+}
+
+// CondAssign sets p <- other, only if yes = 1, in constant-time.
+func (p *G1Point) CondAssign(yes saferith.Choice, other *G1Point) *G1Point {
+	p.inner.CondAssign(yes, &other.inner)
+	return p
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *G1Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	out := *p
+	return out.CondAssign(yes, castG1Point(other))
+}
+
+// mul calculates k * p, where k is given as Big Endian bytes, in constant-time.
+func (p *G1Point) mul(k []byte) *G1Point {
+	return &G1Point{inner: g1Curve.Mul(&p.inner, k)}
+}
@@ -0,0 +1,110 @@
+package bn254
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// mustDecodeHex decodes a hex string, panicking on failure.
+func mustDecodeHex(s string) []byte {
+	out, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func randomG1Point(r *rand.Rand, size int) *G1Point {
+	return randomScalar(r, ScalarBytes).Act(g1Generator).(*G1Point)
+}
+
+func (*G1Point) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomG1Point(r, size))
+}
+
+func TestG1PointAdditionCommutative(t *testing.T) {
+	err := quick.Check(func(a, b *G1Point) bool {
+		return a.Add(b).Equal(b.Add(a))
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG1PointAddIdentityDoesNothing(t *testing.T) {
+	err := quick.Check(func(a *G1Point) bool {
+		return a.Add(NewG1Point()).Equal(a) && NewG1Point().Add(a).Equal(a)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG1PointSubSelfIsIdentity(t *testing.T) {
+	err := quick.Check(func(a *G1Point) bool {
+		return a.Sub(a).IsIdentity()
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG1PointMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(a *G1Point) bool {
+		data, err := a.MarshalBinary()
+		if err != nil || len(data) != G1Bytes {
+			return false
+		}
+		decoded := NewG1Point()
+		return decoded.UnmarshalBinary(data) == nil && decoded.Equal(a)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG1IdentityEncoding(t *testing.T) {
+	data, _ := NewG1Point().MarshalBinary()
+	for _, b := range data {
+		if b != 0 {
+			t.Fatalf("identity should be encoded as zeros: %x", data)
+		}
+	}
+	decoded := NewG1BasePoint()
+	if decoded.UnmarshalBinary(data) != nil || !decoded.IsIdentity() {
+		t.Error("zeros should decode to the identity")
+	}
+}
+
+func TestG1BadEncodings(t *testing.T) {
+	generator, _ := g1Generator.MarshalBinary()
+	modify := func(data []byte, f func([]byte)) []byte {
+		out := append([]byte{}, data...)
+		f(out)
+		return out
+	}
+	cases := map[string][]byte{
+		"empty":         {},
+		"wrong length":  generator[1:],
+		"x not reduced": append(pModulus.Bytes(), generator[fpBytes:]...),
+		"y not reduced": append(generator[:fpBytes:fpBytes], pModulus.Bytes()...),
+		"not on curve":  modify(generator, func(b []byte) { b[len(b)-1] ^= 1 }),
+		"zero x":        modify(make([]byte, G1Bytes), func(b []byte) { b[len(b)-1] = 1 }),
+	}
+	for name, data := range cases {
+		if NewG1Point().UnmarshalBinary(data) == nil {
+			t.Errorf("%s: encoding should be rejected", name)
+		}
+	}
+}
+
+func BenchmarkG1Add(b *testing.B) {
+	p := randomG1Point(rand.New(rand.NewSource(0)), 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Add(p)
+	}
+}
@@ -0,0 +1,201 @@
+package bn254
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/pairing"
+	"github.com/cronokirby/saferith"
+)
+
+// G2Bytes is the size of the encoding of a G2 point.
+const G2Bytes = 2 * fp2Bytes
+
+// xi is the non-residue 9 + u, used to build the extension tower, and the twist.
+var xi = fp2{c0: fpFromHex("09"), c1: fpOne}
+
+// g2B is b, where y^2 = x^3 + b is the equation of the twisted curve containing G2, with b = 3 / (9 + u).
+var g2B = func() fp2 {
+	out := fp2{c0: fpFromHex("03")}
+	return *out.Mul(new(fp2).Set(&xi).Invert())
+}()
+
+// g2Curve holds the constants of the twisted curve containing G2, with B3 = 3 * b.
+var g2Curve = func() *pairing.Curve[fp2, *fp2] {
+	b3 := g2B
+	b3.Add(&g2B).Add(&g2B)
+	return &pairing.Curve[fp2, *fp2]{B3: b3, One: fp2One()}
+}()
+
+// g2Cofactor is the cofactor of the curve containing G2, 2p - r, as Big Endian bytes.
+var g2Cofactor = func() []byte {
+	out := new(big.Int).Lsh(pBig, 1)
+	return out.Sub(out, new(big.Int).SetBytes(r.Bytes())).Bytes()
+}()
+
+// G2Point represents a point on the twisted curve containing G2.
+//
+// Points decoded from bytes always lie in G2, but points created in other ways
+// might need to have their cofactor cleared first.
+type G2Point struct {
+	inner pairing.Point[fp2, *fp2]
+}
+
+// g2Generator is the standard generator of G2, as used by EIP-197.
+var g2Generator = &G2Point{inner: pairing.Point[fp2, *fp2]{
+	X: fp2{
+		c0: fpFromHex("1800DEEF121F1E76426A00665E5C4479674322D4F75EDADD46DEBD5CD992F6ED"),
+		c1: fpFromHex("198E9393920D483A7260BFB731FB5D25F1AA493335A9E71297E485B7AEF312C2"),
+	},
+	Y: fp2{
+		c0: fpFromHex("12C85EA5DB8C6DEB4AAB71808DCB408FE3D1E7690C43D37B4CE6CC0166FA7DAA"),
+		c1: fpFromHex("090689D0585FF075EC9E99AD690C3395BC4B313370B38EF355ACDADCD122975B"),
+	},
+	Z: fp2One(),
+}}
+
+// NewG2Point returns the identity point of G2.
+func NewG2Point() *G2Point {
+	return &G2Point{inner: g2Curve.Identity()}
+}
+
+// NewG2BasePoint returns the standard generator of G2.
+func NewG2BasePoint() *G2Point {
+	out := *g2Generator
+	return &out
+}
+
+// castG2Point converts a point implementing the generic interface to this specific type.
+func castG2Point(p kyokusen.Point) *G2Point {
+	casted, ok := p.(*G2Point)
+	if !ok {
+		panic("failed to cast type to *bn254.G2Point")
+	}
+	return casted
+}
+
+func (p *G2Point) String() string {
+	data, _ := p.MarshalBinary()
+	return fmt.Sprintf("%x", data)
+}
+
+// affine returns the affine coordinates of this point, or (0, 0) for the identity.
+func (p *G2Point) affine() (x, y fp2) {
+	return p.inner.ToAffine()
+}
+
+// MarshalBinary encodes this point as x || y, using 128 bytes.
+//
+// This is the encoding used by the Ethereum precompiles, where each coordinate
+// c0 + c1 u is written as c1 || c0, and the identity is encoded as zero bytes.
+func (p *G2Point) MarshalBinary() ([]byte, error) {
+	x, y := p.affine()
+	return append(x.Bytes(), y.Bytes()...), nil
+}
+
+// g2Rhs calculates x^3 + 3 / (9 + u).
+func g2Rhs(x *fp2) *fp2 {
+	return new(fp2).Set(x).Square().Mul(x).Add(&g2B)
+}
+
+// UnmarshalBinary decodes a point in the encoding used by the Ethereum precompiles.
+//
+// This returns an error if the point doesn't lie in G2.
+func (p *G2Point) UnmarshalBinary(data []byte) error {
+	if len(data) != G2Bytes {
+		return errors.New("bn254.G2Point.UnmarshalBinary: invalid data length")
+	}
+	var x, y fp2
+	if err := x.SetBytes(data[:fp2Bytes]); err != nil {
+		return errors.New("bn254.G2Point.UnmarshalBinary: invalid x coordinate")
+	}
+	if err := y.SetBytes(data[fp2Bytes:]); err != nil {
+		return errors.New("bn254.G2Point.UnmarshalBinary: invalid y coordinate")
+	}
+	// (0, 0) isn't on the curve, so it can be used to encode the identity.
+	if x.EqZero()&y.EqZero() == 1 {
+		*p = *NewG2Point()
+		return nil
+	}
+	if new(fp2).Set(&y).Square().Eq(g2Rhs(&x)) != 1 {
+		return errors.New("bn254.G2Point.UnmarshalBinary: point is not on the curve")
+	}
+	decoded := G2Point{inner: g2Curve.Affine(&x, &y)}
+	if !decoded.IsTorsionFree() {
+		return errors.New("bn254.G2Point.UnmarshalBinary: point is not in G2")
+	}
+	*p = decoded
+	return nil
+}
+
+func (*G2Point) Curve() kyokusen.Curve {
+	return G2Curve{}
+}
+
+// add sets p <- a + b, returning p.
+func (p *G2Point) add(a, b *G2Point) *G2Point {
+	g2Curve.Add(&p.inner, &a.inner, &b.inner)
+	return p
+}
+
+func (p1 *G2Point) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := castG2Point(other)
+	return new(G2Point).add(p1, p2)
+}
+
+func (p1 *G2Point) Sub(other kyokusen.Point) kyokusen.Point {
+	return p1.Add(other.Negate())
+}
+
+func (p *G2Point) Negate() kyokusen.Point {
+	out := *p
+	out.inner.Negate()
+	return &out
+}
+
+func (p1 *G2Point) Equal(other kyokusen.Point) bool {
+	p2 := castG2Point(other)
+	return p1.inner.Equal(&p2.inner) == 1
+}
+
+func (p *G2Point) IsIdentity() bool {
+	return p.inner.IsIdentity() == 1
+}
+
+// XScalar returns nil, since the x coordinate lies in Fp2, which has no natural map to scalars.
+func (p *G2Point) XScalar() kyokusen.Scalar {
+	return nil
+}
+
+// CondAssign sets p <- other, only if yes = 1, in constant-time.
+func (p *G2Point) CondAssign(yes saferith.Choice, other *G2Point) *G2Point {
+	p.inner.CondAssign(yes, &other.inner)
+	return p
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *G2Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	out := *p
+	return out.CondAssign(yes, castG2Point(other))
+}
+
+// mul calculates k * p, where k is given as Big Endian bytes, in constant-time.
+func (p *G2Point) mul(k []byte) *G2Point {
+	return &G2Point{inner: g2Curve.Mul(&p.inner, k)}
+}
+
+// ClearCofactor returns a new point, equal to this point multiplied by the cofactor.
+//
+// The result always lies in G2. This implements the kyokusen.CofactorPoint interface.
+func (p *G2Point) ClearCofactor() kyokusen.Point {
+	return p.mul(g2Cofactor)
+}
+
+// IsTorsionFree checks if this point lies in G2, by checking that r * P is the identity.
+func (p *G2Point) IsTorsionFree() bool {
+	return p.mul(rBytes).IsIdentity()
+}
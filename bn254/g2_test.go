@@ -0,0 +1,107 @@
+package bn254
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+func randomG2Point(r *rand.Rand, size int) *G2Point {
+	return randomScalar(r, ScalarBytes).Act(g2Generator).(*G2Point)
+}
+
+func (*G2Point) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomG2Point(r, size))
+}
+
+// g2NonSubgroupPoint is a point on the twisted curve, with x = 1, which doesn't lie in G2.
+var g2NonSubgroupPoint = mustDecodeHex("0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000001" +
+	"0D1271953ED9EA0836846E70A1934187998C7F790CB4D7511B7F8DA82DE048A4" +
+	"2869111D5381F072F8E2728FDB825A51AADD70E52C9830E9AB4B871C0531F1BB")
+
+func TestG2PointAdditionCommutative(t *testing.T) {
+	err := quick.Check(func(a, b *G2Point) bool {
+		return a.Add(b).Equal(b.Add(a))
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG2PointAddIdentityDoesNothing(t *testing.T) {
+	err := quick.Check(func(a *G2Point) bool {
+		return a.Add(NewG2Point()).Equal(a) && NewG2Point().Add(a).Equal(a)
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG2PointSubSelfIsIdentity(t *testing.T) {
+	err := quick.Check(func(a *G2Point) bool {
+		return a.Sub(a).IsIdentity()
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG2PointMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(a *G2Point) bool {
+		data, err := a.MarshalBinary()
+		if err != nil || len(data) != G2Bytes {
+			return false
+		}
+		decoded := NewG2Point()
+		return decoded.UnmarshalBinary(data) == nil && decoded.Equal(a)
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestG2BadEncodings(t *testing.T) {
+	generator, _ := g2Generator.MarshalBinary()
+	modify := func(data []byte, f func([]byte)) []byte {
+		out := append([]byte{}, data...)
+		f(out)
+		return out
+	}
+	cases := map[string][]byte{
+		"empty":           {},
+		"wrong length":    generator[1:],
+		"x not reduced":   modify(generator, func(b []byte) { copy(b, pModulus.Bytes()) }),
+		"not on curve":    modify(generator, func(b []byte) { b[len(b)-1] ^= 1 }),
+		"not in subgroup": g2NonSubgroupPoint,
+	}
+	for name, data := range cases {
+		if NewG2Point().UnmarshalBinary(data) == nil {
+			t.Errorf("%s: encoding should be rejected", name)
+		}
+	}
+}
+
+func TestG2ClearCofactor(t *testing.T) {
+	// We can't decode the point directly, since that checks the subgroup.
+	var x, y fp2
+	_ = x.SetBytes(g2NonSubgroupPoint[:fp2Bytes])
+	_ = y.SetBytes(g2NonSubgroupPoint[fp2Bytes:])
+	p := G2Point{inner: g2Curve.Affine(&x, &y)}
+	if p.IsTorsionFree() {
+		t.Fatal("point should not be torsion free")
+	}
+	if !kyokusen.IsTorsionFree(kyokusen.ClearCofactor(&p)) {
+		t.Error("clearing the cofactor should produce a point in G2")
+	}
+	if !g2Generator.IsTorsionFree() {
+		t.Error("generator should be torsion free")
+	}
+	if kyokusen.Cofactor(G2Curve{}).Eq(new(saferith.Nat).SetBytes(g2Cofactor)) != 1 {
+		t.Error("incorrect cofactor")
+	}
+}
@@ -0,0 +1,214 @@
+package bn254
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/cronokirby/kyokusen/internal/pairing"
+)
+
+// ateLoopCount is 6u + 2, where u = 0x44E992B44A6909F1 is the BN parameter of this curve.
+//
+// This fits in 65 bits, so we store the lower 64 bits, and treat the top bit separately.
+const ateLoopCount uint64 = 0x9D797039BE763BA8
+
+// bigLimbs converts a big.Int into little endian limbs.
+func bigLimbs(x *big.Int) []uint64 {
+	data := x.FillBytes(make([]byte, 8*((x.BitLen()+63)/64)))
+	return bytesToLimbs(data)
+}
+
+// bytesToLimbs converts Big Endian bytes, with a length divisible by 8, into little endian limbs.
+func bytesToLimbs(data []byte) []uint64 {
+	out := make([]uint64, len(data)/8)
+	for i := range out {
+		out[i] = binary.BigEndian.Uint64(data[len(data)-8*(i+1):])
+	}
+	return out
+}
+
+// pBig is p, as a big.Int, used to calculate the constants needed for the pairing.
+var pBig = new(big.Int).SetBytes(pModulus.Bytes())
+
+// frobeniusPower returns xi^((p^k - 1) / d).
+func frobeniusPower(k int, d int64) fp2 {
+	e := new(big.Int).Exp(pBig, big.NewInt(int64(k)), nil)
+	e.Sub(e, big.NewInt(1))
+	e.Div(e, big.NewInt(d))
+	out := xi
+	return *out.pow(bigLimbs(e))
+}
+
+var (
+	// xiToPMinus1Over3 and xiToPMinus1Over2 are used to apply the Frobenius map to points on the twist.
+	xiToPMinus1Over3 = frobeniusPower(1, 3)
+	xiToPMinus1Over2 = frobeniusPower(1, 2)
+	// xiToPSquaredMinus1Over3 is used to apply the Frobenius map twice to points on the twist.
+	xiToPSquaredMinus1Over3 = frobeniusPower(2, 3)
+)
+
+// finalExponent is (p^2 + 1)(p^4 - p^2 + 1) / r, as Big Endian bytes.
+//
+// Together with the easy part, raising to p^6 - 1, this makes up the final
+// exponentiation of the pairing.
+var finalExponent = func() []byte {
+	p2 := new(big.Int).Mul(pBig, pBig)
+	p4 := new(big.Int).Mul(p2, p2)
+	hard := new(big.Int).Sub(p4, p2)
+	hard.Add(hard, big.NewInt(1))
+	hard.Div(hard, new(big.Int).SetBytes(r.Bytes()))
+	return hard.Mul(hard, p2.Add(p2, big.NewInt(1))).Bytes()
+}()
+
+// GTBytes is the number of bytes in the encoding of an element of GT.
+//
+// Each element is written as its 12 coefficients over the base field, from
+// the highest to the lowest, with each coefficient taking 32 Big Endian bytes.
+const GTBytes = fp12Bytes
+
+// GT represents an element of the target group of the pairing.
+//
+// This is the subgroup of order r of the multiplicative group of Fp12.
+type GT = pairing.GT[fp12, *fp12, *Scalar, gtParams]
+
+// gtParams holds the constants needed by GT.
+type gtParams struct{}
+
+func (gtParams) One() fp12 {
+	return fp12One()
+}
+
+func (gtParams) Order() []byte {
+	return rBytes
+}
+
+func (gtParams) Prefix() string {
+	return "bn254"
+}
+
+// NewGT returns the identity element of GT, 1.
+func NewGT() *GT {
+	return pairing.NewGT[fp12, *fp12, *Scalar, gtParams]()
+}
+
+// lineEval evaluates the line with slope lambda through the point (xT, yT) on the twist, at the point (xP, yP).
+//
+// Mapping the twist back onto the curve over Fp12 sends (x, y) to (x w^2, y w^3),
+// and the line yP - y - lambda (xP - x) becomes yP - lambda xP w + (lambda xT - yT) v w.
+func lineEval(lambda, xT, yT *fp2, xP, yP *fp) fp12 {
+	var out fp12
+	out.c0.c0.c0.Set(yP)
+	out.c1.c0.Set(lambda).MulFp(xP).Negate()
+	out.c1.c1.Set(lambda).Mul(xT).Sub(yT)
+	return out
+}
+
+// doubleStep multiplies f by the tangent line at T, evaluated at P, and sets T <- 2 T.
+func doubleStep(f *fp12, t *[2]fp2, p *[2]fp) {
+	xT, yT := &t[0], &t[1]
+	// lambda = 3 xT^2 / 2 yT
+	xx := new(fp2).Set(xT).Square()
+	lambda := new(fp2).Set(yT).Double().Invert()
+	lambda.Mul(new(fp2).Set(xx).Double().Add(xx))
+	line := lineEval(lambda, xT, yT, &p[0], &p[1])
+	f.Mul(&line)
+	// x3 = lambda^2 - 2 xT, y3 = lambda (xT - x3) - yT
+	x3 := new(fp2).Set(lambda).Square().Sub(xT).Sub(xT)
+	yT.Negate().Sub(new(fp2).Set(x3).Sub(xT).Mul(lambda))
+	xT.Set(x3)
+}
+
+// addStep multiplies f by the line through T and Q, evaluated at P, and sets T <- T + Q.
+func addStep(f *fp12, t *[2]fp2, q *[2]fp2, p *[2]fp) {
+	xT, yT := &t[0], &t[1]
+	xQ, yQ := &q[0], &q[1]
+	// lambda = (yQ - yT) / (xQ - xT)
+	lambda := new(fp2).Set(xQ).Sub(xT).Invert()
+	lambda.Mul(new(fp2).Set(yQ).Sub(yT))
+	line := lineEval(lambda, xT, yT, &p[0], &p[1])
+	f.Mul(&line)
+	// x3 = lambda^2 - xT - xQ, y3 = lambda (xT - x3) - yT
+	x3 := new(fp2).Set(lambda).Square().Sub(xT).Sub(xQ)
+	yT.Negate().Sub(new(fp2).Set(x3).Sub(xT).Mul(lambda))
+	xT.Set(x3)
+}
+
+// millerLoop calculates the product of the Miller loops of the optimal ate pairing, for several pairs of points.
+//
+// The points are given in affine coordinates, and none of them may be the identity.
+// After the main loop over 6u + 2, we add two more lines, using the Frobenius
+// map applied once and twice to Q. Since every point in G2 has prime order r,
+// none of the additions hit exceptional cases.
+func millerLoop(ps [][2]fp, qs [][2]fp2) fp12 {
+	f := fp12One()
+	ts := make([][2]fp2, len(qs))
+	copy(ts, qs)
+	// The top bit of 6u + 2 is handled by starting with T = Q.
+	for i := 63; i >= 0; i-- {
+		f.Square()
+		for j := range ts {
+			doubleStep(&f, &ts[j], &ps[j])
+		}
+		if (ateLoopCount>>i)&1 == 0 {
+			continue
+		}
+		for j := range ts {
+			addStep(&f, &ts[j], &qs[j], &ps[j])
+		}
+	}
+	for j := range ts {
+		xQ, yQ := qs[j][0], qs[j][1]
+		// Q1 = pi(Q)
+		var q1 [2]fp2
+		q1[0].Set(&xQ).Conjugate().Mul(&xiToPMinus1Over3)
+		q1[1].Set(&yQ).Conjugate().Mul(&xiToPMinus1Over2)
+		addStep(&f, &ts[j], &q1, &ps[j])
+		// Q2 = -pi^2(Q), where the negation cancels out the factor applied to y.
+		var q2 [2]fp2
+		q2[0].Set(&xQ).Mul(&xiToPSquaredMinus1Over3)
+		q2[1].Set(&yQ)
+		addStep(&f, &ts[j], &q2, &ps[j])
+	}
+	return f
+}
+
+// finalExponentiation sets f <- f^((p^12 - 1) / r), returning f.
+func finalExponentiation(f *fp12) *fp12 {
+	return pairing.EasyPart[fp12](f).Exp(finalExponent)
+}
+
+// Pairing calculates the optimal ate pairing e(P, Q).
+func Pairing(P *G1Point, Q *G2Point) *GT {
+	return MultiPairing([]*G1Point{P}, []*G2Point{Q})
+}
+
+// MultiPairing calculates the product of the pairings e(P_i, Q_i).
+//
+// This is much faster than calculating each pairing separately, since the
+// final exponentiation is shared. Pairs where either point is the identity
+// are skipped, since they don't contribute to the product.
+func MultiPairing(ps []*G1Point, qs []*G2Point) *GT {
+	if len(ps) != len(qs) {
+		panic("bn254.MultiPairing: mismatched number of points")
+	}
+	affineP := make([][2]fp, 0, len(ps))
+	affineQ := make([][2]fp2, 0, len(qs))
+	for i := range ps {
+		if ps[i].IsIdentity() || qs[i].IsIdentity() {
+			continue
+		}
+		xP, yP := ps[i].affine()
+		xQ, yQ := qs[i].affine()
+		affineP = append(affineP, [2]fp{xP, yP})
+		affineQ = append(affineQ, [2]fp2{xQ, yQ})
+	}
+	f := millerLoop(affineP, affineQ)
+	return NewGT().SetFp12(finalExponentiation(&f))
+}
+
+// PairingCheck checks if the product of the pairings e(P_i, Q_i) is 1.
+//
+// This is the operation performed by the Ethereum pairing precompile, described in EIP-197.
+func PairingCheck(ps []*G1Point, qs []*G2Point) bool {
+	return MultiPairing(ps, qs).IsOne()
+}
@@ -0,0 +1,89 @@
+package bn254
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+)
+
+// gtGenerator is the encoding of e(G1, G2), matching other implementations, such as go-ethereum.
+var gtGenerator = mustDecodeHex("108c19d15f9446f744d0f110405d3856d6cc3bda6c4d537663729f52576284170dc26f240656bbe2029bd441d77c221f0ba4c70c94b29b5f17f0f6d08745a069279db296f9d479292532c7c493d8e0722b6efae42158387564889c79fc038ee31ad9db1937fd72f4ac462173d31d3d6117411fa48dba8d499d762b47edb3b54a27ed208e7a0b55ae6e710bbfbd2fd922669c026360e37cc5b2ab8624115361042c53748bcd21a7c038fb30ddc8ac3bf0af25d7859cfbc12c30c866276c5659092b03614464f04dd772d86df88674c270ffc8747ea13e72da95e3594468f222c401676555de427abc409c4a394bc5426886302996919d4bf4bdd02236e14b36362067586885c3318eeffa1938c754fe3c60224ee5ae15e66af6b5104c47c8c5d80e841c2ac18a4003ac9326b9558380e0bc27fdd375e3605f96b819a358d34bde084f330485b09e866bc2f2ea2b897394deaf3f12aa31f28cb0552990967d470412c70e90e12b7874510cd1707e8856f71bf7f61d72631e268fca81000db9a1f5")
+
+func TestPairingOfGenerators(t *testing.T) {
+	actual, _ := Pairing(g1Generator, g2Generator).MarshalBinary()
+	if !bytes.Equal(actual, gtGenerator) {
+		t.Errorf("incorrect pairing: %x", actual)
+	}
+	decoded := NewGT()
+	if err := decoded.UnmarshalBinary(gtGenerator); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(Pairing(g1Generator, g2Generator)) {
+		t.Error("decoded value doesn't match")
+	}
+}
+
+func TestPairingIsBilinear(t *testing.T) {
+	err := quick.Check(func(a, b *Scalar) bool {
+		aP := a.Act(g1Generator).(*G1Point)
+		bQ := b.Act(g2Generator).(*G2Point)
+		ab := NewScalar().Set(a).Mul(b).(*Scalar)
+		expected := Pairing(g1Generator, g2Generator).Exp(ab)
+		return Pairing(aP, bQ).Equal(expected) &&
+			Pairing(ab.Act(g1Generator).(*G1Point), g2Generator).Equal(expected)
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPairingWithIdentity(t *testing.T) {
+	if !Pairing(NewG1Point(), g2Generator).IsOne() {
+		t.Error("e(O, G2) should be 1")
+	}
+	if !Pairing(g1Generator, NewG2Point()).IsOne() {
+		t.Error("e(G1, O) should be 1")
+	}
+	if Pairing(g1Generator, g2Generator).IsOne() {
+		t.Error("e(G1, G2) should not be 1")
+	}
+}
+
+func TestPairingCheckCancels(t *testing.T) {
+	err := quick.Check(func(P *G1Point, Q *G2Point) bool {
+		return PairingCheck([]*G1Point{P, P.Negate().(*G1Point)}, []*G2Point{Q, Q})
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGTMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(s *Scalar) bool {
+		x := Pairing(g1Generator, g2Generator).Exp(s)
+		data, err := x.MarshalBinary()
+		if err != nil || len(data) != GTBytes {
+			return false
+		}
+		decoded := NewGT()
+		return decoded.UnmarshalBinary(data) == nil && decoded.Equal(x)
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGTRejectsNonSubgroup(t *testing.T) {
+	// 2 is an element of Fp12, but it doesn't have order r.
+	data := make([]byte, GTBytes)
+	data[len(data)-1] = 2
+	if NewGT().UnmarshalBinary(data) == nil {
+		t.Error("2 should not be accepted as an element of GT")
+	}
+}
+
+func BenchmarkPairing(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Pairing(g1Generator, g2Generator)
+	}
+}
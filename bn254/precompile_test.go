@@ -0,0 +1,102 @@
+package bn254
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/cronokirby/saferith"
+)
+
+// precompileVector is a test vector for one of the Ethereum precompiles, in the format used by go-ethereum.
+type precompileVector struct {
+	Name     string
+	Input    string
+	Expected string
+}
+
+func readPrecompileVectors(t *testing.T, path string) []precompileVector {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []precompileVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	return vectors
+}
+
+// padInput truncates or pads an input with zeros to a given length, like the precompiles do.
+func padInput(input []byte, length int) []byte {
+	out := make([]byte, length)
+	copy(out, input)
+	return out
+}
+
+// TestAddVectors checks against the vectors for the addition precompile, described in EIP-196.
+func TestAddVectors(t *testing.T) {
+	for _, v := range readPrecompileVectors(t, "testdata/bn256Add.json") {
+		input, _ := hex.DecodeString(v.Input)
+		expected, _ := hex.DecodeString(v.Expected)
+		input = padInput(input, 2*G1Bytes)
+		a, b := NewG1Point(), NewG1Point()
+		if err := a.UnmarshalBinary(input[:G1Bytes]); err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		if err := b.UnmarshalBinary(input[G1Bytes:]); err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		if actual, _ := a.Add(b).MarshalBinary(); !bytes.Equal(actual, expected) {
+			t.Errorf("%s: incorrect result %x", v.Name, actual)
+		}
+	}
+}
+
+// TestScalarMulVectors checks against the vectors for the scalar multiplication precompile, described in EIP-196.
+//
+// The precompile accepts any 32 byte scalar, so we reduce it modulo r.
+func TestScalarMulVectors(t *testing.T) {
+	for _, v := range readPrecompileVectors(t, "testdata/bn256ScalarMul.json") {
+		input, _ := hex.DecodeString(v.Input)
+		expected, _ := hex.DecodeString(v.Expected)
+		input = padInput(input, G1Bytes+ScalarBytes)
+		p := NewG1Point()
+		if err := p.UnmarshalBinary(input[:G1Bytes]); err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		s := NewScalar().SetNat(new(saferith.Nat).SetBytes(input[G1Bytes:]))
+		if actual, _ := s.Act(p).MarshalBinary(); !bytes.Equal(actual, expected) {
+			t.Errorf("%s: incorrect result %x", v.Name, actual)
+		}
+	}
+}
+
+// TestPairingVectors checks against the vectors for the pairing check precompile, described in EIP-197.
+//
+// Each input is a list of pairs of points, and the expected output is 1
+// exactly when the product of their pairings is 1.
+func TestPairingVectors(t *testing.T) {
+	for _, v := range readPrecompileVectors(t, "testdata/bn256Pairing.json") {
+		input, _ := hex.DecodeString(v.Input)
+		expected, _ := hex.DecodeString(v.Expected)
+		var ps []*G1Point
+		var qs []*G2Point
+		for ; len(input) > 0; input = input[G1Bytes+G2Bytes:] {
+			p, q := NewG1Point(), NewG2Point()
+			if err := p.UnmarshalBinary(input[:G1Bytes]); err != nil {
+				t.Fatalf("%s: %v", v.Name, err)
+			}
+			if err := q.UnmarshalBinary(input[G1Bytes : G1Bytes+G2Bytes]); err != nil {
+				t.Fatalf("%s: %v", v.Name, err)
+			}
+			ps = append(ps, p)
+			qs = append(qs, q)
+		}
+		if PairingCheck(ps, qs) != (expected[len(expected)-1] == 1) {
+			t.Errorf("%s: incorrect result", v.Name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package bn254
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/saferith"
+)
+
+func randomScalar(r *rand.Rand, size int) *Scalar {
+	data := make([]byte, ScalarBytes)
+	// Fill in a certain number of bytes with zero. Smaller sizes will be closer to zero.
+	for i := 0; i < size && i < len(data); i++ {
+		data[len(data)-i-1] = byte(r.Uint32())
+	}
+	return NewScalar().SetNat(new(saferith.Nat).SetBytes(data)).(*Scalar)
+}
+
+func (*Scalar) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomScalar(r, size))
+}
+
+func TestScalarMultiplyInverse(t *testing.T) {
+	err := quick.Check(func(a *Scalar) bool {
+		if a.IsZero() {
+			return true
+		}
+		shouldBeOne := NewScalar().Set(a).Invert().Mul(a)
+		one := NewScalar().SetNat(new(saferith.Nat).SetUint64(1))
+		return shouldBeOne.Equal(one)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(a *Scalar) bool {
+		marshalled, err := a.MarshalBinary()
+		if err != nil || len(marshalled) != ScalarBytes {
+			return false
+		}
+		unmarshalled := NewScalar()
+		if err := unmarshalled.UnmarshalBinary(marshalled); err != nil {
+			return false
+		}
+		return unmarshalled.Equal(a)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarRejectsNonCanonical(t *testing.T) {
+	if NewScalar().UnmarshalBinary(r.Nat().FillBytes(make([]byte, ScalarBytes))) == nil {
+		t.Error("r should be rejected as a scalar")
+	}
+}
+
+func TestScalarCurve(t *testing.T) {
+	if _, ok := NewScalar().Curve().(G1Curve); !ok {
+		t.Error("NewScalar should be associated with G1")
+	}
+	if _, ok := (G2Curve{}).NewScalar().Curve().(G2Curve); !ok {
+		t.Error("G2Curve.NewScalar should be associated with G2")
+	}
+	if _, ok := (G2Curve{}).NewScalar().ActOnBase().(*G2Point); !ok {
+		t.Error("G2 scalars should act on the G2 generator")
+	}
+}
+
+func TestScalarActIsAdditive(t *testing.T) {
+	err := quick.Check(func(a, b *Scalar) bool {
+		abG1 := NewScalar().Set(a).Add(b).ActOnBase()
+		abG2 := newG2Scalar().Set(a).Add(b).ActOnBase()
+		return abG1.Equal(a.Act(g1Generator).Add(b.Act(g1Generator))) &&
+			abG2.Equal(a.Act(g2Generator).Add(b.Act(g2Generator)))
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarActOrderIsIdentity(t *testing.T) {
+	minusOne := NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).Negate()
+	if !minusOne.ActOnBase().Add(g1Generator).IsIdentity() {
+		t.Error("r * G1 should be the identity")
+	}
+	if !minusOne.Act(g2Generator).Add(g2Generator).IsIdentity() {
+		t.Error("r * G2 should be the identity")
+	}
+}
+
+func BenchmarkActG1(b *testing.B) {
+	s := randomScalar(rand.New(rand.NewSource(0)), ScalarBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Act(g1Generator)
+	}
+}
+
+func BenchmarkActG2(b *testing.B) {
+	s := randomScalar(rand.New(rand.NewSource(0)), ScalarBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Act(g2Generator)
+	}
+}
@@ -0,0 +1,85 @@
+package kyokusen
+
+import (
+	"errors"
+
+	"github.com/cronokirby/saferith"
+)
+
+// CofactorCurve is an optional interface for Curves whose group of points doesn't have prime order.
+//
+// For such curves, Order returns the prime order l of the subgroup generated
+// by the base point, and the full group of points has order Cofactor() * l.
+// Curves not implementing this interface are assumed to have prime order,
+// i.e. a cofactor of 1.
+type CofactorCurve interface {
+	Curve
+	// Cofactor returns the cofactor of this curve.
+	Cofactor() *saferith.Nat
+}
+
+// CofactorPoint is an optional interface for Points on curves with a cofactor.
+//
+// Points decoded from bytes might lie outside of the prime order subgroup,
+// and these methods let us detect, or remove, the small order component of such points.
+type CofactorPoint interface {
+	Point
+	// ClearCofactor returns a new Point, equal to this point multiplied by the cofactor.
+	//
+	// The result always lies in the prime order subgroup.
+	ClearCofactor() Point
+	// IsTorsionFree checks if this point lies in the prime order subgroup.
+	IsTorsionFree() bool
+}
+
+// Cofactor returns the cofactor of a curve.
+//
+// If the curve doesn't implement CofactorCurve, this returns 1.
+func Cofactor(curve Curve) *saferith.Nat {
+	if c, ok := curve.(CofactorCurve); ok {
+		return c.Cofactor()
+	}
+	return new(saferith.Nat).SetUint64(1)
+}
+
+// ClearCofactor multiplies a point by the cofactor of its curve.
+//
+// If the point doesn't implement CofactorPoint, it's returned unchanged.
+func ClearCofactor(p Point) Point {
+	if c, ok := p.(CofactorPoint); ok {
+		return c.ClearCofactor()
+	}
+	return p
+}
+
+// IsTorsionFree checks if a point lies in the prime order subgroup of its curve.
+//
+// If the point doesn't implement CofactorPoint, this always returns true.
+func IsTorsionFree(p Point) bool {
+	if c, ok := p.(CofactorPoint); ok {
+		return c.IsTorsionFree()
+	}
+	return true
+}
+
+// IsSmallOrder checks if a point has an order dividing the cofactor of its curve.
+//
+// For curves of prime order, only the identity has small order.
+func IsSmallOrder(p Point) bool {
+	return ClearCofactor(p).IsIdentity()
+}
+
+// UnmarshalPrimeOrderPoint decodes a point, only accepting points in the prime order subgroup.
+//
+// This returns an error if the point can't be decoded, or lies outside the
+// prime order subgroup. The identity point is accepted, if the curve can encode it.
+func UnmarshalPrimeOrderPoint(curve Curve, data []byte) (Point, error) {
+	p := curve.NewPoint()
+	if err := p.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if !IsTorsionFree(p) {
+		return nil, errors.New("kyokusen.UnmarshalPrimeOrderPoint: point is not in the prime order subgroup")
+	}
+	return p, nil
+}
@@ -0,0 +1,63 @@
+package kyokusen_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/edwards25519"
+	"github.com/cronokirby/kyokusen/secp256k1"
+	"github.com/cronokirby/saferith"
+)
+
+// smallOrderHex encodes a point of order 8 on edwards25519.
+const smallOrderHex = "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"
+
+func TestCofactorDefaultsToOne(t *testing.T) {
+	if kyokusen.Cofactor(secp256k1.Curve{}).Eq(new(saferith.Nat).SetUint64(1)) != 1 {
+		t.Error("secp256k1 should have a cofactor of 1")
+	}
+	if kyokusen.Cofactor(edwards25519.Curve{}).Eq(new(saferith.Nat).SetUint64(8)) != 1 {
+		t.Error("edwards25519 should have a cofactor of 8")
+	}
+	P := randomScalar(secp256k1.Curve{}).ActOnBase()
+	if !kyokusen.IsTorsionFree(P) || !kyokusen.ClearCofactor(P).Equal(P) || kyokusen.IsSmallOrder(P) {
+		t.Error("points on prime order curves should be torsion free")
+	}
+	if !kyokusen.IsSmallOrder(secp256k1.NewPoint()) {
+		t.Error("the identity should have small order")
+	}
+}
+
+func TestUnmarshalPrimeOrderPoint(t *testing.T) {
+	curve := edwards25519.Curve{}
+	smallOrder, _ := hex.DecodeString(smallOrderHex)
+	T := curve.NewPoint()
+	if err := T.UnmarshalBinary(smallOrder); err != nil {
+		t.Fatal(err)
+	}
+	if !kyokusen.IsSmallOrder(T) {
+		t.Error("point should have small order")
+	}
+	if _, err := kyokusen.UnmarshalPrimeOrderPoint(curve, smallOrder); err == nil {
+		t.Error("small order point should be rejected")
+	}
+
+	P := randomScalar(curve).ActOnBase()
+	mixed, _ := P.Add(T).MarshalBinary()
+	if _, err := kyokusen.UnmarshalPrimeOrderPoint(curve, mixed); err == nil {
+		t.Error("mixed order point should be rejected")
+	}
+	if kyokusen.IsSmallOrder(P.Add(T)) {
+		t.Error("mixed order point shouldn't have small order")
+	}
+
+	data, _ := P.MarshalBinary()
+	decoded, err := kyokusen.UnmarshalPrimeOrderPoint(curve, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(P) {
+		t.Error("incorrect decoding")
+	}
+}
@@ -0,0 +1,155 @@
+// Package ecdsa implements ECDSA signatures over any kyokusen.Curve.
+//
+// This package works with prehashed messages: the caller is responsible for
+// hashing the message, and passing in the resulting digest. The only requirement
+// on the curve is that its points implement the XScalar method.
+package ecdsa
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/rfc6979"
+	"github.com/cronokirby/saferith"
+)
+
+var (
+	// ErrZeroR is returned by SignWithNonce when the nonce produces r = 0.
+	//
+	// In that case, the signature should be retried with a different nonce.
+	ErrZeroR = errors.New("r is zero")
+	// ErrZeroS is returned by SignWithNonce when the nonce produces s = 0.
+	//
+	// In that case, the signature should be retried with a different nonce.
+	ErrZeroS = errors.New("s is zero")
+)
+
+// DigestToScalar converts a digest into a scalar, following the ECDSA specification.
+//
+// This takes the leftmost ScalarBits() bits of the digest, interpreted as a Big Endian
+// number, and then reduces that number modulo the order of the curve.
+func DigestToScalar(curve kyokusen.Curve, digest []byte) kyokusen.Scalar {
+	bits := curve.ScalarBits()
+	// We only need enough bytes to cover the bits of a scalar.
+	byteLen := (bits + 7) / 8
+	if len(digest) > byteLen {
+		digest = digest[:byteLen]
+	}
+	var nat saferith.Nat
+	nat.SetBytes(digest)
+	// If we have more bits than we need, we shift out the excess on the right.
+	if excess := 8*len(digest) - bits; excess > 0 {
+		nat.Rsh(&nat, uint(excess), bits)
+	}
+	return curve.NewScalar().SetNat(&nat)
+}
+
+// sampleScalar samples a uniform non-zero scalar, using some source of randomness.
+func sampleScalar(rand io.Reader, curve kyokusen.Curve) (kyokusen.Scalar, error) {
+	data := make([]byte, curve.SafeScalarBytes())
+	for {
+		if _, err := io.ReadFull(rand, data); err != nil {
+			return nil, err
+		}
+		s := curve.NewScalar().SetNat(new(saferith.Nat).SetBytes(data))
+		if !s.IsZero() {
+			return s, nil
+		}
+	}
+}
+
+// Sign creates an ECDSA signature for a digest, using a secret key.
+//
+// The nonce used in the signature is sampled using rand. The result is a pair
+// of non-zero scalars (r, s). This returns an error if the points of the curve
+// don't implement XScalar.
+func Sign(rand io.Reader, secret kyokusen.Scalar, digest []byte) (r, s kyokusen.Scalar, err error) {
+	curve := secret.Curve()
+	if curve.NewBasePoint().XScalar() == nil {
+		return nil, nil, errors.New("ecdsa.Sign: curve doesn't implement XScalar")
+	}
+	e := DigestToScalar(curve, digest)
+	for {
+		k, err := sampleScalar(rand, curve)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, s, err = SignWithNonce(secret, k, e)
+		// With a zero r or s, we try again, with a different nonce.
+		if errors.Is(err, ErrZeroR) || errors.Is(err, ErrZeroS) {
+			continue
+		}
+		return r, s, err
+	}
+}
+
+// SignDeterministic creates an ECDSA signature for a digest, deriving the nonce following RFC 6979.
+//
+// The hash function h is used to derive the nonce, and should usually be the same
+// hash function used to produce the digest.
+func SignDeterministic(h func() hash.Hash, secret kyokusen.Scalar, digest []byte) (r, s kyokusen.Scalar, err error) {
+	k := rfc6979.Nonce(h, secret, digest)
+	return SignWithNonce(secret, k, DigestToScalar(secret.Curve(), digest))
+}
+
+// SignWithNonce creates an ECDSA signature, using a given nonce, and a digest already converted to a scalar.
+//
+// This will return ErrZeroR or ErrZeroS if either r or s end up being zero.
+// In that case, a different nonce should be used.
+//
+// The nonce must be kept secret, and never used more than once.
+func SignWithNonce(secret, k, e kyokusen.Scalar) (r, s kyokusen.Scalar, err error) {
+	curve := secret.Curve()
+	r = k.ActOnBase().XScalar()
+	if r == nil {
+		return nil, nil, errors.New("ecdsa.SignWithNonce: curve doesn't implement XScalar")
+	}
+	if r.IsZero() {
+		return nil, nil, fmt.Errorf("ecdsa.SignWithNonce: %w", ErrZeroR)
+	}
+	// s = k^-1 (e + r * secret)
+	s = curve.NewScalar().Set(r).Mul(secret).Add(e)
+	s.Mul(curve.NewScalar().Set(k).Invert())
+	if s.IsZero() {
+		return nil, nil, fmt.Errorf("ecdsa.SignWithNonce: %w", ErrZeroS)
+	}
+	return r, s, nil
+}
+
+// Verify checks that (r, s) is a valid signature of a digest, under a given public key.
+//
+// This returns nil if the signature is valid, and an error otherwise.
+func Verify(public kyokusen.Point, digest []byte, r, s kyokusen.Scalar) error {
+	// On curves with a cofactor, we also reject points outside of the prime order subgroup.
+	if public == nil || public.IsIdentity() || !kyokusen.IsTorsionFree(public) {
+		return errors.New("ecdsa.Verify: invalid public key")
+	}
+	if r.IsZero() {
+		return errors.New("ecdsa.Verify: r is zero")
+	}
+	if s.IsZero() {
+		return errors.New("ecdsa.Verify: s is zero")
+	}
+	curve := public.Curve()
+	e := DigestToScalar(curve, digest)
+	// u1 = e / s, u2 = r / s
+	sInv := curve.NewScalar().Set(s).Invert()
+	u1 := e.Mul(sInv)
+	u2 := curve.NewScalar().Set(r).Mul(sInv)
+	// Every input here is public, so we can use variable-time operations.
+	R := kyokusen.VartimeDoubleBaseMul(u1, u2, public)
+	if R.IsIdentity() {
+		return errors.New("ecdsa.Verify: invalid signature")
+	}
+	x := R.XScalar()
+	if x == nil {
+		return errors.New("ecdsa.Verify: curve doesn't implement XScalar")
+	}
+	if !x.Equal(r) {
+		return errors.New("ecdsa.Verify: invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package ecdsa_test
+
+import (
+	stdecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/ecdsa"
+	"github.com/cronokirby/kyokusen/edwards25519"
+	"github.com/cronokirby/kyokusen/p256"
+	"github.com/cronokirby/kyokusen/p384"
+	"github.com/cronokirby/kyokusen/p521"
+	"github.com/cronokirby/kyokusen/secp256k1"
+	"github.com/cronokirby/saferith"
+)
+
+func randomKeyPair(t *testing.T, curve kyokusen.Curve) (kyokusen.Scalar, kyokusen.Point) {
+	secret, err := ecdsa.SampleScalar(rand.Reader, curve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return secret, secret.ActOnBase()
+}
+
+func TestSignThenVerify(t *testing.T) {
+	secret, public := randomKeyPair(t, secp256k1.Curve{})
+	digest := sha256.Sum256([]byte("hello world"))
+	r, s, err := ecdsa.Sign(rand.Reader, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ecdsa.Verify(public, digest[:], r, s); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSignRejectsCurveWithoutXScalar(t *testing.T) {
+	secret, _ := randomKeyPair(t, edwards25519.Curve{})
+	digest := sha256.Sum256([]byte("hello world"))
+	if _, _, err := ecdsa.Sign(rand.Reader, secret, digest[:]); err == nil {
+		t.Error("expected an error for a curve without XScalar")
+	}
+}
+
+func TestVerifyRejectsWrongDigest(t *testing.T) {
+	secret, public := randomKeyPair(t, secp256k1.Curve{})
+	digest := sha256.Sum256([]byte("hello world"))
+	r, s, err := ecdsa.Sign(rand.Reader, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := sha256.Sum256([]byte("goodbye world"))
+	if ecdsa.Verify(public, other[:], r, s) == nil {
+		t.Error("signature verified for the wrong digest")
+	}
+}
+
+func TestVerifyRejectsWrongKey(t *testing.T) {
+	secret, _ := randomKeyPair(t, secp256k1.Curve{})
+	_, public := randomKeyPair(t, secp256k1.Curve{})
+	digest := sha256.Sum256([]byte("hello world"))
+	r, s, err := ecdsa.Sign(rand.Reader, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ecdsa.Verify(public, digest[:], r, s) == nil {
+		t.Error("signature verified for the wrong public key")
+	}
+}
+
+func TestVerifyRejectsZero(t *testing.T) {
+	curve := secp256k1.Curve{}
+	secret, public := randomKeyPair(t, curve)
+	digest := sha256.Sum256([]byte("hello world"))
+	r, s, err := ecdsa.Sign(rand.Reader, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ecdsa.Verify(public, digest[:], curve.NewScalar(), s) == nil {
+		t.Error("signature verified with r = 0")
+	}
+	if ecdsa.Verify(public, digest[:], r, curve.NewScalar()) == nil {
+		t.Error("signature verified with s = 0")
+	}
+}
+
+func TestVerifyRejectsIdentity(t *testing.T) {
+	curve := secp256k1.Curve{}
+	secret, _ := randomKeyPair(t, curve)
+	digest := sha256.Sum256([]byte("hello world"))
+	r, s, err := ecdsa.Sign(rand.Reader, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ecdsa.Verify(curve.NewPoint(), digest[:], r, s) == nil {
+		t.Error("signature verified with the identity as a public key")
+	}
+}
+
+func TestDigestToScalarTruncates(t *testing.T) {
+	curve := secp256k1.Curve{}
+	digest := make([]byte, 64)
+	for i := range digest {
+		digest[i] = byte(i)
+	}
+	expected := ecdsa.DigestToScalar(curve, digest[:32])
+	if !ecdsa.DigestToScalar(curve, digest).Equal(expected) {
+		t.Error("long digests should be truncated to the leftmost bits")
+	}
+}
+
+func scalarFromHex(t *testing.T, curve kyokusen.Curve, s string) kyokusen.Scalar {
+	nat, err := new(saferith.Nat).SetHex(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return curve.NewScalar().SetNat(nat)
+}
+
+func TestSignWithNonceSecp256k1Vectors(t *testing.T) {
+	curve := secp256k1.Curve{}
+	vectors := []struct {
+		key, hash, nonce, r, s string
+	}{
+		{
+			key:   "0000000000000000000000000000000000000000000000000000000000000001",
+			hash:  "C301BA9DE5D6053CAAD9F5EB46523F007702ADD2C62FA39DE03146A36B8026B7",
+			nonce: "A6DF66500AFEB7711D4C8E2220960855D940A5ED57260D2C98FBF6066CCA283E",
+			r:     "B073759A96A835B09B79E7B93C37FDBE48FB82B000C4A0E1404BA5D1FBC15D0A",
+			s:     "7E34928A3E3832EC21E7711644D9388F7DEB6340EAD661D7056B0665974B87F3",
+		},
+		{
+			key:   "0000000000000000000000000000000000000000000000000000000000000002",
+			hash:  "DC063EBA3C8D52A159E725C1A161506F6CB6B53478AD5EF3F08D534EFA871D9F",
+			nonce: "026ECE4CFB704733DD5EEF7898E44C33BD5A0D749EB043F48705E40FA9E9AFA0",
+			r:     "3C4C5A2F217EA758113FD4E89EB756314DFAD101A300F48E5BD764D3B6E0F8BF",
+			s:     "6513E82442F133CB892514926ED9158328EAD488FF1B027A31827603A65009DF",
+		},
+	}
+	for i, v := range vectors {
+		secret := scalarFromHex(t, curve, v.key)
+		digest, _ := hex.DecodeString(v.hash)
+		k := scalarFromHex(t, curve, v.nonce)
+		r, s, err := ecdsa.SignWithNonce(secret, k, ecdsa.DigestToScalar(curve, digest))
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if !r.Equal(scalarFromHex(t, curve, v.r)) {
+			t.Errorf("vector %d: incorrect r", i)
+		}
+		// These vectors have been normalized to have a low s, so we also accept -s.
+		expectedS := scalarFromHex(t, curve, v.s)
+		if !s.Equal(expectedS) && !s.Equal(curve.NewScalar().Set(expectedS).Negate()) {
+			t.Errorf("vector %d: incorrect s", i)
+		}
+		if err := ecdsa.Verify(secret.ActOnBase(), digest, r, s); err != nil {
+			t.Errorf("vector %d: %v", i, err)
+		}
+	}
+}
+
+func TestSignDeterministicSecp256k1Vector(t *testing.T) {
+	curve := secp256k1.Curve{}
+	secret := scalarFromHex(t, curve, "0000000000000000000000000000000000000000000000000000000000000001")
+	digest := sha256.Sum256([]byte("Satoshi Nakamoto"))
+	r, s, err := ecdsa.SignDeterministic(sha256.New, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(scalarFromHex(t, curve, "934B1EA10A4B3C1757E2B0C017D0B6143CE3C9A7E6A4A49860D7A6AB210EE3D8")) {
+		t.Error("incorrect r")
+	}
+	expectedS := scalarFromHex(t, curve, "2442CE9D2B916064108014783E923EC36B49743E2FFA1C4496F01A512AAFD9E5")
+	if !s.Equal(expectedS) && !s.Equal(curve.NewScalar().Set(expectedS).Negate()) {
+		t.Error("incorrect s")
+	}
+	if err := ecdsa.Verify(secret.ActOnBase(), digest[:], r, s); err != nil {
+		t.Error(err)
+	}
+}
+
+// rfc6979Vector is a signature using SHA-256, and the nonce generation from RFC 6979.
+type rfc6979Vector struct {
+	msg, r, s string
+}
+
+// marshalUncompressed is implemented by the points of the NIST curves.
+type marshalUncompressed interface {
+	MarshalUncompressed() ([]byte, error)
+}
+
+func testSignDeterministicVectors(t *testing.T, curve kyokusen.Curve, secretHex, x, y string, vectors []rfc6979Vector) {
+	secret := scalarFromHex(t, curve, secretHex)
+	public, _ := secret.ActOnBase().(marshalUncompressed).MarshalUncompressed()
+	rlen := (curve.ScalarBits() + 7) / 8
+	expectedPublic := "04" + strings.Repeat("0", 2*rlen-len(x)) + x + strings.Repeat("0", 2*rlen-len(y)) + y
+	if hex.EncodeToString(public) != strings.ToLower(expectedPublic) {
+		t.Errorf("incorrect public key %x", public)
+	}
+	for i, v := range vectors {
+		digest := sha256.Sum256([]byte(v.msg))
+		r, s, err := ecdsa.SignDeterministic(sha256.New, secret, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.Equal(scalarFromHex(t, curve, v.r)) || !s.Equal(scalarFromHex(t, curve, v.s)) {
+			t.Errorf("vector %d: incorrect signature", i)
+		}
+		if err := ecdsa.Verify(secret.ActOnBase(), digest[:], r, s); err != nil {
+			t.Errorf("vector %d: %v", i, err)
+		}
+	}
+}
+
+// These vectors come from RFC 6979, section A.2.5, along with a vector from the
+// Go standard library which makes the generation of the nonce loop.
+func TestSignDeterministicP256Vectors(t *testing.T) {
+	testSignDeterministicVectors(t, p256.Curve{},
+		"C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+		"60FED4BA255A9D31C961EB74C6356D68C049B8923B61FA6CE669622E60F29FB6",
+		"7903FE1008B8BC99A41AE9E95628BC64F2F1B20C2D7E9F5177A3C294D4462299",
+		[]rfc6979Vector{
+			{
+				"sample",
+				"EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+				"F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+			},
+			{
+				"test",
+				"F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+				"019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083",
+			},
+			{
+				"wv[vnX",
+				"EFD9073B652E76DA1B5A019C0E4A2E3FA529B035A6ABB91EF67F0ED7A1F21234",
+				"3DB4706C9D9F4A4FE13BB5E08EF0FAB53A57DBAB2061C83A35FA411C68D2BA33",
+			},
+		})
+}
+
+// These vectors come from RFC 6979, section A.2.6.
+func TestSignDeterministicP384Vectors(t *testing.T) {
+	testSignDeterministicVectors(t, p384.Curve{},
+		"6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5",
+		"EC3A4E415B4E19A4568618029F427FA5DA9A8BC4AE92E02E06AAE5286B300C64DEF8F0EA9055866064A254515480BC13",
+		"8015D9B72D7D57244EA8EF9AC0C621896708A59367F9DFB9F54CA84B3F1C9DB1288B231C3AE0D4FE7344FD2533264720",
+		[]rfc6979Vector{
+			{
+				"sample",
+				"21B13D1E013C7FA1392D03C5F99AF8B30C570C6F98D4EA8E354B63A21D3DAA33BDE1E888E63355D92FA2B3C36D8FB2CD",
+				"F3AA443FB107745BF4BD77CB3891674632068A10CA67E3D45DB2266FA7D1FEEBEFDC63ECCD1AC42EC0CB8668A4FA0AB0",
+			},
+			{
+				"test",
+				"6D6DEFAC9AB64DABAFE36C6BF510352A4CC27001263638E5B16D9BB51D451559F918EEDAF2293BE5B475CC8F0188636B",
+				"2D46F3BECBCC523D5F1A1256BF0C9B024D879BA9E838144C8BA6BAEB4B53B47D51AB373F9845C0514EEFB14024787265",
+			},
+		})
+}
+
+// These vectors come from RFC 6979, section A.2.7.
+func TestSignDeterministicP521Vectors(t *testing.T) {
+	testSignDeterministicVectors(t, p521.Curve{},
+		"0FAD06DAA62BA3B25D2FB40133DA757205DE67F5BB0018FEE8C86E1B68C7E75CAA896EB32F1F47C70855836A6D16FCC1466F6D8FBEC67DB89EC0C08B0E996B83538",
+		"1894550D0785932E00EAA23B694F213F8C3121F86DC97A04E5A7167DB4E5BCD371123D46E45DB6B5D5370A7F20FB633155D38FFA16D2BD761DCAC474B9A2F5023A4",
+		"0493101C962CD4D2FDDF782285E64584139C2F91B47F87FF82354D6630F746A28A0DB25741B5B34A828008B22ACC23F924FAAFBD4D33F81EA66956DFEAA2BFDFCF5",
+		[]rfc6979Vector{
+			{
+				"sample",
+				"1511BB4D675114FE266FC4372B87682BAECC01D3CC62CF2303C92B3526012659D16876E25C7C1E57648F23B73564D67F61C6F14D527D54972810421E7D87589E1A7",
+				"04A171143A83163D6DF460AAF61522695F207A58B95C0644D87E52AA1A347916E4F7A72930B1BC06DBE22CE3F58264AFD23704CBB63B29B931F7DE6C9D949A7ECFC",
+			},
+			{
+				"test",
+				"00E871C4A14F993C6C7369501900C4BC1E9C7B0B4BA44E04868B30B41D8071042EB28C4C250411D0CE08CD197E4188EA4876F279F90B3D8D74A3C76E6F1E4656AA8",
+				"0CD52DBAA33B063C3A6CD8058A1FB0A46A4754B034FCC644766CA14DA8CA5CA9FDE00E88C1AD60CCBA759025299079D7A427EC3CC5B619BFBC828E7769BCD694E86",
+			},
+		})
+}
+
+func testInteroperatesWithStandardLibrary(t *testing.T, curve kyokusen.Curve, stdCurve elliptic.Curve) {
+	secret, public := randomKeyPair(t, curve)
+	secretBytes, _ := secret.MarshalBinary()
+	publicBytes, _ := public.(marshalUncompressed).MarshalUncompressed()
+	x, y := elliptic.Unmarshal(stdCurve, publicBytes)
+	key := &stdecdsa.PrivateKey{
+		PublicKey: stdecdsa.PublicKey{Curve: stdCurve, X: x, Y: y},
+		D:         new(big.Int).SetBytes(secretBytes),
+	}
+	digest := sha256.Sum256([]byte("hello world"))
+
+	r, s, err := ecdsa.Sign(rand.Reader, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	rBytes, _ := r.MarshalBinary()
+	sBytes, _ := s.MarshalBinary()
+	if !stdecdsa.Verify(&key.PublicKey, digest[:], new(big.Int).SetBytes(rBytes), new(big.Int).SetBytes(sBytes)) {
+		t.Error("the standard library rejected our signature")
+	}
+
+	rInt, sInt, err := stdecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = curve.NewScalar().SetNat(new(saferith.Nat).SetBig(rInt, curve.ScalarBits()))
+	s = curve.NewScalar().SetNat(new(saferith.Nat).SetBig(sInt, curve.ScalarBits()))
+	if err := ecdsa.Verify(public, digest[:], r, s); err != nil {
+		t.Errorf("we rejected the standard library's signature: %v", err)
+	}
+}
+
+func TestInteroperatesWithStandardLibrary(t *testing.T) {
+	t.Run("P-256", func(t *testing.T) {
+		testInteroperatesWithStandardLibrary(t, p256.Curve{}, elliptic.P256())
+	})
+	t.Run("P-384", func(t *testing.T) {
+		testInteroperatesWithStandardLibrary(t, p384.Curve{}, elliptic.P384())
+	})
+	t.Run("P-521", func(t *testing.T) {
+		testInteroperatesWithStandardLibrary(t, p521.Curve{}, elliptic.P521())
+	})
+}
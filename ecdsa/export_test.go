@@ -0,0 +1,6 @@
+package ecdsa
+
+// SampleScalar exposes sampleScalar to the tests in package ecdsa_test.
+//
+// These live in a separate package, since they use curves which import this one.
+var SampleScalar = sampleScalar
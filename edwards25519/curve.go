@@ -0,0 +1,55 @@
+// Package edwards25519 implements the edwards25519 curve, as used in Ed25519.
+//
+// Unlike most other curves in this library, this curve doesn't have prime order:
+// the full group has order 8 * l, where l is the prime order of the subgroup
+// generated by the base point. Scalars are taken modulo l. Points decoded from
+// bytes may have a small order component, which can be checked with IsTorsionFree,
+// or removed with ClearCofactor.
+//
+// If you need a prime order group, consider using ristretto255 instead.
+package edwards25519
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Curve represents the edwards25519 curve, implementing the kyokusen.Curve interface.
+type Curve struct{}
+
+func (Curve) NewPoint() kyokusen.Point {
+	return NewPoint()
+}
+
+func (Curve) NewBasePoint() kyokusen.Point {
+	return NewBasePoint()
+}
+
+func (Curve) NewScalar() kyokusen.Scalar {
+	return NewScalar()
+}
+
+func (Curve) Name() string {
+	return "edwards25519"
+}
+
+func (Curve) ScalarBits() int {
+	return 253
+}
+
+func (Curve) SafeScalarBytes() int {
+	return 64
+}
+
+// Order returns the order of the prime order subgroup, which scalars are taken modulo.
+func (Curve) Order() *saferith.Modulus {
+	return l
+}
+
+// Cofactor returns the cofactor of the curve, 8.
+//
+// The full group of points has order Cofactor() * Order().
+// This implements the kyokusen.CofactorCurve interface.
+func (Curve) Cofactor() *saferith.Nat {
+	return new(saferith.Nat).SetUint64(cofactor)
+}
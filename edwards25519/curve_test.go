@@ -0,0 +1,11 @@
+package edwards25519
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/kyokusentest"
+)
+
+func TestConformance(t *testing.T) {
+	kyokusentest.TestCurve(t, Curve{})
+}
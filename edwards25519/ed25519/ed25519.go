@@ -0,0 +1,178 @@
+// Package ed25519 implements Ed25519 signatures, following RFC 8032, over edwards25519.
+//
+// Verification comes in two flavors. Verify uses the cofactored equation
+// [8][S]B = [8]R + [8][k]A, as recommended by RFC 8032, and gives the same
+// answer as batch verification would. VerifyCofactorless uses the equation
+// [S]B = R + [k]A, which is what many existing implementations check.
+// These two only disagree for signatures involving points of small order,
+// which honest signers never produce.
+//
+// In both cases, we reject non-canonical encodings of points, and values of S
+// which aren't reduced modulo the order of the group.
+package ed25519
+
+import (
+	"crypto/sha512"
+	"errors"
+	"io"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/edwards25519"
+)
+
+// SeedBytes is the number of bytes in the seed of a private key.
+const SeedBytes = 32
+
+// PublicKeyBytes is the number of bytes in a public key.
+const PublicKeyBytes = 32
+
+// SignatureBytes is the number of bytes in a signature.
+const SignatureBytes = 64
+
+// PrivateKey holds an Ed25519 private key, along with the values derived from it.
+type PrivateKey struct {
+	seed   []byte
+	secret *edwards25519.Scalar
+	prefix []byte
+	public []byte
+}
+
+// NewPrivateKey derives a private key from a 32 byte seed, following section 5.1.5 of RFC 8032.
+func NewPrivateKey(seed []byte) (*PrivateKey, error) {
+	if len(seed) != SeedBytes {
+		return nil, errors.New("ed25519.NewPrivateKey: invalid seed length")
+	}
+	h := sha512.Sum512(seed)
+	// We clamp the first half of the hash, and use it as a little endian scalar.
+	wide := make([]byte, edwards25519.UniformBytes)
+	copy(wide, h[:32])
+	wide[0] &= 248
+	wide[31] &= 127
+	wide[31] |= 64
+	secret := edwards25519.NewScalar()
+	if err := secret.SetUniformBytes(wide); err != nil {
+		return nil, err
+	}
+	public, err := secret.ActOnBase().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{
+		seed:   append([]byte{}, seed...),
+		secret: secret,
+		prefix: append([]byte{}, h[32:]...),
+		public: public,
+	}, nil
+}
+
+// GenerateKey creates a new private key, using some source of randomness.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	seed := make([]byte, SeedBytes)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(seed)
+}
+
+// Seed returns the seed this private key was derived from.
+func (k *PrivateKey) Seed() []byte {
+	return append([]byte{}, k.seed...)
+}
+
+// PublicKey returns the encoded public key associated with this private key.
+func (k *PrivateKey) PublicKey() []byte {
+	return append([]byte{}, k.public...)
+}
+
+// Scalar returns the secret scalar a, such that the public key is a * B.
+//
+// This is useful for using an Ed25519 key with other protocols over edwards25519.
+func (k *PrivateKey) Scalar() *edwards25519.Scalar {
+	return edwards25519.NewScalar().Set(k.secret).(*edwards25519.Scalar)
+}
+
+// hashToScalar calculates SHA-512 of some data, interpreted as a little endian scalar.
+func hashToScalar(data ...[]byte) *edwards25519.Scalar {
+	h := sha512.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	s := edwards25519.NewScalar()
+	// This can't fail, since the output of SHA-512 has the right length.
+	_ = s.SetUniformBytes(h.Sum(nil))
+	return s
+}
+
+// Sign creates a signature of a message, following section 5.1.6 of RFC 8032.
+//
+// Signing is deterministic, so the same message will always produce the same signature.
+func Sign(key *PrivateKey, msg []byte) []byte {
+	r := hashToScalar(key.prefix, msg)
+	R, _ := r.ActOnBase().MarshalBinary()
+	k := hashToScalar(R, key.public, msg)
+	S := k.Mul(key.secret).Add(r).(*edwards25519.Scalar)
+
+	sig := make([]byte, 0, SignatureBytes)
+	sig = append(sig, R...)
+	sig = append(sig, S.Bytes()...)
+	return sig
+}
+
+// parse decodes the components of a signature, and the public key, returning [S]B - [k]A - R.
+func parse(public []byte, msg []byte, sig []byte) (*edwards25519.Point, error) {
+	if len(public) != PublicKeyBytes {
+		return nil, errors.New("invalid public key length")
+	}
+	if len(sig) != SignatureBytes {
+		return nil, errors.New("invalid signature length")
+	}
+	A := edwards25519.NewPoint()
+	if err := A.UnmarshalBinary(public); err != nil {
+		return nil, errors.New("invalid public key")
+	}
+	R := edwards25519.NewPoint()
+	if err := R.UnmarshalBinary(sig[:32]); err != nil {
+		return nil, errors.New("invalid signature")
+	}
+	S := edwards25519.NewScalar()
+	if err := S.SetCanonicalBytes(sig[32:]); err != nil {
+		return nil, errors.New("invalid signature")
+	}
+	k := hashToScalar(sig[:32], public, msg)
+	// A may have a small order component, so we can't negate k modulo l, and need to subtract [k]A instead.
+	// Every input here is public, so we can use variable-time operations.
+	SB := kyokusen.VartimeAct(S, edwards25519.NewBasePoint())
+	return SB.Sub(kyokusen.VartimeAct(k, A)).Sub(R).(*edwards25519.Point), nil
+}
+
+// Verify checks that a signature of a message is valid under a public key.
+//
+// This uses the cofactored verification equation [8][S]B = [8]R + [8][k]A.
+//
+// This returns nil if the signature is valid, and an error otherwise.
+func Verify(public []byte, msg []byte, sig []byte) error {
+	diff, err := parse(public, msg, sig)
+	if err != nil {
+		return errors.New("ed25519.Verify: " + err.Error())
+	}
+	if !diff.ClearCofactor().IsIdentity() {
+		return errors.New("ed25519.Verify: invalid signature")
+	}
+	return nil
+}
+
+// VerifyCofactorless checks that a signature of a message is valid under a public key.
+//
+// This uses the cofactorless verification equation [S]B = R + [k]A.
+//
+// This returns nil if the signature is valid, and an error otherwise.
+func VerifyCofactorless(public []byte, msg []byte, sig []byte) error {
+	diff, err := parse(public, msg, sig)
+	if err != nil {
+		return errors.New("ed25519.VerifyCofactorless: " + err.Error())
+	}
+	if !diff.IsIdentity() {
+		return errors.New("ed25519.VerifyCofactorless: invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package ed25519
+
+import (
+	"bufio"
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// TestSignVectors checks against the test vectors distributed with the reference implementation.
+//
+// The first three lines are the vectors TEST 1, TEST 2, and TEST 3 from section 7.1 of RFC 8032.
+// Each line has the format seed || public:public:message:signature || message:.
+func TestSignVectors(t *testing.T) {
+	file, err := os.Open("testdata/sign.input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for i := 1; scanner.Scan(); i++ {
+		parts := strings.Split(scanner.Text(), ":")
+		if len(parts) != 5 {
+			t.Fatalf("line %d: invalid format", i)
+		}
+		seed, _ := hex.DecodeString(parts[0][:2*SeedBytes])
+		public, _ := hex.DecodeString(parts[1])
+		msg, _ := hex.DecodeString(parts[2])
+		sig, _ := hex.DecodeString(parts[3][:2*SignatureBytes])
+
+		key, err := NewPrivateKey(seed)
+		if err != nil {
+			t.Fatalf("line %d: %v", i, err)
+		}
+		if !bytes.Equal(key.PublicKey(), public) {
+			t.Errorf("line %d: incorrect public key", i)
+		}
+		if actual := Sign(key, msg); !bytes.Equal(actual, sig) {
+			t.Errorf("line %d: incorrect signature", i)
+		}
+		if err := Verify(public, msg, sig); err != nil {
+			t.Errorf("line %d: %v", i, err)
+		}
+		if err := VerifyCofactorless(public, msg, sig); err != nil {
+			t.Errorf("line %d: %v", i, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSpeccheckVectors checks against the edge cases from "Taming the many EdDSAs",
+// by Chalkias, Garillot, and Nikolaenko.
+//
+// The test data comes from https://github.com/novifinancial/ed25519-speccheck.
+func TestSpeccheckVectors(t *testing.T) {
+	cases := []struct {
+		description  string
+		cofactored   bool
+		cofactorless bool
+	}{
+		{"small order A, small order R", true, true},
+		{"small order A, mixed order R", true, true},
+		{"mixed order A, small order R", true, true},
+		{"mixed order A, mixed order R", true, true},
+		{"cofactored verification", true, false},
+		{"cofactored verification computes 8(hA) instead of (8h mod l)A", true, false},
+		{"non-canonical S (S > l)", false, false},
+		{"non-canonical S (S >> l)", false, false},
+		{"non-canonical small order R, reduced before hashing", false, false},
+		{"non-canonical small order R, not reduced before hashing", false, false},
+		{"non-canonical small order A, reduced before hashing", false, false},
+		{"non-canonical small order A, not reduced before hashing", false, false},
+	}
+
+	data, err := os.ReadFile("testdata/speccheck_cases.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []struct {
+		Message   string `json:"message"`
+		PublicKey string `json:"pub_key"`
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != len(cases) {
+		t.Fatalf("expected %d vectors, found %d", len(cases), len(vectors))
+	}
+	for i, c := range cases {
+		msg, _ := hex.DecodeString(vectors[i].Message)
+		public, _ := hex.DecodeString(vectors[i].PublicKey)
+		sig, _ := hex.DecodeString(vectors[i].Signature)
+		if valid := Verify(public, msg, sig) == nil; valid != c.cofactored {
+			t.Errorf("%d (%s): cofactored verification returned %v", i, c.description, valid)
+		}
+		if valid := VerifyCofactorless(public, msg, sig) == nil; valid != c.cofactorless {
+			t.Errorf("%d (%s): cofactorless verification returned %v", i, c.description, valid)
+		}
+	}
+}
+
+func TestInteroperatesWithStandardLibrary(t *testing.T) {
+	err := quick.Check(func(seed [SeedBytes]byte, msg []byte) bool {
+		key, err := NewPrivateKey(seed[:])
+		if err != nil {
+			return false
+		}
+		stdKey := stded25519.NewKeyFromSeed(seed[:])
+		if !bytes.Equal(key.PublicKey(), stdKey.Public().(stded25519.PublicKey)) {
+			return false
+		}
+		sig := Sign(key, msg)
+		return bytes.Equal(sig, stded25519.Sign(stdKey, msg)) && stded25519.Verify(key.PublicKey(), msg, sig)
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerifyRejectsModifiedSignatures(t *testing.T) {
+	err := quick.Check(func(seed [SeedBytes]byte, msg []byte, index uint8) bool {
+		key, err := NewPrivateKey(seed[:])
+		if err != nil {
+			return false
+		}
+		sig := Sign(key, msg)
+		if Verify(key.PublicKey(), msg, sig) != nil {
+			return false
+		}
+		sig[int(index)%SignatureBytes] ^= 1
+		return Verify(key.PublicKey(), msg, sig) != nil && VerifyCofactorless(key.PublicKey(), msg, sig) != nil
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrivateKeyScalarMatchesPublicKey(t *testing.T) {
+	err := quick.Check(func(seed [SeedBytes]byte) bool {
+		key, err := NewPrivateKey(seed[:])
+		if err != nil {
+			return false
+		}
+		public, _ := key.Scalar().ActOnBase().MarshalBinary()
+		return bytes.Equal(public, key.PublicKey()) && bytes.Equal(key.Seed(), seed[:])
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,168 @@
+package edwards25519
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/curve25519"
+	"github.com/cronokirby/saferith"
+)
+
+// PointBytes is the number of bytes in the encoding of a point.
+const PointBytes = 32
+
+// cofactor is the cofactor of the curve.
+const cofactor = 8
+
+// lBytes holds the order of the prime order subgroup, as little endian bytes.
+var lBytes = curve25519.Reverse(l.Nat().FillBytes(make([]byte, ScalarBytes)))
+
+// Point represents a point on the edwards25519 curve.
+//
+// This point may lie outside of the prime order subgroup.
+type Point struct {
+	inner curve25519.Point
+}
+
+// NewPoint returns the identity point.
+func NewPoint() *Point {
+	return &Point{inner: *curve25519.NewIdentity()}
+}
+
+// NewBasePoint returns the generator of the prime order subgroup.
+func NewBasePoint() *Point {
+	return &Point{inner: *curve25519.NewBasePoint()}
+}
+
+// castPoint converts a point implementing the generic interface to this specific type.
+//
+// Since implementors of the Point interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+func castPoint(p kyokusen.Point) *Point {
+	casted, ok := p.(*Point)
+	if !ok {
+		panic("failed to cast type to *edwards25519.Point")
+	}
+	return casted
+}
+
+func (p *Point) String() string {
+	data, _ := p.MarshalBinary()
+	return fmt.Sprintf("%x", data)
+}
+
+// MarshalBinary encodes this point into 32 bytes, following section 5.1.2 of RFC 8032.
+//
+// This consists of the y coordinate, in little endian order, with the sign of
+// the x coordinate stored in the top bit.
+func (p *Point) MarshalBinary() ([]byte, error) {
+	zInv := curve25519.NewField().Set(&p.inner.Z).Invert()
+	x := curve25519.NewField().Set(&p.inner.X).Mul(zInv)
+	y := curve25519.NewField().Set(&p.inner.Y).Mul(zInv)
+	out := y.Bytes()
+	out[PointBytes-1] |= byte(x.IsNegative()) << 7
+	return out, nil
+}
+
+// UnmarshalBinary decodes a point from 32 bytes, following section 5.1.3 of RFC 8032.
+//
+// This rejects non-canonical encodings, i.e. those where the y coordinate isn't
+// reduced modulo p, or where the sign bit is set for x = 0.
+//
+// Note that the resulting point may lie outside of the prime order subgroup.
+func (p *Point) UnmarshalBinary(data []byte) error {
+	if len(data) != PointBytes {
+		return errors.New("edwards25519.Point.UnmarshalBinary: invalid data length")
+	}
+	yBytes := append([]byte{}, data...)
+	sign := saferith.Choice(yBytes[PointBytes-1] >> 7)
+	yBytes[PointBytes-1] &= 0x7F
+	y := curve25519.NewField()
+	if err := y.SetCanonicalBytes(yBytes); err != nil {
+		return errors.New("edwards25519.Point.UnmarshalBinary: non-canonical encoding")
+	}
+
+	// x^2 = (y^2 - 1) / (d y^2 + 1)
+	one := curve25519.NewField().SetUint64(1)
+	yy := curve25519.NewField().Set(y).Square()
+	u := curve25519.NewField().Set(yy).Sub(one)
+	v := curve25519.NewField().Set(yy).Mul(curve25519.D).Add(one)
+	x := curve25519.NewField()
+	if x.SqrtRatio(u, v) != 1 {
+		return errors.New("edwards25519.Point.UnmarshalBinary: point is not on the curve")
+	}
+	if (x.EqZero() & sign) == 1 {
+		return errors.New("edwards25519.Point.UnmarshalBinary: non-canonical encoding")
+	}
+	x.CondNegate(x.IsNegative() ^ sign)
+
+	p.inner.X.Set(x)
+	p.inner.Y.Set(y)
+	p.inner.Z.Set(one)
+	p.inner.T.Set(x).Mul(y)
+	return nil
+}
+
+func (*Point) Curve() kyokusen.Curve {
+	return Curve{}
+}
+
+func (p1 *Point) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := castPoint(other)
+	out := NewPoint()
+	out.inner.Add(&p1.inner, &p2.inner)
+	return out
+}
+
+func (p1 *Point) Sub(other kyokusen.Point) kyokusen.Point {
+	return p1.Add(other.Negate())
+}
+
+func (p *Point) Negate() kyokusen.Point {
+	out := NewPoint()
+	out.inner.Negate(&p.inner)
+	return out
+}
+
+func (p1 *Point) Equal(other kyokusen.Point) bool {
+	p2 := castPoint(other)
+	return p1.inner.Equal(&p2.inner) == 1
+}
+
+func (p *Point) IsIdentity() bool {
+	return p.inner.Equal(curve25519.NewIdentity()) == 1
+}
+
+// XScalar returns nil, since signatures over this curve use EdDSA, which never needs the x coordinate.
+func (p *Point) XScalar() kyokusen.Scalar {
+	return nil
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	out := &Point{inner: p.inner}
+	out.inner.CondAssign(yes, &castPoint(other).inner)
+	return out
+}
+
+// ClearCofactor returns a new point, equal to 8 times this point.
+//
+// The result always lies in the prime order subgroup.
+// This implements the kyokusen.CofactorPoint interface, along with IsTorsionFree.
+func (p *Point) ClearCofactor() kyokusen.Point {
+	out := NewPoint()
+	out.inner.MulByCofactor(&p.inner)
+	return out
+}
+
+// IsTorsionFree checks if this point lies in the prime order subgroup.
+//
+// This is done by checking that l times this point is the identity, in constant-time.
+func (p *Point) IsTorsionFree() bool {
+	var lp curve25519.Point
+	lp.ScalarMult(lBytes, &p.inner)
+	return lp.Equal(curve25519.NewIdentity()) == 1
+}
@@ -0,0 +1,145 @@
+package edwards25519
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+func randomPoint(r *rand.Rand, size int) *Point {
+	return randomScalar(r, size).ActOnBase().(*Point)
+}
+
+func (*Point) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randomPoint(r, size))
+}
+
+// smallOrderPoint returns a point of order 8.
+func smallOrderPoint() *Point {
+	data, _ := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	p := NewPoint()
+	if err := p.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func TestPointAdditionCommutative(t *testing.T) {
+	err := quick.Check(func(a, b *Point) bool {
+		return a.Add(b).Equal(b.Add(a))
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPointSubSelfIsIdentity(t *testing.T) {
+	err := quick.Check(func(a *Point) bool {
+		return a.Sub(a).IsIdentity() && a.Add(NewPoint()).Equal(a)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPointMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(a *Point) bool {
+		// We include a small order component, to check points outside of the subgroup.
+		for _, p := range []kyokusen.Point{a, a.Add(smallOrderPoint())} {
+			marshalled, err := p.MarshalBinary()
+			if err != nil {
+				return false
+			}
+			unmarshalled := NewPoint()
+			if err := unmarshalled.UnmarshalBinary(marshalled); err != nil {
+				return false
+			}
+			if !unmarshalled.Equal(p) {
+				return false
+			}
+		}
+		return true
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBasePointEncoding(t *testing.T) {
+	expected, _ := hex.DecodeString("5866666666666666666666666666666666666666666666666666666666666666")
+	encoded, _ := NewBasePoint().MarshalBinary()
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("expected %x, got %x", expected, encoded)
+	}
+	identity, _ := NewPoint().MarshalBinary()
+	if !bytes.Equal(identity, append([]byte{1}, make([]byte, PointBytes-1)...)) {
+		t.Errorf("incorrect encoding of identity: %x", identity)
+	}
+}
+
+func TestBadEncodings(t *testing.T) {
+	for _, encodedHex := range []string{
+		// y = p
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// y = p + 1, which would otherwise be the identity
+		"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// y = 1, with x = 0, but the sign bit set
+		"0100000000000000000000000000000000000000000000000000000000000080",
+		// y = 2, which isn't on the curve
+		"0200000000000000000000000000000000000000000000000000000000000000",
+	} {
+		encoded, _ := hex.DecodeString(encodedHex)
+		if NewPoint().UnmarshalBinary(encoded) == nil {
+			t.Errorf("%s: should have failed to decode", encodedHex)
+		}
+	}
+}
+
+func TestSmallOrderPoints(t *testing.T) {
+	T := smallOrderPoint()
+	var acc kyokusen.Point = NewPoint()
+	for i := 0; i < cofactor; i++ {
+		p := acc.(*Point)
+		if !kyokusen.IsSmallOrder(p) {
+			t.Errorf("%d * T should have small order", i)
+		}
+		if p.IsTorsionFree() != (i == 0) {
+			t.Errorf("%d * T: incorrect torsion check", i)
+		}
+		if !p.ClearCofactor().IsIdentity() {
+			t.Errorf("%d * T: clearing cofactor should give identity", i)
+		}
+		if i > 0 && p.IsIdentity() {
+			t.Errorf("%d * T shouldn't be the identity", i)
+		}
+		acc = acc.Add(T)
+	}
+	if !acc.IsIdentity() {
+		t.Error("8 * T should be the identity")
+	}
+}
+
+func TestTorsionFree(t *testing.T) {
+	err := quick.Check(func(a *Point) bool {
+		mixed := a.Add(smallOrderPoint()).(*Point)
+		return a.IsTorsionFree() &&
+			(a.IsIdentity() || !mixed.IsTorsionFree()) &&
+			mixed.ClearCofactor().(*Point).IsTorsionFree() &&
+			mixed.ClearCofactor().Equal(a.ClearCofactor())
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCofactor(t *testing.T) {
+	if (Curve{}).Cofactor().Eq(new(saferith.Nat).SetUint64(8)) != 1 {
+		t.Error("cofactor should be 8")
+	}
+}
@@ -0,0 +1,44 @@
+package edwards25519
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/curve25519"
+)
+
+// l is the order of the prime order subgroup.
+var l = curve25519.Order
+
+// ScalarBytes is the number of bytes in the encoding of a scalar.
+const ScalarBytes = curve25519.ScalarBytes
+
+// UniformBytes is the number of bytes needed by SetUniformBytes.
+const UniformBytes = curve25519.UniformBytes
+
+// group connects the scalars of this package to its points.
+type group struct{}
+
+func (group) Prefix() string {
+	return "edwards25519"
+}
+
+func (group) Curve() kyokusen.Curve {
+	return Curve{}
+}
+
+func (group) Unwrap(p kyokusen.Point) *curve25519.Point {
+	return &castPoint(p).inner
+}
+
+func (group) Wrap(p *curve25519.Point) kyokusen.Point {
+	return &Point{inner: *p}
+}
+
+// Scalar represents an integer modulo the order of the edwards25519 prime order subgroup.
+//
+// Besides kyokusen.Scalar, this implements kyokusen.VartimeScalar.
+type Scalar = curve25519.Scalar[group]
+
+// NewScalar returns a new scalar, with its value set to 0.
+func NewScalar() *Scalar {
+	return curve25519.NewScalar[group]()
+}
@@ -0,0 +1,99 @@
+package edwards25519
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/saferith"
+)
+
+// The arithmetic of scalars is tested in internal/curve25519, and these tests
+// only check how they act on the points of this package.
+
+func randomScalar(r *rand.Rand, size int) *Scalar {
+	data := make([]byte, ScalarBytes)
+	// Fill in a certain number of bytes with zero. Smaller sizes will be closer to zero.
+	for i := 0; i < size && i < len(data); i++ {
+		data[len(data)-i-1] = byte(r.Uint32())
+	}
+	return NewScalar().SetNat(new(saferith.Nat).SetBytes(data)).(*Scalar)
+}
+
+// scalarFromBytes reduces Big Endian bytes into a scalar, for use with quick.Check.
+func scalarFromBytes(data [ScalarBytes]byte) *Scalar {
+	return NewScalar().SetNat(new(saferith.Nat).SetBytes(data[:])).(*Scalar)
+}
+
+func TestScalarActIsAdditive(t *testing.T) {
+	err := quick.Check(func(aBytes, bBytes [ScalarBytes]byte, p *Point) bool {
+		a, b := scalarFromBytes(aBytes), scalarFromBytes(bBytes)
+		ap := a.Act(p)
+		bp := b.Act(p)
+		abp := NewScalar().Set(a).Add(b).Act(p)
+		return abp.Equal(ap.Add(bp))
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarActOrderIsIdentity(t *testing.T) {
+	minusOne := NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).Negate()
+	B := NewBasePoint()
+	if !minusOne.ActOnBase().Add(B).IsIdentity() {
+		t.Error("(l - 1) * B + B should be the identity")
+	}
+}
+
+func TestVartimeActMatchesAct(t *testing.T) {
+	err := quick.Check(func(data [ScalarBytes]byte, p *Point) bool {
+		s := scalarFromBytes(data)
+		return s.VartimeAct(p).Equal(s.Act(p))
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVartimeActWithSmallOrderComponent(t *testing.T) {
+	err := quick.Check(func(data [ScalarBytes]byte, p *Point) bool {
+		s := scalarFromBytes(data)
+		mixed := p.Add(smallOrderPoint())
+		return s.VartimeAct(mixed).Equal(s.Act(mixed))
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVartimeDoubleBaseMulMatchesAct(t *testing.T) {
+	err := quick.Check(func(aBytes, bBytes [ScalarBytes]byte, p *Point) bool {
+		a, b := scalarFromBytes(aBytes), scalarFromBytes(bBytes)
+		expected := a.ActOnBase().Add(b.Act(p))
+		return a.VartimeDoubleBaseMul(b, p).Equal(expected)
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkAct(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	s := randomScalar(r, ScalarBytes)
+	p := randomPoint(r, ScalarBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Act(p)
+	}
+}
+
+func BenchmarkVartimeAct(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	s := randomScalar(r, ScalarBytes)
+	p := randomPoint(r, ScalarBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.VartimeAct(p)
+	}
+}
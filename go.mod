@@ -1,5 +1,10 @@
 module github.com/cronokirby/kyokusen
 
-go 1.17
+go 1.20
 
-require github.com/cronokirby/saferith v0.31.0 // indirect
+require (
+	github.com/cronokirby/saferith v0.31.0
+	golang.org/x/crypto v0.11.0
+)
+
+require golang.org/x/sys v0.10.0 // indirect
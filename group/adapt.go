@@ -0,0 +1,311 @@
+package group
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// AdaptedCurve implements Group, using a curve implementing the kyokusen.Curve interface.
+//
+// The curve type C is carried by the points and scalars of this group, so that
+// points from two different curves have different types. C should be the
+// concrete type of a curve, like secp256k1.Curve, rather than kyokusen.Curve itself.
+type AdaptedCurve[C kyokusen.Curve] struct {
+	curve C
+}
+
+// Adapt converts a curve implementing the kyokusen.Curve interface into a Group.
+func Adapt[C kyokusen.Curve](curve C) AdaptedCurve[C] {
+	return AdaptedCurve[C]{curve: curve}
+}
+
+// Curve returns the underlying curve of this group.
+func (g AdaptedCurve[C]) Curve() C {
+	return g.curve
+}
+
+func (g AdaptedCurve[C]) NewPoint() *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: g.curve.NewPoint()}
+}
+
+func (g AdaptedCurve[C]) NewBasePoint() *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: g.curve.NewBasePoint()}
+}
+
+func (g AdaptedCurve[C]) NewScalar() *AdaptedScalar[C] {
+	return &AdaptedScalar[C]{inner: g.curve.NewScalar()}
+}
+
+func (g AdaptedCurve[C]) Name() string {
+	return g.curve.Name()
+}
+
+func (g AdaptedCurve[C]) ScalarBits() int {
+	return g.curve.ScalarBits()
+}
+
+func (g AdaptedCurve[C]) SafeScalarBytes() int {
+	return g.curve.SafeScalarBytes()
+}
+
+func (g AdaptedCurve[C]) Order() *saferith.Modulus {
+	return g.curve.Order()
+}
+
+// Cofactor returns the cofactor of the underlying curve, implementing CofactorGroup.
+//
+// This is 1 if the curve doesn't implement kyokusen.CofactorCurve.
+func (g AdaptedCurve[C]) Cofactor() *saferith.Nat {
+	return kyokusen.Cofactor(g.curve)
+}
+
+// unwrapPoints returns the underlying points of a slice of AdaptedPoints.
+func unwrapPoints[C kyokusen.Curve](points []*AdaptedPoint[C]) []kyokusen.Point {
+	out := make([]kyokusen.Point, len(points))
+	for i, p := range points {
+		out[i] = p.Unwrap()
+	}
+	return out
+}
+
+// unwrapScalars returns the underlying scalars of a slice of AdaptedScalars.
+func unwrapScalars[C kyokusen.Curve](scalars []*AdaptedScalar[C]) []kyokusen.Scalar {
+	out := make([]kyokusen.Scalar, len(scalars))
+	for i, s := range scalars {
+		out[i] = s.Unwrap()
+	}
+	return out
+}
+
+// MultiScalarMul implements MultiScalarMuler, using kyokusen.MultiScalarMul.
+func (g AdaptedCurve[C]) MultiScalarMul(scalars []*AdaptedScalar[C], points []*AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: kyokusen.MultiScalarMul(unwrapScalars(scalars), unwrapPoints(points))}
+}
+
+// VartimeMultiScalarMul implements MultiScalarMuler, using kyokusen.VartimeMultiScalarMul.
+func (g AdaptedCurve[C]) VartimeMultiScalarMul(scalars []*AdaptedScalar[C], points []*AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: kyokusen.VartimeMultiScalarMul(unwrapScalars(scalars), unwrapPoints(points))}
+}
+
+// HashToCurve implements HashableGroup, using kyokusen.HashToCurve.
+//
+// This returns an error if the curve doesn't implement kyokusen.HashableCurve.
+func (g AdaptedCurve[C]) HashToCurve(msg, dst []byte) (*AdaptedPoint[C], error) {
+	p, err := kyokusen.HashToCurve(g.curve, msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return &AdaptedPoint[C]{inner: p}, nil
+}
+
+// EncodeToCurve implements HashableGroup, using kyokusen.EncodeToCurve.
+//
+// This returns an error if the curve doesn't implement kyokusen.HashableCurve.
+func (g AdaptedCurve[C]) EncodeToCurve(msg, dst []byte) (*AdaptedPoint[C], error) {
+	p, err := kyokusen.EncodeToCurve(g.curve, msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return &AdaptedPoint[C]{inner: p}, nil
+}
+
+// checkCurve panics if a point or scalar doesn't belong to the curve C.
+func checkCurve[C kyokusen.Curve](curve kyokusen.Curve, name string) {
+	if _, ok := curve.(C); !ok {
+		panic("group." + name + ": value belongs to the curve " + curve.Name())
+	}
+}
+
+// AdaptedPoint implements Point, using a point implementing the kyokusen.Point interface.
+//
+// The zero value is the identity point.
+type AdaptedPoint[C kyokusen.Curve] struct {
+	inner kyokusen.Point
+}
+
+// WrapPoint converts a point implementing the kyokusen.Point interface into an AdaptedPoint.
+//
+// This panics if the point doesn't belong to the curve C.
+func WrapPoint[C kyokusen.Curve](p kyokusen.Point) *AdaptedPoint[C] {
+	checkCurve[C](p.Curve(), "WrapPoint")
+	return &AdaptedPoint[C]{inner: p}
+}
+
+// Unwrap returns the underlying point, implementing the kyokusen.Point interface.
+func (p *AdaptedPoint[C]) Unwrap() kyokusen.Point {
+	if p.inner == nil {
+		var curve C
+		return curve.NewPoint()
+	}
+	return p.inner
+}
+
+func (p *AdaptedPoint[C]) MarshalBinary() ([]byte, error) {
+	return p.Unwrap().MarshalBinary()
+}
+
+func (p *AdaptedPoint[C]) UnmarshalBinary(data []byte) error {
+	var curve C
+	inner := curve.NewPoint()
+	if err := inner.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	p.inner = inner
+	return nil
+}
+
+func (p *AdaptedPoint[C]) Add(other *AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: p.Unwrap().Add(other.Unwrap())}
+}
+
+func (p *AdaptedPoint[C]) Sub(other *AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: p.Unwrap().Sub(other.Unwrap())}
+}
+
+func (p *AdaptedPoint[C]) Negate() *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: p.Unwrap().Negate()}
+}
+
+func (p *AdaptedPoint[C]) Equal(other *AdaptedPoint[C]) bool {
+	return p.Unwrap().Equal(other.Unwrap())
+}
+
+func (p *AdaptedPoint[C]) IsIdentity() bool {
+	return p.Unwrap().IsIdentity()
+}
+
+// XScalar returns the x coordinate of this point as a scalar, implementing XScalarPoint.
+//
+// This returns false if the underlying point returns nil from its XScalar method.
+func (p *AdaptedPoint[C]) XScalar() (*AdaptedScalar[C], bool) {
+	x := p.Unwrap().XScalar()
+	if x == nil {
+		return nil, false
+	}
+	return &AdaptedScalar[C]{inner: x}, true
+}
+
+// ClearCofactor implements CofactorPoint, using kyokusen.ClearCofactor.
+func (p *AdaptedPoint[C]) ClearCofactor() *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: kyokusen.ClearCofactor(p.Unwrap())}
+}
+
+// IsTorsionFree implements CofactorPoint, using kyokusen.IsTorsionFree.
+func (p *AdaptedPoint[C]) IsTorsionFree() bool {
+	return kyokusen.IsTorsionFree(p.Unwrap())
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise, implementing SelectablePoint.
+//
+// If the underlying point doesn't implement kyokusen.SelectablePoint, this
+// falls back to a slower method, using Act, which is expected to be constant-time.
+func (p *AdaptedPoint[C]) CondSelect(yes saferith.Choice, other *AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: condSelect(yes, p.Unwrap(), other.Unwrap())}
+}
+
+// condSelect returns b if yes = 1, and a otherwise, in constant-time.
+//
+// This uses kyokusen.SelectablePoint if possible, and otherwise calculates a + yes * (b - a).
+func condSelect(yes saferith.Choice, a, b kyokusen.Point) kyokusen.Point {
+	if s, ok := a.(kyokusen.SelectablePoint); ok {
+		return s.CondSelect(yes, b)
+	}
+	bit := a.Curve().NewScalar().SetNat(new(saferith.Nat).SetUint64(uint64(yes)))
+	return a.Add(bit.Act(b.Sub(a)))
+}
+
+// AdaptedScalar implements Scalar, using a scalar implementing the kyokusen.Scalar interface.
+//
+// The zero value is the scalar 0.
+type AdaptedScalar[C kyokusen.Curve] struct {
+	inner kyokusen.Scalar
+}
+
+// WrapScalar converts a scalar implementing the kyokusen.Scalar interface into an AdaptedScalar.
+//
+// The scalar isn't copied, so mutating the result also mutates the original scalar.
+// This panics if the scalar doesn't belong to the curve C.
+func WrapScalar[C kyokusen.Curve](s kyokusen.Scalar) *AdaptedScalar[C] {
+	checkCurve[C](s.Curve(), "WrapScalar")
+	return &AdaptedScalar[C]{inner: s}
+}
+
+// Unwrap returns the underlying scalar, implementing the kyokusen.Scalar interface.
+//
+// The scalar isn't copied, so mutating the result also mutates this scalar.
+func (s *AdaptedScalar[C]) Unwrap() kyokusen.Scalar {
+	if s.inner == nil {
+		var curve C
+		s.inner = curve.NewScalar()
+	}
+	return s.inner
+}
+
+func (s *AdaptedScalar[C]) MarshalBinary() ([]byte, error) {
+	return s.Unwrap().MarshalBinary()
+}
+
+func (s *AdaptedScalar[C]) UnmarshalBinary(data []byte) error {
+	return s.Unwrap().UnmarshalBinary(data)
+}
+
+func (s *AdaptedScalar[C]) Add(other *AdaptedScalar[C]) *AdaptedScalar[C] {
+	s.Unwrap().Add(other.Unwrap())
+	return s
+}
+
+func (s *AdaptedScalar[C]) Sub(other *AdaptedScalar[C]) *AdaptedScalar[C] {
+	s.Unwrap().Sub(other.Unwrap())
+	return s
+}
+
+func (s *AdaptedScalar[C]) Negate() *AdaptedScalar[C] {
+	s.Unwrap().Negate()
+	return s
+}
+
+func (s *AdaptedScalar[C]) Mul(other *AdaptedScalar[C]) *AdaptedScalar[C] {
+	s.Unwrap().Mul(other.Unwrap())
+	return s
+}
+
+func (s *AdaptedScalar[C]) Invert() *AdaptedScalar[C] {
+	s.Unwrap().Invert()
+	return s
+}
+
+func (s *AdaptedScalar[C]) Equal(other *AdaptedScalar[C]) bool {
+	return s.Unwrap().Equal(other.Unwrap())
+}
+
+func (s *AdaptedScalar[C]) IsZero() bool {
+	return s.Unwrap().IsZero()
+}
+
+func (s *AdaptedScalar[C]) Set(other *AdaptedScalar[C]) *AdaptedScalar[C] {
+	s.Unwrap().Set(other.Unwrap())
+	return s
+}
+
+func (s *AdaptedScalar[C]) SetNat(x *saferith.Nat) *AdaptedScalar[C] {
+	s.Unwrap().SetNat(x)
+	return s
+}
+
+func (s *AdaptedScalar[C]) Act(p *AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: s.Unwrap().Act(p.Unwrap())}
+}
+
+func (s *AdaptedScalar[C]) ActOnBase() *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: s.Unwrap().ActOnBase()}
+}
+
+// VartimeAct implements VartimeScalar, using kyokusen.VartimeAct.
+func (s *AdaptedScalar[C]) VartimeAct(p *AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: kyokusen.VartimeAct(s.Unwrap(), p.Unwrap())}
+}
+
+// VartimeDoubleBaseMul implements VartimeScalar, using kyokusen.VartimeDoubleBaseMul.
+func (s *AdaptedScalar[C]) VartimeDoubleBaseMul(b *AdaptedScalar[C], p *AdaptedPoint[C]) *AdaptedPoint[C] {
+	return &AdaptedPoint[C]{inner: kyokusen.VartimeDoubleBaseMul(s.Unwrap(), b.Unwrap(), p.Unwrap())}
+}
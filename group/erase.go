@@ -0,0 +1,365 @@
+package group
+
+import (
+	"errors"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// erasedCurve implements kyokusen.Curve, using a Group.
+type erasedCurve[P Point[P], S Scalar[S, P]] struct {
+	group Group[P, S]
+}
+
+// Erase converts a Group into a curve implementing the kyokusen.Curve interface.
+//
+// This lets generic implementations be used with functions written against
+// the kyokusen interfaces. Like other implementations of those interfaces,
+// the points and scalars of the result panic when mixed with those of another curve.
+//
+// The result also implements the optional interfaces of the kyokusen package,
+// like kyokusen.CofactorCurve, or kyokusen.VartimeScalar for its scalars. These
+// forward to their counterparts in this package, like CofactorGroup, when the
+// group implements them, and otherwise use the same defaults as the kyokusen package.
+func Erase[P Point[P], S Scalar[S, P]](group Group[P, S]) kyokusen.Curve {
+	return erasedCurve[P, S]{group: group}
+}
+
+// ErasePoint converts a point of a Group into a point implementing the kyokusen.Point interface.
+func ErasePoint[P Point[P], S Scalar[S, P]](group Group[P, S], p P) kyokusen.Point {
+	return &erasedPoint[P, S]{group: group, inner: p}
+}
+
+// EraseScalar converts a scalar of a Group into a scalar implementing the kyokusen.Scalar interface.
+//
+// The scalar isn't copied, so mutating the result also mutates the original scalar.
+func EraseScalar[P Point[P], S Scalar[S, P]](group Group[P, S], s S) kyokusen.Scalar {
+	return &erasedScalar[P, S]{group: group, inner: s}
+}
+
+// UnerasePoint recovers the point of a Group, from a point returned by ErasePoint, or by an erased curve.
+//
+// This panics if the point wasn't created in this way, with the same type of points and scalars.
+func UnerasePoint[P Point[P], S Scalar[S, P]](p kyokusen.Point) P {
+	return castErasedPoint[P, S](p).inner
+}
+
+// UneraseScalar recovers the scalar of a Group, from a scalar returned by EraseScalar, or by an erased curve.
+//
+// This panics if the scalar wasn't created in this way, with the same type of points and scalars.
+func UneraseScalar[P Point[P], S Scalar[S, P]](s kyokusen.Scalar) S {
+	return castErasedScalar[P, S](s).inner
+}
+
+func (c erasedCurve[P, S]) NewPoint() kyokusen.Point {
+	return ErasePoint(c.group, c.group.NewPoint())
+}
+
+func (c erasedCurve[P, S]) NewBasePoint() kyokusen.Point {
+	return ErasePoint(c.group, c.group.NewBasePoint())
+}
+
+func (c erasedCurve[P, S]) NewScalar() kyokusen.Scalar {
+	return EraseScalar(c.group, c.group.NewScalar())
+}
+
+func (c erasedCurve[P, S]) Name() string {
+	return c.group.Name()
+}
+
+func (c erasedCurve[P, S]) ScalarBits() int {
+	return c.group.ScalarBits()
+}
+
+func (c erasedCurve[P, S]) SafeScalarBytes() int {
+	return c.group.SafeScalarBytes()
+}
+
+func (c erasedCurve[P, S]) Order() *saferith.Modulus {
+	return c.group.Order()
+}
+
+// Cofactor returns the cofactor of the group, implementing kyokusen.CofactorCurve.
+//
+// This is 1 if the group doesn't implement CofactorGroup.
+func (c erasedCurve[P, S]) Cofactor() *saferith.Nat {
+	if g, ok := c.group.(CofactorGroup); ok {
+		return g.Cofactor()
+	}
+	return new(saferith.Nat).SetUint64(1)
+}
+
+// MultiScalarMul implements kyokusen.MultiScalarMuler.
+//
+// This uses the group's implementation of MultiScalarMuler, if it has one,
+// and kyokusen.PippengerMultiScalarMul otherwise.
+func (c erasedCurve[P, S]) MultiScalarMul(scalars []kyokusen.Scalar, points []kyokusen.Point) kyokusen.Point {
+	g, ok := c.group.(MultiScalarMuler[P, S])
+	if !ok {
+		return kyokusen.PippengerMultiScalarMul(scalars, points)
+	}
+	return ErasePoint(c.group, g.MultiScalarMul(uneraseScalars[P, S](scalars), unerasePoints[P, S](points)))
+}
+
+// VartimeMultiScalarMul implements kyokusen.MultiScalarMuler.
+//
+// This uses the group's implementation of MultiScalarMuler, if it has one,
+// and kyokusen.VartimePippengerMultiScalarMul otherwise.
+func (c erasedCurve[P, S]) VartimeMultiScalarMul(scalars []kyokusen.Scalar, points []kyokusen.Point) kyokusen.Point {
+	g, ok := c.group.(MultiScalarMuler[P, S])
+	if !ok {
+		return kyokusen.VartimePippengerMultiScalarMul(scalars, points)
+	}
+	return ErasePoint(c.group, g.VartimeMultiScalarMul(uneraseScalars[P, S](scalars), unerasePoints[P, S](points)))
+}
+
+// uneraseScalars recovers the scalars of a Group, from a slice of erased scalars.
+func uneraseScalars[P Point[P], S Scalar[S, P]](scalars []kyokusen.Scalar) []S {
+	out := make([]S, len(scalars))
+	for i, s := range scalars {
+		out[i] = UneraseScalar[P, S](s)
+	}
+	return out
+}
+
+// unerasePoints recovers the points of a Group, from a slice of erased points.
+func unerasePoints[P Point[P], S Scalar[S, P]](points []kyokusen.Point) []P {
+	out := make([]P, len(points))
+	for i, p := range points {
+		out[i] = UnerasePoint[P, S](p)
+	}
+	return out
+}
+
+// HashToCurve implements kyokusen.HashableCurve.
+//
+// This returns an error if the group doesn't implement HashableGroup.
+func (c erasedCurve[P, S]) HashToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	g, ok := c.group.(HashableGroup[P])
+	if !ok {
+		return nil, errors.New("group.HashToCurve: group doesn't support hashing")
+	}
+	p, err := g.HashToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return ErasePoint(c.group, p), nil
+}
+
+// EncodeToCurve implements kyokusen.HashableCurve.
+//
+// This returns an error if the group doesn't implement HashableGroup.
+func (c erasedCurve[P, S]) EncodeToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	g, ok := c.group.(HashableGroup[P])
+	if !ok {
+		return nil, errors.New("group.EncodeToCurve: group doesn't support hashing")
+	}
+	p, err := g.EncodeToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return ErasePoint(c.group, p), nil
+}
+
+// erasedPoint implements kyokusen.Point, using a point of a Group.
+type erasedPoint[P Point[P], S Scalar[S, P]] struct {
+	group Group[P, S]
+	inner P
+}
+
+// castErasedPoint converts a point implementing the generic interface to this specific type.
+func castErasedPoint[P Point[P], S Scalar[S, P]](p kyokusen.Point) *erasedPoint[P, S] {
+	casted, ok := p.(*erasedPoint[P, S])
+	if !ok {
+		panic("failed to cast type to an erased point of the same group")
+	}
+	return casted
+}
+
+func (p *erasedPoint[P, S]) MarshalBinary() ([]byte, error) {
+	return p.inner.MarshalBinary()
+}
+
+func (p *erasedPoint[P, S]) UnmarshalBinary(data []byte) error {
+	inner := p.group.NewPoint()
+	if err := inner.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	p.inner = inner
+	return nil
+}
+
+func (p *erasedPoint[P, S]) Curve() kyokusen.Curve {
+	return Erase(p.group)
+}
+
+func (p *erasedPoint[P, S]) Add(other kyokusen.Point) kyokusen.Point {
+	return ErasePoint(p.group, p.inner.Add(castErasedPoint[P, S](other).inner))
+}
+
+func (p *erasedPoint[P, S]) Sub(other kyokusen.Point) kyokusen.Point {
+	return ErasePoint(p.group, p.inner.Sub(castErasedPoint[P, S](other).inner))
+}
+
+func (p *erasedPoint[P, S]) Negate() kyokusen.Point {
+	return ErasePoint(p.group, p.inner.Negate())
+}
+
+func (p *erasedPoint[P, S]) Equal(other kyokusen.Point) bool {
+	return p.inner.Equal(castErasedPoint[P, S](other).inner)
+}
+
+func (p *erasedPoint[P, S]) IsIdentity() bool {
+	return p.inner.IsIdentity()
+}
+
+// XScalar returns the x coordinate of this point as a scalar.
+//
+// This is only available if the point implements XScalarPoint, and returns nil otherwise.
+func (p *erasedPoint[P, S]) XScalar() kyokusen.Scalar {
+	x, ok := any(p.inner).(XScalarPoint[S])
+	if !ok {
+		return nil
+	}
+	s, ok := x.XScalar()
+	if !ok {
+		return nil
+	}
+	return EraseScalar(p.group, s)
+}
+
+// ClearCofactor implements kyokusen.CofactorPoint.
+//
+// If the point doesn't implement CofactorPoint, it's returned unchanged.
+func (p *erasedPoint[P, S]) ClearCofactor() kyokusen.Point {
+	c, ok := any(p.inner).(CofactorPoint[P])
+	if !ok {
+		return p
+	}
+	return ErasePoint(p.group, c.ClearCofactor())
+}
+
+// IsTorsionFree implements kyokusen.CofactorPoint.
+//
+// If the point doesn't implement CofactorPoint, this always returns true.
+func (p *erasedPoint[P, S]) IsTorsionFree() bool {
+	c, ok := any(p.inner).(CofactorPoint[P])
+	if !ok {
+		return true
+	}
+	return c.IsTorsionFree()
+}
+
+// CondSelect implements kyokusen.SelectablePoint.
+//
+// If the point doesn't implement SelectablePoint, this calculates this + yes * (other - this),
+// using Act, which is expected to be constant-time.
+func (p *erasedPoint[P, S]) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	q := castErasedPoint[P, S](other).inner
+	if s, ok := any(p.inner).(SelectablePoint[P]); ok {
+		return ErasePoint(p.group, s.CondSelect(yes, q))
+	}
+	bit := p.group.NewScalar().SetNat(new(saferith.Nat).SetUint64(uint64(yes)))
+	return ErasePoint(p.group, p.inner.Add(bit.Act(q.Sub(p.inner))))
+}
+
+// erasedScalar implements kyokusen.Scalar, using a scalar of a Group.
+type erasedScalar[P Point[P], S Scalar[S, P]] struct {
+	group Group[P, S]
+	inner S
+}
+
+// castErasedScalar converts a scalar implementing the generic interface to this specific type.
+func castErasedScalar[P Point[P], S Scalar[S, P]](s kyokusen.Scalar) *erasedScalar[P, S] {
+	casted, ok := s.(*erasedScalar[P, S])
+	if !ok {
+		panic("failed to cast type to an erased scalar of the same group")
+	}
+	return casted
+}
+
+func (s *erasedScalar[P, S]) MarshalBinary() ([]byte, error) {
+	return s.inner.MarshalBinary()
+}
+
+func (s *erasedScalar[P, S]) UnmarshalBinary(data []byte) error {
+	return s.inner.UnmarshalBinary(data)
+}
+
+func (s *erasedScalar[P, S]) Curve() kyokusen.Curve {
+	return Erase(s.group)
+}
+
+func (s *erasedScalar[P, S]) Add(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Add(castErasedScalar[P, S](other).inner)
+	return s
+}
+
+func (s *erasedScalar[P, S]) Sub(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Sub(castErasedScalar[P, S](other).inner)
+	return s
+}
+
+func (s *erasedScalar[P, S]) Negate() kyokusen.Scalar {
+	s.inner.Negate()
+	return s
+}
+
+func (s *erasedScalar[P, S]) Mul(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Mul(castErasedScalar[P, S](other).inner)
+	return s
+}
+
+func (s *erasedScalar[P, S]) Invert() kyokusen.Scalar {
+	s.inner.Invert()
+	return s
+}
+
+func (s *erasedScalar[P, S]) Equal(other kyokusen.Scalar) bool {
+	return s.inner.Equal(castErasedScalar[P, S](other).inner)
+}
+
+func (s *erasedScalar[P, S]) IsZero() bool {
+	return s.inner.IsZero()
+}
+
+func (s *erasedScalar[P, S]) Set(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Set(castErasedScalar[P, S](other).inner)
+	return s
+}
+
+func (s *erasedScalar[P, S]) SetNat(x *saferith.Nat) kyokusen.Scalar {
+	s.inner.SetNat(x)
+	return s
+}
+
+func (s *erasedScalar[P, S]) Act(p kyokusen.Point) kyokusen.Point {
+	return ErasePoint(s.group, s.inner.Act(castErasedPoint[P, S](p).inner))
+}
+
+func (s *erasedScalar[P, S]) ActOnBase() kyokusen.Point {
+	return ErasePoint(s.group, s.inner.ActOnBase())
+}
+
+// VartimeAct implements kyokusen.VartimeScalar.
+//
+// If the scalar doesn't implement VartimeScalar, this falls back to Act.
+func (s *erasedScalar[P, S]) VartimeAct(p kyokusen.Point) kyokusen.Point {
+	v, ok := any(s.inner).(VartimeScalar[S, P])
+	if !ok {
+		return s.Act(p)
+	}
+	return ErasePoint(s.group, v.VartimeAct(castErasedPoint[P, S](p).inner))
+}
+
+// VartimeDoubleBaseMul implements kyokusen.VartimeScalar.
+//
+// If the scalar doesn't implement VartimeScalar, this falls back to ActOnBase and Act.
+func (s *erasedScalar[P, S]) VartimeDoubleBaseMul(b kyokusen.Scalar, p kyokusen.Point) kyokusen.Point {
+	v, ok := any(s.inner).(VartimeScalar[S, P])
+	if !ok {
+		return s.ActOnBase().Add(b.Act(p))
+	}
+	return ErasePoint(s.group, v.VartimeDoubleBaseMul(castErasedScalar[P, S](b).inner, castErasedPoint[P, S](p).inner))
+}
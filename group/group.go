@@ -0,0 +1,174 @@
+// Package group provides a generic version of the kyokusen interfaces.
+//
+// With the interfaces in the kyokusen package, mixing the points or scalars of
+// different curves compiles, but panics at runtime, when an implementation casts
+// its arguments back to its own types. Here, points and scalars are instead
+// parameterized by their concrete types, so that mixing curves is a compile-time
+// error.
+//
+// Adapt converts any kyokusen.Curve into a Group, and Erase converts any Group
+// back into a kyokusen.Curve, so that both APIs can be used together.
+//
+// None of the curves in this module implement Group directly, so the casts
+// haven't gone away: the points and scalars returned by Adapt still call the
+// kyokusen interfaces underneath, where each curve casts its arguments at
+// runtime, and the curves returned by Erase cast in the same way. The type
+// parameters only guarantee that these casts never fail. Only an implementation
+// of Group written against the generic interfaces would avoid them entirely.
+package group
+
+import (
+	"encoding"
+
+	"github.com/cronokirby/saferith"
+)
+
+// Point represents an element of an Elliptic Curve group, with P being the concrete type of points.
+//
+// Like kyokusen.Point, the methods on Point are immutable, never modifying the receiver.
+type Point[P any] interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	// Add returns a new Point, by adding another Point to this one.
+	Add(P) P
+	// Sub returns a new Point, by subtracting another Point from this one.
+	Sub(P) P
+	// Negate returns the negated version of this point.
+	Negate() P
+	// Equal checks if this point is equal to another.
+	Equal(P) bool
+	// IsIdentity checks if this is the identity element of this group.
+	IsIdentity() bool
+}
+
+// XScalarPoint is an optional interface for Points whose x coordinate can be converted into a Scalar.
+//
+// This is the counterpart of kyokusen.Point.XScalar, which is used in ECDSA.
+type XScalarPoint[S any] interface {
+	// XScalar returns the x coordinate of this point as a Scalar.
+	//
+	// This returns false if the conversion isn't available.
+	XScalar() (S, bool)
+}
+
+// Scalar represents a number modulo the order of an Elliptic Curve group.
+//
+// S is the concrete type of scalars, and P the concrete type of the points they act on.
+// Like kyokusen.Scalar, the methods on Scalar mutate the receiver, before returning it.
+type Scalar[S any, P any] interface {
+	// This should encode the Scalar as Big Endian bytes, without failure.
+	encoding.BinaryMarshaler
+	// This should decode the Scalar from Big Endian bytes.
+	encoding.BinaryUnmarshaler
+	// Add mutates this Scalar, by adding in another.
+	Add(S) S
+	// Sub mutates this Scalar, by subtracting another.
+	Sub(S) S
+	// Negate mutates this Scalar, replacing it with its negation.
+	Negate() S
+	// Mul mutates this Scalar, by multiplying it with another.
+	Mul(S) S
+	// Invert mutates this Scalar, replacing it with its multiplicative inverse.
+	Invert() S
+	// Equal checks if this Scalar is equal to another, in constant-time.
+	Equal(S) bool
+	// IsZero checks if this Scalar is equal to 0, in constant-time.
+	IsZero() bool
+	// Set mutates this Scalar, replacing its value with another.
+	Set(S) S
+	// SetNat mutates this Scalar, replacing it with the value of a number, modulo the order of the group.
+	SetNat(*saferith.Nat) S
+	// Act acts on a Point with this Scalar, returning a new Point.
+	//
+	// This shouldn't mutate the Scalar, or the Point.
+	Act(P) P
+	// ActOnBase acts on the Base Point with this Scalar, returning a new Point.
+	ActOnBase() P
+}
+
+// Group represents an Elliptic Curve group, with points of type P, and scalars of type S.
+//
+// This is the generic counterpart of kyokusen.Curve.
+type Group[P Point[P], S Scalar[S, P]] interface {
+	// NewPoint creates an identity point.
+	NewPoint() P
+	// NewBasePoint creates the generator of this group.
+	NewBasePoint() P
+	// NewScalar creates a scalar with the value of 0.
+	NewScalar() S
+	// Name returns the name of this curve.
+	//
+	// This should be unique between curves.
+	Name() string
+	// ScalarBits returns the number of significant bits in a scalar.
+	ScalarBits() int
+	// SafeScalarBytes returns the number of random bytes need to sample a scalar through modular reduction.
+	SafeScalarBytes() int
+	// Order returns a Modulus holding the order of this group.
+	Order() *saferith.Modulus
+}
+
+// The following optional interfaces are the generic counterparts of those in the kyokusen package.
+//
+// The points, scalars, and groups returned by Adapt always implement them,
+// falling back to the same defaults as the kyokusen package when the underlying
+// curve doesn't, and Erase forwards them to the kyokusen interfaces.
+
+// CofactorGroup is an optional interface for Groups whose points don't form a group of prime order.
+//
+// This is the counterpart of kyokusen.CofactorCurve.
+type CofactorGroup interface {
+	// Cofactor returns the cofactor of this group.
+	Cofactor() *saferith.Nat
+}
+
+// CofactorPoint is an optional interface for Points in a group with a cofactor.
+//
+// This is the counterpart of kyokusen.CofactorPoint.
+type CofactorPoint[P any] interface {
+	// ClearCofactor returns a new Point, equal to this point multiplied by the cofactor.
+	ClearCofactor() P
+	// IsTorsionFree checks if this point lies in the prime order subgroup.
+	IsTorsionFree() bool
+}
+
+// SelectablePoint is an optional interface for Points supporting constant-time selection.
+//
+// This is the counterpart of kyokusen.SelectablePoint.
+type SelectablePoint[P any] interface {
+	// CondSelect returns a new Point, equal to other if yes = 1, and to this point otherwise.
+	//
+	// This should be done in constant-time, and shouldn't mutate either point.
+	CondSelect(yes saferith.Choice, other P) P
+}
+
+// VartimeScalar is an optional interface for Scalars supporting faster variable-time operations.
+//
+// This is the counterpart of kyokusen.VartimeScalar. These methods must NEVER
+// be used with secret scalars.
+type VartimeScalar[S any, P any] interface {
+	// VartimeAct acts on a Point with this Scalar, like Act, but in variable-time.
+	VartimeAct(P) P
+	// VartimeDoubleBaseMul calculates this * G + b * P, in variable-time, where G is the base point.
+	VartimeDoubleBaseMul(b S, P P) P
+}
+
+// MultiScalarMuler is an optional interface for Groups providing their own multi-scalar multiplication.
+//
+// This is the counterpart of kyokusen.MultiScalarMuler.
+type MultiScalarMuler[P any, S any] interface {
+	// MultiScalarMul calculates sum(scalars[i] * points[i]), in constant-time.
+	MultiScalarMul(scalars []S, points []P) P
+	// VartimeMultiScalarMul calculates sum(scalars[i] * points[i]), in variable-time.
+	VartimeMultiScalarMul(scalars []S, points []P) P
+}
+
+// HashableGroup is an optional interface for Groups supporting hashing to the curve, following RFC 9380.
+//
+// This is the counterpart of kyokusen.HashableCurve.
+type HashableGroup[P any] interface {
+	// HashToCurve hashes a message to a point, using a domain separation tag.
+	HashToCurve(msg, dst []byte) (P, error)
+	// EncodeToCurve encodes a message as a point, using a domain separation tag.
+	EncodeToCurve(msg, dst []byte) (P, error)
+}
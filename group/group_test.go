@@ -0,0 +1,208 @@
+package group
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/ecdsa"
+	"github.com/cronokirby/kyokusen/edwards25519"
+	"github.com/cronokirby/kyokusen/p256"
+	"github.com/cronokirby/kyokusen/ristretto255"
+	"github.com/cronokirby/kyokusen/secp256k1"
+	"github.com/cronokirby/saferith"
+)
+
+var secp256k1Group = Adapt(secp256k1.Curve{})
+
+type (
+	secp256k1Point  = *AdaptedPoint[secp256k1.Curve]
+	secp256k1Scalar = *AdaptedScalar[secp256k1.Curve]
+)
+
+// scalarFromUint64 creates a scalar from a small number, only using the generic API.
+func scalarFromUint64[P Point[P], S Scalar[S, P]](group Group[P, S], x uint64) S {
+	return group.NewScalar().SetNat(new(saferith.Nat).SetUint64(x))
+}
+
+// distributes checks that (a + b) * G = a * G + b * G, only using the generic API.
+func distributes[P Point[P], S Scalar[S, P]](group Group[P, S], a, b uint64) bool {
+	aS, bS := scalarFromUint64(group, a), scalarFromUint64(group, b)
+	abG := group.NewScalar().Set(aS).Add(bS).ActOnBase()
+	return abG.Equal(aS.ActOnBase().Add(bS.Act(group.NewBasePoint())))
+}
+
+func TestGenericArithmetic(t *testing.T) {
+	err := quick.Check(func(a, b uint64) bool {
+		return distributes[secp256k1Point, secp256k1Scalar](secp256k1Group, a, b) &&
+			distributes[*AdaptedPoint[p256.Curve], *AdaptedScalar[p256.Curve]](Adapt(p256.Curve{}), a, b)
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdaptedMatchesCurve(t *testing.T) {
+	err := quick.Check(func(a uint64) bool {
+		s := secp256k1.NewScalar().SetNat(new(saferith.Nat).SetUint64(a))
+		expected, _ := s.ActOnBase().MarshalBinary()
+		actual, _ := WrapScalar[secp256k1.Curve](s).ActOnBase().MarshalBinary()
+		return string(expected) == string(actual)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestZeroValues(t *testing.T) {
+	var p AdaptedPoint[secp256k1.Curve]
+	if !p.IsIdentity() || !p.Equal(secp256k1Group.NewPoint()) {
+		t.Error("zero point should be the identity")
+	}
+	var s AdaptedScalar[secp256k1.Curve]
+	if !s.IsZero() || !s.ActOnBase().IsIdentity() {
+		t.Error("zero scalar should be 0")
+	}
+	if !p.Add(secp256k1Group.NewBasePoint()).Equal(secp256k1Group.NewBasePoint()) {
+		t.Error("adding the zero point should do nothing")
+	}
+}
+
+func TestWrapRejectsOtherCurve(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("wrapping a point of another curve should panic")
+		}
+	}()
+	WrapPoint[p256.Curve](secp256k1.Curve{}.NewBasePoint())
+}
+
+func TestMarshalRoundtrip(t *testing.T) {
+	p := scalarFromUint64[secp256k1Point, secp256k1Scalar](secp256k1Group, 42).ActOnBase()
+	data, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded AdaptedPoint[secp256k1.Curve]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(p) {
+		t.Error("decoded point doesn't match")
+	}
+}
+
+func TestXScalar(t *testing.T) {
+	x, ok := secp256k1Group.NewBasePoint().XScalar()
+	if !ok || !x.Equal(WrapScalar[secp256k1.Curve](secp256k1.Curve{}.NewBasePoint().XScalar())) {
+		t.Error("incorrect x coordinate for the generator")
+	}
+	if _, ok := Adapt(ristretto255.Curve{}).NewBasePoint().XScalar(); ok {
+		t.Error("ristretto255 points should not have an x coordinate")
+	}
+}
+
+func TestErasedECDSA(t *testing.T) {
+	curve := Erase[secp256k1Point, secp256k1Scalar](secp256k1Group)
+	secret := curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(1234))
+	public := secret.ActOnBase()
+	digest := sha256.Sum256([]byte("hello world"))
+	r, s, err := ecdsa.Sign(rand.Reader, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ecdsa.Verify(public, digest[:], r, s); err != nil {
+		t.Error(err)
+	}
+	inner := UnerasePoint[secp256k1Point, secp256k1Scalar](public)
+	if !inner.Unwrap().Equal(secp256k1.NewScalar().SetNat(new(saferith.Nat).SetUint64(1234)).ActOnBase()) {
+		t.Error("unerased point doesn't match")
+	}
+}
+
+func TestErasedMultiScalarMul(t *testing.T) {
+	curve := Erase[secp256k1Point, secp256k1Scalar](secp256k1Group)
+	scalars := []kyokusen.Scalar{
+		curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(2)),
+		curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(3)),
+	}
+	points := []kyokusen.Point{curve.NewBasePoint(), curve.NewBasePoint()}
+	expected := curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(5)).ActOnBase()
+	if !kyokusen.MultiScalarMul(scalars, points).Equal(expected) {
+		t.Error("incorrect multi-scalar multiplication")
+	}
+}
+
+// smallOrderPoint returns a point of order 8 on edwards25519.
+func smallOrderPoint(t *testing.T) kyokusen.Point {
+	data, _ := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	p := edwards25519.NewPoint()
+	if err := p.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestCofactorIsForwarded(t *testing.T) {
+	edwardsGroup := Adapt(edwards25519.Curve{})
+	small := WrapPoint[edwards25519.Curve](smallOrderPoint(t))
+	if small.IsTorsionFree() || !small.ClearCofactor().IsIdentity() {
+		t.Error("adapted point should have a small order component")
+	}
+	if edwardsGroup.Cofactor().Eq(new(saferith.Nat).SetUint64(8)) != 1 {
+		t.Error("adapted cofactor should be 8")
+	}
+
+	curve := Erase[*AdaptedPoint[edwards25519.Curve], *AdaptedScalar[edwards25519.Curve]](edwardsGroup)
+	erased := ErasePoint[*AdaptedPoint[edwards25519.Curve], *AdaptedScalar[edwards25519.Curve]](edwardsGroup, small)
+	if kyokusen.IsTorsionFree(erased) || !kyokusen.IsSmallOrder(erased) {
+		t.Error("erased point should have a small order component")
+	}
+	if kyokusen.Cofactor(curve).Eq(new(saferith.Nat).SetUint64(8)) != 1 {
+		t.Error("erased cofactor should be 8")
+	}
+	data, _ := erased.MarshalBinary()
+	if _, err := kyokusen.UnmarshalPrimeOrderPoint(curve, data); err == nil {
+		t.Error("erased curve should reject points outside the prime order subgroup")
+	}
+	if !kyokusen.IsTorsionFree(curve.NewBasePoint()) {
+		t.Error("the generator should be torsion free")
+	}
+}
+
+func TestOptionalInterfacesAreForwarded(t *testing.T) {
+	curve := Erase[secp256k1Point, secp256k1Scalar](secp256k1Group)
+	if _, ok := curve.NewPoint().(kyokusen.SelectablePoint); !ok {
+		t.Error("erased point should implement SelectablePoint")
+	}
+	a := curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(5))
+	b := curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(7))
+	P := curve.NewBasePoint()
+	if !kyokusen.VartimeAct(a, P).Equal(a.Act(P)) {
+		t.Error("incorrect VartimeAct")
+	}
+	if !kyokusen.VartimeDoubleBaseMul(a, b, P).Equal(a.ActOnBase().Add(b.Act(P))) {
+		t.Error("incorrect VartimeDoubleBaseMul")
+	}
+	selected := P.(kyokusen.SelectablePoint).CondSelect(1, curve.NewPoint())
+	if !selected.IsIdentity() || !P.(kyokusen.SelectablePoint).CondSelect(0, curve.NewPoint()).Equal(P) {
+		t.Error("incorrect CondSelect")
+	}
+
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	hashed, err := kyokusen.HashToCurve(curve, []byte("abc"), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := secp256k1.Curve{}.HashToCurve([]byte("abc"), dst)
+	if !UnerasePoint[secp256k1Point, secp256k1Scalar](hashed).Unwrap().Equal(expected) {
+		t.Error("incorrect HashToCurve")
+	}
+	ristretto := Erase[*AdaptedPoint[ristretto255.Curve], *AdaptedScalar[ristretto255.Curve]](Adapt(ristretto255.Curve{}))
+	if _, err := kyokusen.HashToCurve(ristretto, []byte("abc"), dst); err == nil {
+		t.Error("hashing should fail for a curve without HashToCurve")
+	}
+}
@@ -0,0 +1,42 @@
+package kyokusen
+
+import "errors"
+
+// HashableCurve is an optional interface for Curves supporting hashing to the curve, following RFC 9380.
+//
+// The points produced this way have no known discrete logarithm, relative to
+// the generator, or to any other point, which is what protocols like VRFs,
+// OPRFs, BLS signatures, or PAKEs need.
+type HashableCurve interface {
+	Curve
+	// HashToCurve hashes a message to a point, using a domain separation tag.
+	//
+	// The result is indistinguishable from a uniformly random point in the prime order subgroup.
+	HashToCurve(msg, dst []byte) (Point, error)
+	// EncodeToCurve encodes a message as a point, using a domain separation tag.
+	//
+	// This is faster than HashToCurve, but the result isn't uniformly distributed.
+	EncodeToCurve(msg, dst []byte) (Point, error)
+}
+
+// HashToCurve hashes a message to a point on a curve, using a domain separation tag.
+//
+// This returns an error if the curve doesn't implement HashableCurve.
+func HashToCurve(curve Curve, msg, dst []byte) (Point, error) {
+	h, ok := curve.(HashableCurve)
+	if !ok {
+		return nil, errors.New("kyokusen.HashToCurve: curve doesn't support hashing")
+	}
+	return h.HashToCurve(msg, dst)
+}
+
+// EncodeToCurve encodes a message as a point on a curve, using a domain separation tag.
+//
+// This returns an error if the curve doesn't implement HashableCurve.
+func EncodeToCurve(curve Curve, msg, dst []byte) (Point, error) {
+	h, ok := curve.(HashableCurve)
+	if !ok {
+		return nil, errors.New("kyokusen.EncodeToCurve: curve doesn't support hashing")
+	}
+	return h.EncodeToCurve(msg, dst)
+}
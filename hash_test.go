@@ -0,0 +1,27 @@
+package kyokusen_test
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/p256"
+	"github.com/cronokirby/kyokusen/secp256k1"
+)
+
+func TestHashToCurveRequiresSupport(t *testing.T) {
+	msg, dst := []byte("msg"), []byte("kyokusen test")
+	if _, err := kyokusen.HashToCurve(p256.Curve{}, msg, dst); err == nil {
+		t.Error("P-256 shouldn't support hashing")
+	}
+	if _, err := kyokusen.EncodeToCurve(p256.Curve{}, msg, dst); err == nil {
+		t.Error("P-256 shouldn't support hashing")
+	}
+	P, err := kyokusen.HashToCurve(secp256k1.Curve{}, msg, dst)
+	if err != nil || P.IsIdentity() {
+		t.Error("secp256k1 should support hashing")
+	}
+	Q, err := kyokusen.EncodeToCurve(secp256k1.Curve{}, msg, dst)
+	if err != nil || Q.Equal(P) {
+		t.Error("encoding should differ from hashing")
+	}
+}
@@ -0,0 +1,329 @@
+// Package curve25519 implements the arithmetic shared by the groups built on top
+// of Curve25519, in its twisted Edwards form.
+//
+// This package isn't intended to be used directly. Instead, it provides the
+// common building blocks for the ristretto255 and edwards25519 packages.
+package curve25519
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/cronokirby/kyokusen/internal/limbs"
+	"github.com/cronokirby/saferith"
+)
+
+// FieldBytes is the number of bytes in the encoding of a field element.
+const FieldBytes = 32
+
+// pLimbs is p = 2^255 - 19, as 64 bit limbs, in little endian order.
+var pLimbs = [4]uint64{0xFFFFFFFFFFFFFFED, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0x7FFFFFFFFFFFFFFF}
+
+// Field represents an element of the field of integers modulo 2^255 - 19.
+//
+// Internally, this is represented with 4 64 bit limbs, in little endian order.
+// The value is always kept fully reduced modulo p.
+type Field struct {
+	limbs [4]uint64
+}
+
+// NewField creates a new field element, with its value set to 0.
+func NewField() *Field {
+	return &Field{}
+}
+
+// Set calculates z <- x, returning z.
+func (z *Field) Set(x *Field) *Field {
+	z.limbs = x.limbs
+	return z
+}
+
+// SetUint64 calculates z <- x, returning z.
+func (z *Field) SetUint64(x uint64) *Field {
+	z.limbs = [4]uint64{x, 0, 0, 0}
+	return z
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *Field) CondAssign(yes saferith.Choice, x *Field) *Field {
+	m := limbs.Mask(yes)
+	for i := range z.limbs {
+		z.limbs[i] ^= m & (z.limbs[i] ^ x.limbs[i])
+	}
+	return z
+}
+
+// CondNegate sets z <- -z, only if yes = 1, in constant-time.
+func (z *Field) CondNegate(yes saferith.Choice) *Field {
+	negated := NewField().Set(z).Negate()
+	return z.CondAssign(yes, negated)
+}
+
+// reduce sets z <- (carry * 2^256 + l) mod p, where carry is small, in constant-time.
+func (z *Field) reduce(carry uint64, l [4]uint64) *Field {
+	// Since 2^255 = 19 mod p, we can fold the carry, and the top bit of l, back
+	// into the bottom, leaving us with a number less than 2p.
+	top := carry<<1 | l[3]>>63
+	l[3] &= 0x7FFFFFFFFFFFFFFF
+	var c uint64
+	l[0], c = bits.Add64(l[0], 19*top, 0)
+	l[1], c = bits.Add64(l[1], 0, c)
+	l[2], c = bits.Add64(l[2], 0, c)
+	l[3], _ = bits.Add64(l[3], 0, c)
+	// Now, we subtract p, if this doesn't borrow.
+	var t [4]uint64
+	var borrow uint64
+	t[0], borrow = bits.Sub64(l[0], pLimbs[0], 0)
+	t[1], borrow = bits.Sub64(l[1], pLimbs[1], borrow)
+	t[2], borrow = bits.Sub64(l[2], pLimbs[2], borrow)
+	t[3], borrow = bits.Sub64(l[3], pLimbs[3], borrow)
+	z.limbs = t
+	m := limbs.Mask(saferith.Choice(borrow))
+	for i := range z.limbs {
+		z.limbs[i] ^= m & (z.limbs[i] ^ l[i])
+	}
+	return z
+}
+
+// Add calculates z <- z + a, returning z.
+func (z *Field) Add(a *Field) *Field {
+	var l [4]uint64
+	var carry uint64
+	l[0], carry = bits.Add64(z.limbs[0], a.limbs[0], 0)
+	l[1], carry = bits.Add64(z.limbs[1], a.limbs[1], carry)
+	l[2], carry = bits.Add64(z.limbs[2], a.limbs[2], carry)
+	l[3], carry = bits.Add64(z.limbs[3], a.limbs[3], carry)
+	return z.reduce(carry, l)
+}
+
+// Sub calculates z <- z - a, returning z.
+func (z *Field) Sub(a *Field) *Field {
+	var l [4]uint64
+	var borrow uint64
+	l[0], borrow = bits.Sub64(z.limbs[0], a.limbs[0], 0)
+	l[1], borrow = bits.Sub64(z.limbs[1], a.limbs[1], borrow)
+	l[2], borrow = bits.Sub64(z.limbs[2], a.limbs[2], borrow)
+	l[3], borrow = bits.Sub64(z.limbs[3], a.limbs[3], borrow)
+	// If we borrowed, then we need to add p back.
+	m := limbs.Mask(saferith.Choice(borrow))
+	var carry uint64
+	z.limbs[0], carry = bits.Add64(l[0], m&pLimbs[0], 0)
+	z.limbs[1], carry = bits.Add64(l[1], m&pLimbs[1], carry)
+	z.limbs[2], carry = bits.Add64(l[2], m&pLimbs[2], carry)
+	z.limbs[3], _ = bits.Add64(l[3], m&pLimbs[3], carry)
+	return z
+}
+
+// Negate calculates z <- -z, returning z.
+func (z *Field) Negate() *Field {
+	x := *z
+	z.limbs = [4]uint64{}
+	return z.Sub(&x)
+}
+
+// Mul calculates z <- z * a, returning z.
+func (z *Field) Mul(a *Field) *Field {
+	// Schoolbook multiplication, producing 8 limbs.
+	var r [8]uint64
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(z.limbs[i], a.limbs[j])
+			var c uint64
+			lo, c = bits.Add64(lo, r[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			r[i+j] = lo
+			carry = hi
+		}
+		r[i+4] = carry
+	}
+	// Since 2^256 = 38 mod p, we can fold the top limbs back in.
+	var l [4]uint64
+	var top uint64
+	for i := range l {
+		hi, lo := bits.Mul64(r[i+4], 38)
+		var c uint64
+		l[i], c = bits.Add64(r[i], lo, 0)
+		hi += c
+		l[i], c = bits.Add64(l[i], top, 0)
+		top = hi + c
+	}
+	// top is now small, so folding it in once more gives us at most one carry.
+	hi, lo := bits.Mul64(top, 38)
+	var carry uint64
+	l[0], carry = bits.Add64(l[0], lo, 0)
+	l[1], carry = bits.Add64(l[1], hi, carry)
+	l[2], carry = bits.Add64(l[2], 0, carry)
+	l[3], carry = bits.Add64(l[3], 0, carry)
+	return z.reduce(carry, l)
+}
+
+// Square calculates z <- z * z, returning z.
+func (z *Field) Square() *Field {
+	return z.Mul(z)
+}
+
+// squareN calculates z <- z^(2^n), returning z.
+func (z *Field) squareN(n int) *Field {
+	for i := 0; i < n; i++ {
+		z.Square()
+	}
+	return z
+}
+
+// pow2250 returns x^(2^250 - 1) and x^11, which are used in the addition chains
+// for inversion and square roots.
+func pow2250(x *Field) (x2250, x11 *Field) {
+	// The naming convention is that xn_m = x^(2^n - 2^m).
+	x2 := NewField().Set(x).Square()
+	x9 := NewField().Set(x2).squareN(2).Mul(x)
+	x11 = NewField().Set(x9).Mul(x2)
+	x5_0 := NewField().Set(x11).Square().Mul(x9)
+	x10_0 := NewField().Set(x5_0).squareN(5).Mul(x5_0)
+	x20_0 := NewField().Set(x10_0).squareN(10).Mul(x10_0)
+	x40_0 := NewField().Set(x20_0).squareN(20).Mul(x20_0)
+	x50_0 := NewField().Set(x40_0).squareN(10).Mul(x10_0)
+	x100_0 := NewField().Set(x50_0).squareN(50).Mul(x50_0)
+	x200_0 := NewField().Set(x100_0).squareN(100).Mul(x100_0)
+	x2250 = NewField().Set(x200_0).squareN(50).Mul(x50_0)
+	return x2250, x11
+}
+
+// Invert calculates z <- z^-1, returning z.
+//
+// This computes z^(p - 2), using a fixed addition chain, so it runs in constant-time.
+// If z = 0, the result is 0.
+func (z *Field) Invert() *Field {
+	x2250, x11 := pow2250(z)
+	return z.Set(x2250).squareN(5).Mul(x11)
+}
+
+// pow22523 calculates z <- z^((p - 5) / 8), returning z.
+func (z *Field) pow22523() *Field {
+	x := NewField().Set(z)
+	x2250, _ := pow2250(x)
+	return z.Set(x2250).squareN(2).Mul(x)
+}
+
+// Eq checks if two field values are equal, in constant-time.
+func (z *Field) Eq(x *Field) saferith.Choice {
+	var diff uint64
+	for i := range z.limbs {
+		diff |= z.limbs[i] ^ x.limbs[i]
+	}
+	return limbs.IsZero(diff)
+}
+
+// EqZero checks if a field value is equal to 0, in constant-time.
+func (z *Field) EqZero() saferith.Choice {
+	return limbs.IsZero(z.limbs[0] | z.limbs[1] | z.limbs[2] | z.limbs[3])
+}
+
+// IsNegative checks if a field element is "negative", which is to say odd.
+func (z *Field) IsNegative() saferith.Choice {
+	return saferith.Choice(z.limbs[0] & 1)
+}
+
+// Abs sets z <- |z|, i.e. the non-negative element out of z and -z, returning z.
+func (z *Field) Abs() *Field {
+	return z.CondNegate(z.IsNegative())
+}
+
+// Bytes returns the little endian encoding of this field element.
+func (z *Field) Bytes() []byte {
+	out := make([]byte, FieldBytes)
+	for i, limb := range z.limbs {
+		binary.LittleEndian.PutUint64(out[8*i:], limb)
+	}
+	return out
+}
+
+// SetCanonicalBytes sets z to the value of 32 little endian bytes.
+//
+// This returns an error if the value isn't the canonical encoding of a field
+// element, i.e. if it's not less than p.
+func (z *Field) SetCanonicalBytes(data []byte) error {
+	if len(data) != FieldBytes {
+		return errors.New("curve25519.Field.SetCanonicalBytes: invalid data length")
+	}
+	var l [4]uint64
+	for i := range l {
+		l[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	var reduced Field
+	reduced.reduce(0, l)
+	if (reduced.Eq(&Field{l})) != 1 {
+		return errors.New("curve25519.Field.SetCanonicalBytes: value is not canonical")
+	}
+	z.Set(&reduced)
+	return nil
+}
+
+// SetBytesMasked sets z to the value of 32 little endian bytes, ignoring the top bit.
+//
+// Unlike SetCanonicalBytes, this accepts values which aren't reduced modulo p.
+func (z *Field) SetBytesMasked(data []byte) *Field {
+	var l [4]uint64
+	for i := range l {
+		l[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	l[3] &= 0x7FFFFFFFFFFFFFFF
+	return z.reduce(0, l)
+}
+
+// SqrtRatio sets z <- sqrt(u / v), following the SQRT_RATIO_M1 function of RFC 9496.
+//
+// The result is always non-negative. The returned choice indicates whether
+// or not u / v was actually square. If it wasn't, then z is set to sqrt(i * u / v),
+// where i is the square root of -1 used in SqrtM1. If v = 0, then z is set to 0,
+// and the choice is 1 only if u = 0.
+func (z *Field) SqrtRatio(u, v *Field) saferith.Choice {
+	v3 := NewField().Set(v).Square().Mul(v)
+	v7 := NewField().Set(v3).Square().Mul(v)
+	r := NewField().Set(u).Mul(v7).pow22523().Mul(v3).Mul(u)
+	check := NewField().Set(r).Square().Mul(v)
+
+	minusU := NewField().Set(u).Negate()
+	correctSign := check.Eq(u)
+	flippedSign := check.Eq(minusU)
+	flippedSignI := check.Eq(minusU.Mul(SqrtM1))
+
+	rPrime := NewField().Set(r).Mul(SqrtM1)
+	r.CondAssign(flippedSign|flippedSignI, rPrime)
+
+	z.Set(r).Abs()
+	return correctSign | flippedSign
+}
+
+// FieldFromHex creates a field element from a Big Endian hex string, panicking on failure.
+//
+// This is intended for constants, which must be canonical.
+func FieldFromHex(hex string) *Field {
+	nat, err := new(saferith.Nat).SetHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	var z Field
+	if err := z.SetCanonicalBytes(Reverse(nat.FillBytes(make([]byte, FieldBytes)))); err != nil {
+		panic(err)
+	}
+	return &z
+}
+
+// Reverse reverses a slice of bytes in place, returning it.
+func Reverse(data []byte) []byte {
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+	return data
+}
+
+var (
+	// D is the d parameter of the curve, -121665 / 121666.
+	D = FieldFromHex("52036CEE2B6FFE738CC740797779E89800700A4D4141D8AB75EB4DCA135978A3")
+	// SqrtM1 is the non-negative square root of -1.
+	SqrtM1 = FieldFromHex("2B8324804FC1DF0B2B4D00993DFBD7A72F431806AD2FE478C4EE1B274A0EA0B0")
+)
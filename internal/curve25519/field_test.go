@@ -0,0 +1,124 @@
+package curve25519
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/saferith"
+)
+
+var bigP = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+func (*Field) Generate(r *rand.Rand, size int) reflect.Value {
+	data := make([]byte, FieldBytes)
+	r.Read(data)
+	out := NewField()
+	switch r.Intn(4) {
+	case 0:
+		// Values just below p.
+		out.SetUint64(r.Uint64() >> uint(r.Intn(64))).Negate()
+	case 1:
+		// Small values.
+		out.SetUint64(r.Uint64() >> uint(r.Intn(64)))
+	default:
+		out.SetBytesMasked(data)
+	}
+	return reflect.ValueOf(out)
+}
+
+func (z *Field) big() *big.Int {
+	return new(big.Int).SetBytes(Reverse(z.Bytes()))
+}
+
+func TestFieldMatchesBig(t *testing.T) {
+	cases := map[string]func(a, b *Field) bool{
+		"Add": func(a, b *Field) bool {
+			expected := new(big.Int).Add(a.big(), b.big())
+			return NewField().Set(a).Add(b).big().Cmp(expected.Mod(expected, bigP)) == 0
+		},
+		"Sub": func(a, b *Field) bool {
+			expected := new(big.Int).Sub(a.big(), b.big())
+			return NewField().Set(a).Sub(b).big().Cmp(expected.Mod(expected, bigP)) == 0
+		},
+		"Mul": func(a, b *Field) bool {
+			expected := new(big.Int).Mul(a.big(), b.big())
+			return NewField().Set(a).Mul(b).big().Cmp(expected.Mod(expected, bigP)) == 0
+		},
+		"Invert": func(a, _ *Field) bool {
+			expected := new(big.Int).ModInverse(a.big(), bigP)
+			if expected == nil {
+				expected = new(big.Int)
+			}
+			return NewField().Set(a).Invert().big().Cmp(expected) == 0
+		},
+		"SetCanonicalBytes": func(a, _ *Field) bool {
+			var decoded Field
+			return decoded.SetCanonicalBytes(a.Bytes()) == nil && decoded.Eq(a) == 1
+		},
+	}
+	for name, f := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSetCanonicalBytesRejectsLargeValues(t *testing.T) {
+	pBytes := Reverse(bigP.FillBytes(make([]byte, FieldBytes)))
+	allOnes := make([]byte, FieldBytes)
+	for i := range allOnes {
+		allOnes[i] = 0xFF
+	}
+	for _, data := range [][]byte{pBytes, allOnes} {
+		if NewField().SetCanonicalBytes(data) == nil {
+			t.Errorf("%x should be rejected", data)
+		}
+	}
+	// The masked version reduces instead.
+	if NewField().SetBytesMasked(pBytes).EqZero() != 1 {
+		t.Error("p should reduce to 0")
+	}
+}
+
+func TestConstants(t *testing.T) {
+	minusOne := NewField().SetUint64(1).Negate()
+	if NewField().Set(SqrtM1).Square().Eq(minusOne) != 1 {
+		t.Error("SqrtM1 isn't a square root of -1")
+	}
+	// d * 121666 = -121665
+	if NewField().Set(D).Mul(NewField().SetUint64(121666)).Eq(NewField().SetUint64(121665).Negate()) != 1 {
+		t.Error("incorrect value for d")
+	}
+}
+
+// These vectors come from curve25519-dalek.
+func TestSqrtRatio(t *testing.T) {
+	zero := NewField()
+	one := NewField().SetUint64(1)
+	two := NewField().SetUint64(2)
+	four := NewField().SetUint64(4)
+	sqrt2i := FieldFromHex("547CDB7FB03E20F4D4B2FF66C2042858D0BCE7F952D01B873B11E4D8B5F15F3C")
+	invSqrt4 := FieldFromHex("3FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF6")
+	vectors := []struct {
+		u, v, r  *Field
+		isSquare saferith.Choice
+	}{
+		{zero, zero, zero, 1},
+		{one, zero, zero, 0},
+		{two, one, sqrt2i, 0},
+		{four, one, two, 1},
+		{one, four, invSqrt4, 1},
+	}
+	for i, v := range vectors {
+		r := NewField()
+		isSquare := r.SqrtRatio(v.u, v.v)
+		if isSquare != v.isSquare || r.Eq(v.r) != 1 {
+			t.Errorf("vector %d: incorrect result", i)
+		}
+	}
+}
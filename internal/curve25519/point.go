@@ -0,0 +1,151 @@
+package curve25519
+
+import (
+	"crypto/subtle"
+
+	"github.com/cronokirby/saferith"
+)
+
+// d2 is 2 * d, which appears in the addition formula.
+var d2 = NewField().Set(D).Add(D)
+
+// Point represents a point on the twisted Edwards curve -x^2 + y^2 = 1 + d x^2 y^2.
+//
+// This uses extended coordinates (X : Y : Z : T), corresponding to the affine
+// point (X / Z, Y / Z), with X * Y = Z * T.
+type Point struct {
+	X, Y, Z, T Field
+}
+
+// NewIdentity returns the identity point, (0, 1).
+func NewIdentity() *Point {
+	var out Point
+	out.Y.SetUint64(1)
+	out.Z.SetUint64(1)
+	return &out
+}
+
+// basePoint is the standard generator of the prime order subgroup.
+var basePoint = func() *Point {
+	var out Point
+	out.X.Set(FieldFromHex("216936D3CD6E53FEC0A4E231FDD6DC5C692CC7609525A7B2C9562D608F25D51A"))
+	out.Y.Set(FieldFromHex("6666666666666666666666666666666666666666666666666666666666666658"))
+	out.Z.SetUint64(1)
+	out.T.Set(&out.X).Mul(&out.Y)
+	return &out
+}()
+
+// NewBasePoint returns the standard generator of the prime order subgroup.
+func NewBasePoint() *Point {
+	return new(Point).Set(basePoint)
+}
+
+// Set sets p <- q, returning p.
+func (p *Point) Set(q *Point) *Point {
+	*p = *q
+	return p
+}
+
+// Add sets p <- a + b, returning p.
+//
+// This uses the complete addition formula "add-2008-hwcd-3", specialized to a = -1.
+func (p *Point) Add(a, b *Point) *Point {
+	A := NewField().Set(&a.Y).Sub(&a.X)
+	A.Mul(NewField().Set(&b.Y).Sub(&b.X))
+	B := NewField().Set(&a.Y).Add(&a.X)
+	B.Mul(NewField().Set(&b.Y).Add(&b.X))
+	C := NewField().Set(&a.T).Mul(d2).Mul(&b.T)
+	D := NewField().Set(&a.Z).Add(&a.Z).Mul(&b.Z)
+
+	E := NewField().Set(B).Sub(A)
+	F := NewField().Set(D).Sub(C)
+	G := NewField().Set(D).Add(C)
+	H := NewField().Set(B).Add(A)
+
+	p.X.Set(E).Mul(F)
+	p.Y.Set(G).Mul(H)
+	p.T.Set(E).Mul(H)
+	p.Z.Set(F).Mul(G)
+	return p
+}
+
+// Double sets p <- 2 * a, returning p.
+//
+// This uses the doubling formula "dbl-2008-hwcd", specialized to a = -1.
+func (p *Point) Double(a *Point) *Point {
+	A := NewField().Set(&a.X).Square()
+	B := NewField().Set(&a.Y).Square()
+	C := NewField().Set(&a.Z).Square()
+	C.Add(C)
+	// D = -A
+	E := NewField().Set(&a.X).Add(&a.Y).Square().Sub(A).Sub(B)
+	G := NewField().Set(B).Sub(A)
+	F := NewField().Set(G).Sub(C)
+	H := NewField().Set(A).Add(B).Negate()
+
+	p.X.Set(E).Mul(F)
+	p.Y.Set(G).Mul(H)
+	p.T.Set(E).Mul(H)
+	p.Z.Set(F).Mul(G)
+	return p
+}
+
+// Negate sets p <- -a, returning p.
+func (p *Point) Negate(a *Point) *Point {
+	p.X.Set(&a.X).Negate()
+	p.Y.Set(&a.Y)
+	p.Z.Set(&a.Z)
+	p.T.Set(&a.T).Negate()
+	return p
+}
+
+// CondAssign sets p <- q, only if yes = 1, in constant-time.
+func (p *Point) CondAssign(yes saferith.Choice, q *Point) *Point {
+	p.X.CondAssign(yes, &q.X)
+	p.Y.CondAssign(yes, &q.Y)
+	p.Z.CondAssign(yes, &q.Z)
+	p.T.CondAssign(yes, &q.T)
+	return p
+}
+
+// Equal checks if two points are equal, in constant-time.
+func (p *Point) Equal(q *Point) saferith.Choice {
+	// X1 / Z1 = X2 / Z2 is the same as X1 Z2 = X2 Z1, and similarly for Y.
+	x1 := NewField().Set(&p.X).Mul(&q.Z)
+	x2 := NewField().Set(&q.X).Mul(&p.Z)
+	y1 := NewField().Set(&p.Y).Mul(&q.Z)
+	y2 := NewField().Set(&q.Y).Mul(&p.Z)
+	return x1.Eq(x2) & y1.Eq(y2)
+}
+
+// ScalarMult sets p <- k * a, returning p.
+//
+// The scalar k is given as little endian bytes, and can be of any length.
+// This runs in constant-time, processing the scalar 4 bits at a time.
+func (p *Point) ScalarMult(k []byte, a *Point) *Point {
+	// table[j] = j * a
+	var table [16]Point
+	table[0].Set(NewIdentity())
+	for j := 1; j < len(table); j++ {
+		table[j].Add(&table[j-1], a)
+	}
+
+	acc := NewIdentity()
+	var selected Point
+	for i := 2*len(k) - 1; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			acc.Double(acc)
+		}
+		d := int32((k[i/2] >> (4 * (i % 2))) & 0xF)
+		for j := range table {
+			selected.CondAssign(saferith.Choice(subtle.ConstantTimeEq(d, int32(j))), &table[j])
+		}
+		acc.Add(acc, &selected)
+	}
+	return p.Set(acc)
+}
+
+// MulByCofactor sets p <- 8 * a, returning p.
+func (p *Point) MulByCofactor(a *Point) *Point {
+	return p.Double(a).Double(p).Double(p)
+}
@@ -0,0 +1,85 @@
+package curve25519
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// bigL is the order of the prime order subgroup.
+var bigL, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+func littleEndian(x *big.Int) []byte {
+	return Reverse(x.FillBytes(make([]byte, 32)))
+}
+
+func (*Point) Generate(r *rand.Rand, size int) reflect.Value {
+	k := make([]byte, 32)
+	r.Read(k)
+	return reflect.ValueOf(new(Point).ScalarMult(k, basePoint))
+}
+
+func isOnCurve(p *Point) bool {
+	// -X^2 Z^2 + Y^2 Z^2 = Z^4 + d X^2 Y^2, and X Y = Z T.
+	x2 := NewField().Set(&p.X).Square()
+	y2 := NewField().Set(&p.Y).Square()
+	z2 := NewField().Set(&p.Z).Square()
+	lhs := NewField().Set(y2).Sub(x2).Mul(z2)
+	rhs := NewField().Set(z2).Square().Add(NewField().Set(D).Mul(x2).Mul(y2))
+	xy := NewField().Set(&p.X).Mul(&p.Y)
+	zt := NewField().Set(&p.Z).Mul(&p.T)
+	return lhs.Eq(rhs) == 1 && xy.Eq(zt) == 1
+}
+
+func TestBasePointOnCurve(t *testing.T) {
+	if !isOnCurve(basePoint) {
+		t.Error("the base point isn't on the curve")
+	}
+}
+
+func TestDoubleMatchesAdd(t *testing.T) {
+	err := quick.Check(func(a *Point) bool {
+		doubled := new(Point).Double(a)
+		added := new(Point).Add(a, a)
+		return isOnCurve(doubled) && doubled.Equal(added) == 1
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddNegateIsIdentity(t *testing.T) {
+	err := quick.Check(func(a *Point) bool {
+		negated := new(Point).Negate(a)
+		return new(Point).Add(a, negated).Equal(NewIdentity()) == 1
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarMultByOrderIsIdentity(t *testing.T) {
+	if new(Point).ScalarMult(littleEndian(bigL), basePoint).Equal(NewIdentity()) != 1 {
+		t.Error("l * B should be the identity")
+	}
+	lMinusOne := littleEndian(new(big.Int).Sub(bigL, big.NewInt(1)))
+	expected := new(Point).Negate(basePoint)
+	if new(Point).ScalarMult(lMinusOne, basePoint).Equal(expected) != 1 {
+		t.Error("(l - 1) * B should be -B")
+	}
+}
+
+func TestScalarMultIsAdditive(t *testing.T) {
+	err := quick.Check(func(a, b uint32) bool {
+		sum := new(big.Int).Add(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		way1 := new(Point).ScalarMult(littleEndian(sum), basePoint)
+		aB := new(Point).ScalarMult(littleEndian(big.NewInt(int64(a))), basePoint)
+		bB := new(Point).ScalarMult(littleEndian(big.NewInt(int64(b))), basePoint)
+		return way1.Equal(new(Point).Add(aB, bB)) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,199 @@
+package curve25519
+
+import (
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Order is the order of the prime order subgroup, 2^252 + 27742317777372353535851937790883648493.
+var Order, _ = saferith.ModulusFromHex("1000000000000000000000000000000014DEF9DEA2F79CD65812631A5CF5D3ED")
+
+// ScalarBytes is the number of bytes in the encoding of a scalar.
+const ScalarBytes = 32
+
+// UniformBytes is the number of bytes needed by SetUniformBytes.
+const UniformBytes = 64
+
+// Group connects scalars to the group of points they act on.
+//
+// The implementations carry no data, so that each group has a distinct Scalar
+// type, and are provided by the edwards25519 and ristretto255 packages, both of
+// which represent their points with a Point.
+type Group interface {
+	// Prefix returns the name of the package, to be used in error messages.
+	Prefix() string
+	// Curve returns the curve implementing the group.
+	Curve() kyokusen.Curve
+	// Unwrap returns the Point representing an element of the group.
+	//
+	// This should panic if the element belongs to a different group.
+	Unwrap(kyokusen.Point) *Point
+	// Wrap returns the element of the group represented by a Point.
+	Wrap(*Point) kyokusen.Point
+}
+
+// Scalar represents an integer modulo the order of the prime order subgroup.
+type Scalar[G Group] struct {
+	nat saferith.Nat
+}
+
+func (s *Scalar[G]) String() string {
+	return s.nat.String()
+}
+
+// NewScalar returns a new scalar, with its value set to 0.
+func NewScalar[G Group]() *Scalar[G] {
+	s := new(Scalar[G])
+	s.nat.Mod(&s.nat, Order)
+	return s
+}
+
+// castScalar converts a scalar implementing the generic interface to this specific type.
+//
+// Since implementors of the Scalar interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+func castScalar[G Group](s kyokusen.Scalar) *Scalar[G] {
+	casted, ok := s.(*Scalar[G])
+	if !ok {
+		var group G
+		panic(fmt.Sprintf("failed to cast type to *%s.Scalar", group.Prefix()))
+	}
+	return casted
+}
+
+// MarshalBinary returns the contents of this scalar as Big Endian bytes.
+//
+// Note that most protocols over Curve25519 encode scalars in little endian
+// order instead, which is what Bytes returns.
+func (s *Scalar[G]) MarshalBinary() ([]byte, error) {
+	return s.nat.FillBytes(make([]byte, ScalarBytes)), nil
+}
+
+// UnmarshalBinary deserializes Big Endian bytes into this scalar.
+func (s *Scalar[G]) UnmarshalBinary(data []byte) error {
+	var group G
+	if len(data) != ScalarBytes {
+		return fmt.Errorf("%s.Scalar.UnmarshalBinary: invalid data length", group.Prefix())
+	}
+	var nat saferith.Nat
+	nat.SetBytes(data)
+	if _, _, lt := nat.CmpMod(Order); lt != 1 {
+		return fmt.Errorf("%s.Scalar.UnmarshalBinary: value is greater than order", group.Prefix())
+	}
+	s.nat.Mod(&nat, Order)
+	return nil
+}
+
+// Bytes returns the canonical little endian encoding of this scalar.
+func (s *Scalar[G]) Bytes() []byte {
+	out, _ := s.MarshalBinary()
+	return Reverse(out)
+}
+
+// SetCanonicalBytes sets this scalar from its canonical little endian encoding.
+func (s *Scalar[G]) SetCanonicalBytes(data []byte) error {
+	if len(data) != ScalarBytes {
+		var group G
+		return fmt.Errorf("%s.Scalar.SetCanonicalBytes: invalid data length", group.Prefix())
+	}
+	return s.UnmarshalBinary(Reverse(append([]byte{}, data...)))
+}
+
+// SetUniformBytes sets this scalar by reducing 64 little endian bytes modulo the order.
+//
+// If these bytes are uniformly random, then the resulting scalar will be too.
+func (s *Scalar[G]) SetUniformBytes(data []byte) error {
+	if len(data) != UniformBytes {
+		var group G
+		return fmt.Errorf("%s.Scalar.SetUniformBytes: invalid data length", group.Prefix())
+	}
+	s.nat.Mod(new(saferith.Nat).SetBytes(Reverse(append([]byte{}, data...))), Order)
+	return nil
+}
+
+// Curve returns the curve associated with this scalar field.
+func (s *Scalar[G]) Curve() kyokusen.Curve {
+	var group G
+	return group.Curve()
+}
+
+func (s1 *Scalar[G]) Add(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar[G](other)
+	s1.nat.ModAdd(&s1.nat, &s2.nat, Order)
+	return s1
+}
+
+func (s1 *Scalar[G]) Sub(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar[G](other)
+	s1.nat.ModSub(&s1.nat, &s2.nat, Order)
+	return s1
+}
+
+func (s1 *Scalar[G]) Negate() kyokusen.Scalar {
+	s1.nat.ModNeg(&s1.nat, Order)
+	return s1
+}
+
+func (s1 *Scalar[G]) Mul(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar[G](other)
+	s1.nat.ModMul(&s1.nat, &s2.nat, Order)
+	return s1
+}
+
+func (s1 *Scalar[G]) Invert() kyokusen.Scalar {
+	s1.nat.ModInverse(&s1.nat, Order)
+	return s1
+}
+
+func (s1 *Scalar[G]) Equal(other kyokusen.Scalar) bool {
+	s2 := castScalar[G](other)
+	return s1.nat.Eq(&s2.nat) == 1
+}
+
+func (s1 *Scalar[G]) IsZero() bool {
+	return s1.nat.EqZero() == 1
+}
+
+func (s1 *Scalar[G]) Set(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := castScalar[G](other)
+	s1.nat.SetNat(&s2.nat)
+	return s1
+}
+
+func (s1 *Scalar[G]) SetNat(other *saferith.Nat) kyokusen.Scalar {
+	s1.nat.Mod(other, Order)
+	return s1
+}
+
+// Act calculates s * P, in constant-time.
+func (s *Scalar[G]) Act(other kyokusen.Point) kyokusen.Point {
+	var group G
+	return group.Wrap(new(Point).ScalarMult(s.Bytes(), group.Unwrap(other)))
+}
+
+// ActOnBase calculates s * B, where B is the generator of the group.
+func (s *Scalar[G]) ActOnBase() kyokusen.Point {
+	var group G
+	return group.Wrap(new(Point).ScalarMult(s.Bytes(), basePoint))
+}
+
+// VartimeAct calculates s * P, in variable-time, using a width 5 NAF.
+//
+// The time this takes depends on the value of s, so this must NEVER be used
+// with a secret scalar. This implements the kyokusen.VartimeScalar interface.
+func (s *Scalar[G]) VartimeAct(other kyokusen.Point) kyokusen.Point {
+	var group G
+	return group.Wrap(new(Point).VartimeScalarMult(s.Bytes(), group.Unwrap(other)))
+}
+
+// VartimeDoubleBaseMul calculates s * B + b * P, in variable-time, where B is the generator of the group.
+//
+// The time this takes depends on the value of the scalars, so this must NEVER
+// be used with secret scalars.
+func (s *Scalar[G]) VartimeDoubleBaseMul(b kyokusen.Scalar, P kyokusen.Point) kyokusen.Point {
+	var group G
+	out := new(Point).VartimeDoubleBaseMult(s.Bytes(), castScalar[G](b).Bytes(), group.Unwrap(P))
+	return group.Wrap(out)
+}
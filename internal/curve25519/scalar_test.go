@@ -0,0 +1,98 @@
+package curve25519
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// testGroup lets us test the arithmetic of scalars, without any points to act on.
+type testGroup struct{}
+
+func (testGroup) Prefix() string {
+	return "curve25519"
+}
+
+func (testGroup) Curve() kyokusen.Curve {
+	panic("curve25519: testGroup has no curve")
+}
+
+func (testGroup) Unwrap(kyokusen.Point) *Point {
+	panic("curve25519: testGroup has no points")
+}
+
+func (testGroup) Wrap(*Point) kyokusen.Point {
+	panic("curve25519: testGroup has no points")
+}
+
+type testScalar = Scalar[testGroup]
+
+// newTestScalar reduces little endian bytes into a scalar, for use with quick.Check.
+func newTestScalar(data [ScalarBytes]byte) *testScalar {
+	s := NewScalar[testGroup]()
+	s.SetNat(new(saferith.Nat).SetBytes(Reverse(data[:])))
+	return s
+}
+
+func TestScalarMultiplyInverse(t *testing.T) {
+	err := quick.Check(func(data [ScalarBytes]byte) bool {
+		a := newTestScalar(data)
+		if a.IsZero() {
+			return true
+		}
+		shouldBeOne := NewScalar[testGroup]().Set(a).Invert().Mul(a)
+		one := NewScalar[testGroup]().SetNat(new(saferith.Nat).SetUint64(1))
+		return shouldBeOne.Equal(one)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarMarshalRoundtrip(t *testing.T) {
+	err := quick.Check(func(data [ScalarBytes]byte) bool {
+		a := newTestScalar(data)
+		marshalled, err := a.MarshalBinary()
+		if err != nil {
+			return false
+		}
+		unmarshalled := NewScalar[testGroup]()
+		if err := unmarshalled.UnmarshalBinary(marshalled); err != nil {
+			return false
+		}
+		decoded := NewScalar[testGroup]()
+		if err := decoded.SetCanonicalBytes(a.Bytes()); err != nil {
+			return false
+		}
+		return unmarshalled.Equal(a) && decoded.Equal(a)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarRejectsNonCanonical(t *testing.T) {
+	lBytes := littleEndian(bigL)
+	if NewScalar[testGroup]().SetCanonicalBytes(lBytes) == nil {
+		t.Error("l should be rejected as a scalar")
+	}
+	if NewScalar[testGroup]().UnmarshalBinary(Reverse(lBytes)) == nil {
+		t.Error("l should be rejected as a scalar")
+	}
+}
+
+func TestScalarSetUniformBytes(t *testing.T) {
+	// 2^512 - 1 mod l, in little endian order.
+	expected, _ := hex.DecodeString("000f9c44e31106a447938568a71b0ed065bef517d273ecce3d9a307c1b419903")
+	s := NewScalar[testGroup]()
+	if err := s.SetUniformBytes(bytes.Repeat([]byte{0xFF}, UniformBytes)); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s.Bytes(), expected) {
+		t.Errorf("expected %x, got %x", expected, s.Bytes())
+	}
+}
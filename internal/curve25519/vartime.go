@@ -0,0 +1,82 @@
+package curve25519
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/cronokirby/kyokusen/internal/wnaf"
+)
+
+// The functions in this file run in variable-time, and must NEVER be used with secret scalars.
+
+// vartimeWindow is the width of the NAF used for arbitrary points.
+const vartimeWindow = 5
+
+// vartimeBaseWindow is the width of the NAF used for the base point, which has a larger precomputed table.
+const vartimeBaseWindow = 8
+
+// recode computes the width w NAF of a number given as little endian bytes.
+func recode(data []byte, w uint) []int8 {
+	return wnaf.Recode(new(big.Int).SetBytes(Reverse(append([]byte{}, data...))), w)
+}
+
+// oddMultiples returns a, 3a, 5a, ..., (2^(w - 1) - 1)a.
+func oddMultiples(a *Point, w uint) []*Point {
+	out := make([]*Point, 1<<(w-2))
+	out[0] = new(Point).Set(a)
+	double := new(Point).Double(a)
+	for i := 1; i < len(out); i++ {
+		out[i] = new(Point).Add(out[i-1], double)
+	}
+	return out
+}
+
+// wnafGroup implements the operations wnaf.Interleave needs, modifying the accumulator in place.
+var wnafGroup = &wnaf.Group[*Point]{
+	Identity: NewIdentity,
+	Double: func(a *Point) *Point {
+		return a.Double(a)
+	},
+	Add: func(a, b *Point) *Point {
+		return a.Add(a, b)
+	},
+	Sub: func(a, b *Point) *Point {
+		var neg Point
+		return a.Add(a, neg.Negate(b))
+	},
+}
+
+var vartimeBaseTableOnce sync.Once
+
+// vartimeBaseTable holds odd multiples of the base point.
+var vartimeBaseTable []*Point
+
+func getVartimeBaseTable() []*Point {
+	vartimeBaseTableOnce.Do(func() {
+		vartimeBaseTable = oddMultiples(basePoint, vartimeBaseWindow)
+	})
+	return vartimeBaseTable
+}
+
+// VartimeScalarMult sets p <- k * a, returning p, in variable-time.
+//
+// The scalar k is given as little endian bytes, and can be of any length.
+// The time this takes depends on the value of k, so this must NEVER be used
+// with a secret scalar.
+func (p *Point) VartimeScalarMult(k []byte, a *Point) *Point {
+	return p.Set(wnaf.Interleave(wnafGroup, []wnaf.Term[*Point]{
+		{Digits: recode(k, vartimeWindow), Table: oddMultiples(a, vartimeWindow)},
+	}))
+}
+
+// VartimeDoubleBaseMult sets p <- k1 * B + k2 * a, returning p, in variable-time, where B is the base point.
+//
+// The scalars k1 and k2 are given as little endian bytes, and can be of any length.
+// The time this takes depends on the value of the scalars, so this must NEVER
+// be used with secret scalars.
+func (p *Point) VartimeDoubleBaseMult(k1, k2 []byte, a *Point) *Point {
+	return p.Set(wnaf.Interleave(wnafGroup, []wnaf.Term[*Point]{
+		{Digits: recode(k1, vartimeBaseWindow), Table: getVartimeBaseTable()},
+		{Digits: recode(k2, vartimeWindow), Table: oddMultiples(a, vartimeWindow)},
+	}))
+}
@@ -0,0 +1,39 @@
+package curve25519
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestVartimeScalarMultMatchesScalarMult(t *testing.T) {
+	err := quick.Check(func(k [32]byte, a *Point) bool {
+		return new(Point).VartimeScalarMult(k[:], a).Equal(new(Point).ScalarMult(k[:], a)) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVartimeDoubleBaseMultMatchesScalarMult(t *testing.T) {
+	err := quick.Check(func(k1, k2 [32]byte, a *Point) bool {
+		expected := new(Point).ScalarMult(k1[:], basePoint)
+		expected.Add(expected, new(Point).ScalarMult(k2[:], a))
+		return new(Point).VartimeDoubleBaseMult(k1[:], k2[:], a).Equal(expected) == 1
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVartimeEdgeCases(t *testing.T) {
+	zero := make([]byte, 32)
+	if new(Point).VartimeScalarMult(zero, basePoint).Equal(NewIdentity()) != 1 {
+		t.Error("0 * B should be the identity")
+	}
+	if new(Point).VartimeDoubleBaseMult(zero, zero, basePoint).Equal(NewIdentity()) != 1 {
+		t.Error("0 * B + 0 * B should be the identity")
+	}
+	if new(Point).VartimeScalarMult(littleEndian(bigL), basePoint).Equal(NewIdentity()) != 1 {
+		t.Error("l * B should be the identity")
+	}
+}
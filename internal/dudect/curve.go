@@ -0,0 +1,203 @@
+package dudect
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Measurements is the number of timings to take for each operation, which can be set with a flag.
+var Measurements = flag.Int("dudect.measurements", 1<<16, "the number of timings to take for each operation")
+
+// Check runs a timing test, failing if the operation leaks which class its input is in.
+//
+// Each input is created by calling prepare with a source of randomness, shared across
+// calls, and whether or not it belongs to the fixed class. The t-statistic is logged.
+func Check[T any](t *testing.T, prepare func(r *rand.Rand, fixed bool) T, op func(T)) {
+	t.Helper()
+	r := rand.New(rand.NewSource(0))
+	result := Run(*Measurements, func(fixed bool) T {
+		return prepare(r, fixed)
+	}, op)
+	t.Logf("%d measurements, t = %.2f", result.Measurements, result.T)
+	if result.Leaks() {
+		t.Errorf("timing depends on the secret input: t = %.2f > %d", result.T, Threshold)
+	}
+}
+
+// pointPoolSize is the number of random points each test picks from.
+//
+// Scalar multiplication is slow on some curves, so we don't create a new point for every measurement.
+const pointPoolSize = 64
+
+// curveTester holds the curve whose operations we're timing.
+type curveTester struct {
+	curve kyokusen.Curve
+	// points holds random points, used by the random class.
+	points []kyokusen.Point
+}
+
+// TestCurve runs timing tests on the operations of a curve handling secret values.
+//
+// These take a while, and can be flaky on a busy machine, so the packages implementing
+// each curve only call this in tests behind the dudect build tag:
+//
+//	go test -tags dudect -run ConstantTime -v ./p256
+//
+// Each operation is run as a separate subtest, comparing a fixed secret against
+// random secrets. The optional interfaces a curve implements, like SelectablePoint,
+// are tested as well. On curves with slow scalar multiplication, the number of
+// measurements can be lowered with the -dudect.measurements flag.
+func TestCurve(t *testing.T, curve kyokusen.Curve) {
+	ct := &curveTester{curve: curve}
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < pointPoolSize; i++ {
+		ct.points = append(ct.points, ct.scalar(r, false).ActOnBase())
+	}
+	for _, test := range []struct {
+		name string
+		run  func(*curveTester, *testing.T)
+	}{
+		{"ScalarInvert", (*curveTester).testScalarInvert},
+		{"ScalarMul", (*curveTester).testScalarMul},
+		{"ScalarUnmarshalBinary", (*curveTester).testScalarUnmarshalBinary},
+		{"ScalarAct", (*curveTester).testScalarAct},
+		{"ScalarActOnBase", (*curveTester).testScalarActOnBase},
+		{"PointAdd", (*curveTester).testPointAdd},
+		{"PointUnmarshalBinary", (*curveTester).testPointUnmarshalBinary},
+		{"PointCondSelect", (*curveTester).testPointCondSelect},
+		{"MultiScalarMul", (*curveTester).testMultiScalarMul},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.run(ct, t)
+		})
+	}
+}
+
+// The helpers below always pick a random value, even for the fixed class, so that
+// the work done between measurements, which disturbs the caches, is the same for both.
+
+// scalar returns 0 for the fixed class, and a random scalar otherwise.
+func (ct *curveTester) scalar(r *rand.Rand, fixed bool) kyokusen.Scalar {
+	data := make([]byte, ct.curve.SafeScalarBytes())
+	r.Read(data)
+	s := ct.curve.NewScalar().SetNat(new(saferith.Nat).SetBytes(data))
+	if fixed {
+		return ct.curve.NewScalar()
+	}
+	return s
+}
+
+// point returns the base point for the fixed class, and a random point otherwise.
+func (ct *curveTester) point(r *rand.Rand, fixed bool) kyokusen.Point {
+	P := ct.points[r.Intn(len(ct.points))]
+	if fixed {
+		return ct.curve.NewBasePoint()
+	}
+	return P
+}
+
+func (ct *curveTester) testScalarInvert(t *testing.T) {
+	Check(t, ct.scalar, func(s kyokusen.Scalar) {
+		s.Invert()
+	})
+}
+
+func (ct *curveTester) testScalarMul(t *testing.T) {
+	// The other factor is public, and the same for both classes.
+	b := ct.scalar(rand.New(rand.NewSource(1)), false)
+	Check(t, ct.scalar, func(s kyokusen.Scalar) {
+		s.Mul(b)
+	})
+}
+
+func (ct *curveTester) testScalarUnmarshalBinary(t *testing.T) {
+	Check(t, func(r *rand.Rand, fixed bool) []byte {
+		data, _ := ct.scalar(r, fixed).MarshalBinary()
+		return data
+	}, func(data []byte) {
+		ct.curve.NewScalar().UnmarshalBinary(data)
+	})
+}
+
+func (ct *curveTester) testScalarAct(t *testing.T) {
+	// The point is public, and the same for both classes; only the scalar is secret.
+	P := ct.point(rand.New(rand.NewSource(1)), false)
+	Check(t, ct.scalar, func(s kyokusen.Scalar) {
+		s.Act(P)
+	})
+}
+
+func (ct *curveTester) testScalarActOnBase(t *testing.T) {
+	// Build any precomputed tables before measuring anything.
+	ct.curve.NewScalar().ActOnBase()
+	Check(t, ct.scalar, func(s kyokusen.Scalar) {
+		s.ActOnBase()
+	})
+}
+
+func (ct *curveTester) testPointAdd(t *testing.T) {
+	// The fixed class adds a point to itself, which incomplete formulas need to treat specially.
+	type pair struct{ P, Q kyokusen.Point }
+	Check(t, func(r *rand.Rand, fixed bool) pair {
+		P := ct.point(r, false)
+		Q := ct.point(r, false)
+		if fixed {
+			Q = P
+		}
+		return pair{P, Q}
+	}, func(pq pair) {
+		pq.P.Add(pq.Q)
+	})
+}
+
+func (ct *curveTester) testPointUnmarshalBinary(t *testing.T) {
+	Check(t, func(r *rand.Rand, fixed bool) []byte {
+		data, _ := ct.point(r, fixed).MarshalBinary()
+		return data
+	}, func(data []byte) {
+		ct.curve.NewPoint().UnmarshalBinary(data)
+	})
+}
+
+func (ct *curveTester) testPointCondSelect(t *testing.T) {
+	if _, ok := ct.curve.NewPoint().(kyokusen.SelectablePoint); !ok {
+		t.Skip("points don't implement SelectablePoint")
+	}
+	// The points are public, and the same for both classes; only the choice is secret.
+	r := rand.New(rand.NewSource(1))
+	P := ct.point(r, false).(kyokusen.SelectablePoint)
+	Q := ct.point(r, false)
+	Check(t, func(r *rand.Rand, fixed bool) saferith.Choice {
+		yes := saferith.Choice(r.Intn(2))
+		if fixed {
+			return 0
+		}
+		return yes
+	}, func(yes saferith.Choice) {
+		P.CondSelect(yes, Q)
+	})
+}
+
+func (ct *curveTester) testMultiScalarMul(t *testing.T) {
+	// The points are public, and the same for both classes; only the scalars are secret.
+	const n = 4
+	r := rand.New(rand.NewSource(1))
+	points := make([]kyokusen.Point, n)
+	for i := range points {
+		points[i] = ct.point(r, false)
+	}
+	Check(t, func(r *rand.Rand, fixed bool) []kyokusen.Scalar {
+		scalars := make([]kyokusen.Scalar, n)
+		for i := range scalars {
+			scalars[i] = ct.scalar(r, fixed)
+		}
+		return scalars
+	}, func(scalars []kyokusen.Scalar) {
+		kyokusen.MultiScalarMul(scalars, points)
+	})
+}
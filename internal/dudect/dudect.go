@@ -0,0 +1,132 @@
+// Package dudect implements statistical timing tests, to detect operations which aren't constant-time.
+//
+// This follows "dude, is my code constant time?" by Reparaz, Balasch, and Verbauwhede:
+// https://eprint.iacr.org/2016/1123. We time an operation on inputs from two classes,
+// usually a fixed value, and uniformly random values, and then use Welch's t-test
+// to check if the two distributions of timings differ. No model of the hardware is
+// needed, so this works on an ordinary machine, but only finds leaks large enough
+// to stand out from the noise.
+//
+// This package isn't intended to be used directly.
+package dudect
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Threshold is the t-statistic above which we consider an operation to leak timing information.
+//
+// This is the threshold used in the original paper. Values above this mean that the
+// timings of the two classes are different, with overwhelming confidence.
+const Threshold = 10
+
+// percentiles is the number of cropped tests we run, in addition to the uncropped one.
+const percentiles = 100
+
+// minSamples is the number of samples a test needs, for its result to be used.
+const minSamples = 1000
+
+// welch accumulates the statistics needed for Welch's t-test, for two classes of samples.
+//
+// This uses Welford's online algorithm to calculate the mean and variance.
+type welch struct {
+	n    [2]float64
+	mean [2]float64
+	m2   [2]float64
+}
+
+func (w *welch) push(class int, x float64) {
+	w.n[class]++
+	delta := x - w.mean[class]
+	w.mean[class] += delta / w.n[class]
+	w.m2[class] += delta * (x - w.mean[class])
+}
+
+func (w *welch) samples() float64 {
+	return w.n[0] + w.n[1]
+}
+
+// t calculates the t-statistic for the difference between the means of both classes.
+func (w *welch) t() float64 {
+	if w.n[0] < 2 || w.n[1] < 2 {
+		return 0
+	}
+	v0 := w.m2[0] / (w.n[0] - 1)
+	v1 := w.m2[1] / (w.n[1] - 1)
+	den := math.Sqrt(v0/w.n[0] + v1/w.n[1])
+	if den == 0 {
+		return 0
+	}
+	return (w.mean[0] - w.mean[1]) / den
+}
+
+// Result holds the outcome of a timing test.
+type Result struct {
+	// Measurements is the number of timings used in the statistics.
+	Measurements int
+	// T is the largest t-statistic, in absolute value, over each test we ran.
+	T float64
+}
+
+// Leaks checks if this result indicates that the timing depends on the class of inputs.
+func (r Result) Leaks() bool {
+	return r.T > Threshold
+}
+
+// Run measures an operation n times, and compares the timings between the fixed and random classes.
+//
+// Before each measurement, prepare is called, outside of the timed section, with fixed set
+// to true for the fixed class, and false for the random class. Preparing each input just
+// before using it means that it's in the cache, no matter how much work went into
+// creating it, so that differences in memory layout between the classes don't show up in
+// the timings. The classes are interleaved randomly, to avoid systematic effects.
+//
+// Like the original paper, we run the t-test on all the timings, and then again after
+// cropping the timings above a range of percentiles, which removes the outliers
+// caused by interrupts and the like. The largest t-statistic over all of these is
+// returned.
+func Run[T any](n int, prepare func(fixed bool) T, op func(T)) Result {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	classes := make([]int, n)
+	timings := make([]float64, n)
+	for i := range timings {
+		classes[i] = r.Intn(2)
+		input := prepare(classes[i] == 0)
+		start := time.Now()
+		op(input)
+		timings[i] = float64(time.Since(start))
+	}
+	// The first measurements are taken while the caches and branch predictors warm up.
+	warmup := n / 10
+	classes = classes[warmup:]
+	timings = timings[warmup:]
+
+	sorted := append([]float64(nil), timings...)
+	sort.Float64s(sorted)
+	var tests [1 + percentiles]welch
+	cutoffs := make([]float64, percentiles)
+	for i := range cutoffs {
+		p := 1 - math.Pow(0.5, 10*float64(i+1)/percentiles)
+		cutoffs[i] = sorted[int(p*float64(len(sorted)-1))]
+	}
+	for i, x := range timings {
+		tests[0].push(classes[i], x)
+		for j, cutoff := range cutoffs {
+			if x < cutoff {
+				tests[1+j].push(classes[i], x)
+			}
+		}
+	}
+
+	result := Result{Measurements: len(timings)}
+	for i := range tests {
+		if tests[i].samples() < minSamples {
+			continue
+		}
+		result.T = math.Max(result.T, math.Abs(tests[i].t()))
+	}
+	return result
+}
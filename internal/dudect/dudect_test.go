@@ -0,0 +1,52 @@
+package dudect
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWelchMatchesDirectCalculation(t *testing.T) {
+	samples := [2][]float64{{1, 2, 3, 4, 5}, {2, 4, 6, 8, 10, 12}}
+	var w welch
+	for class, xs := range samples {
+		for _, x := range xs {
+			w.push(class, x)
+		}
+	}
+	// The means are 3 and 7, and the variances are 2.5 and 14.
+	expected := (3 - 7) / math.Sqrt(2.5/5+14.0/6)
+	if math.Abs(w.t()-expected) > 1e-9 {
+		t.Errorf("t = %f, expected %f", w.t(), expected)
+	}
+}
+
+func TestWelchIdenticalClasses(t *testing.T) {
+	var w welch
+	for i := 0; i < 100; i++ {
+		w.push(i%2, 7)
+	}
+	if w.t() != 0 {
+		t.Errorf("t = %f, expected 0", w.t())
+	}
+}
+
+var sink int
+
+func TestRunDetectsLeak(t *testing.T) {
+	result := Run(10000, func(fixed bool) int {
+		if fixed {
+			return 0
+		}
+		return 10000
+	}, func(iterations int) {
+		for i := 0; i < iterations; i++ {
+			sink += i
+		}
+	})
+	if !result.Leaks() {
+		t.Errorf("expected a leak to be detected, got t = %f", result.T)
+	}
+	if result.Measurements != 9000 {
+		t.Errorf("expected 9000 measurements, got %d", result.Measurements)
+	}
+}
@@ -0,0 +1,18 @@
+// Package limbs implements the constant-time helpers shared by the fields which
+// represent numbers as 64 bit limbs.
+//
+// This package isn't intended to be used directly.
+package limbs
+
+import "github.com/cronokirby/saferith"
+
+// Mask returns a word with every bit set to yes.
+func Mask(yes saferith.Choice) uint64 {
+	return -uint64(yes)
+}
+
+// IsZero returns 1 if x = 0, and 0 otherwise, in constant-time.
+func IsZero(x uint64) saferith.Choice {
+	// The top bit of x | -x is set exactly when x != 0.
+	return saferith.Choice(1 ^ ((x | -x) >> 63))
+}
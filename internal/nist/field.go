@@ -0,0 +1,151 @@
+package nist
+
+import (
+	"fmt"
+
+	"github.com/cronokirby/saferith"
+)
+
+// Field represents an element in the prime field of one of the curves.
+//
+// Each element remembers its curve, so elements need to be created with Curve.NewField.
+type Field struct {
+	c   *Curve
+	nat saferith.Nat
+}
+
+// NewField creates a new field element, with its value set to 0.
+func (c *Curve) NewField() *Field {
+	z := &Field{c: c}
+	// This will conveniently set the announced size, and mark this number as reduced modulo p.
+	z.nat.Mod(&z.nat, c.p)
+	return z
+}
+
+// fieldFromHex creates a new field element from a hex string, reducing it modulo p.
+func (c *Curve) fieldFromHex(hex string) *Field {
+	nat, err := new(saferith.Nat).SetHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	z := c.NewField()
+	z.nat.Mod(nat, c.p)
+	return z
+}
+
+// Set calculates z <- x, returning z.
+func (z *Field) Set(x *Field) *Field {
+	z.nat.SetNat(&x.nat)
+	return z
+}
+
+// SetUint64 calculates z <- x, returning z.
+func (z *Field) SetUint64(x uint64) *Field {
+	z.nat.SetUint64(x)
+	return z
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *Field) CondAssign(yes saferith.Choice, x *Field) *Field {
+	z.nat.CondAssign(yes, &x.nat)
+	return z
+}
+
+// CondNegate sets z <- -z, only if yes = 1, in constant-time.
+func (z *Field) CondNegate(yes saferith.Choice) *Field {
+	negated := z.c.NewField().Set(z).Negate()
+	return z.CondAssign(yes, negated)
+}
+
+// String returns a string representation of this field element.
+func (z *Field) String() string {
+	return z.nat.String()
+}
+
+// Add calculates z <- z + a, returning z.
+func (z *Field) Add(a *Field) *Field {
+	z.nat.ModAdd(&z.nat, &a.nat, z.c.p)
+	return z
+}
+
+// Sub calculates z <- z - a, returning z.
+func (z *Field) Sub(a *Field) *Field {
+	z.nat.ModSub(&z.nat, &a.nat, z.c.p)
+	return z
+}
+
+// Negate calculates z <- -z, returning z.
+func (z *Field) Negate() *Field {
+	z.nat.ModNeg(&z.nat, z.c.p)
+	return z
+}
+
+// Mul calculates z <- z * a, returning z.
+func (z *Field) Mul(a *Field) *Field {
+	z.nat.ModMul(&z.nat, &a.nat, z.c.p)
+	return z
+}
+
+// Square calculates z <- z * z, returning z.
+func (z *Field) Square() *Field {
+	return z.Mul(z)
+}
+
+// Invert calculates z <- z^-1, returning z.
+func (z *Field) Invert() *Field {
+	z.nat.ModInverse(&z.nat, z.c.p)
+	return z
+}
+
+// Eq checks if two field values are equal, in constant-time.
+func (z *Field) Eq(x *Field) saferith.Choice {
+	return z.nat.Eq(&x.nat)
+}
+
+// EqZero checks if a field value is equal to 0, in constant-time.
+func (z *Field) EqZero() saferith.Choice {
+	return z.nat.EqZero()
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// This encodes the field element as big endian bytes. The result will always occupy
+// exactly FieldBytes bytes of space.
+func (z *Field) MarshalBinary() ([]byte, error) {
+	// Since z will always be reduced modulo p, this just pads the announced size.
+	z.nat.Mod(&z.nat, z.c.p)
+	return z.nat.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// This expects exactly FieldBytes Big Endian bytes, and will also return an error if the
+// resulting value is >= the field modulus.
+func (z *Field) UnmarshalBinary(data []byte) error {
+	if len(data) != z.c.params.FieldBytes {
+		return fmt.Errorf("%s.Field.UnmarshalBinary: invalid data length", z.c.prefix)
+	}
+	z.nat.SetBytes(data)
+	if _, _, lt := z.nat.CmpMod(z.c.p); lt != 1 {
+		return fmt.Errorf("%s.Field.UnmarshalBinary: value is greater than field prime", z.c.prefix)
+	}
+	return nil
+}
+
+// HasSqrt checks if a field value has a valid square root.
+func (z *Field) HasSqrt() saferith.Choice {
+	check := new(saferith.Nat).Exp(&z.nat, z.c.pDiv2, z.c.p)
+	one := new(saferith.Nat).SetUint64(1)
+	return check.Eq(one) | check.EqZero()
+}
+
+// Sqrt calculates z <- sqrt(z), if such a value exists. Otherwise, the result is undefined.
+func (z *Field) Sqrt() *Field {
+	z.nat.ModSqrt(&z.nat, z.c.p)
+	return z
+}
+
+// IsEven returns a choice indicating if a field element is even.
+func (z *Field) IsEven() saferith.Choice {
+	return 1 ^ saferith.Choice(z.nat.Byte(0)&1)
+}
@@ -0,0 +1,111 @@
+// Package nist implements the short Weierstrass curves with a = -3 standardized by NIST.
+//
+// The arithmetic is the same for P-256, P-384, and P-521, with only the field,
+// the order, the constant b, and the generator changing. Each curve is described by
+// Params, and the packages p256, p384, and p521 wrap a Curve built from these.
+//
+// This package isn't intended to be used directly.
+package nist
+
+import (
+	"strings"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Params describes one of the NIST curves, y^2 = x^3 - 3x + b.
+type Params struct {
+	// Curve is the value returned by the Curve method of points and scalars.
+	//
+	// This lets each wrapping package use its own type for the curve.
+	Curve kyokusen.Curve
+	// Name is the standard name of the curve, like "P-256".
+	Name string
+	// P is the prime modulus of the field, in hex.
+	P string
+	// Q is the order of the group, in hex.
+	Q string
+	// B is the constant term in the equation of the curve, in hex.
+	B string
+	// Gx and Gy are the affine coordinates of the generator, in hex.
+	Gx string
+	Gy string
+	// FieldBytes is the number of bytes needed to encode a field element.
+	FieldBytes int
+	// ScalarBytes is the number of bytes needed to encode a scalar.
+	ScalarBytes int
+	// SafeScalarBytes is the number of random bytes needed to sample a scalar without bias.
+	SafeScalarBytes int
+}
+
+// Curve holds the constants for one of the NIST curves, derived from its Params.
+type Curve struct {
+	params Params
+	// prefix is used in error messages, and matches the name of the wrapping package.
+	prefix string
+	p      *saferith.Modulus
+	// pDiv2 is (p - 1) / 2, useful for checking if a value has a square root.
+	pDiv2     *saferith.Nat
+	q         *saferith.Modulus
+	b         *Field
+	basePoint *Point
+}
+
+func modulusFromHex(hex string) *saferith.Modulus {
+	m, err := saferith.ModulusFromHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// NewCurve creates a curve from its parameters, panicking if they're malformed.
+func NewCurve(params Params) *Curve {
+	c := &Curve{
+		params: params,
+		prefix: strings.ToLower(strings.ReplaceAll(params.Name, "-", "")),
+		p:      modulusFromHex(params.P),
+		q:      modulusFromHex(params.Q),
+	}
+	if (c.p.BitLen()+7)/8 != params.FieldBytes || (c.q.BitLen()+7)/8 != params.ScalarBytes {
+		panic("nist: byte sizes don't match the parameters of " + params.Name)
+	}
+	c.pDiv2 = new(saferith.Nat).Rsh(c.p.Nat(), 1, c.p.BitLen())
+	c.b = c.fieldFromHex(params.B)
+	c.basePoint = &Point{
+		c:          c,
+		x:          c.fieldFromHex(params.Gx),
+		y:          c.fieldFromHex(params.Gy),
+		z:          c.NewField().SetUint64(1),
+		normalized: true,
+	}
+	return c
+}
+
+// NewBasePoint returns a copy of the generator of the group.
+//
+// We return a copy, so that users can't modify the shared base point.
+func (c *Curve) NewBasePoint() *Point {
+	return c.NewPoint().CondAssign(1, c.basePoint)
+}
+
+// Name returns the standard name of this curve.
+func (c *Curve) Name() string {
+	return c.params.Name
+}
+
+// ScalarBits returns the number of bits in the order of the group.
+func (c *Curve) ScalarBits() int {
+	return c.q.BitLen()
+}
+
+// SafeScalarBytes returns the number of random bytes needed to sample a scalar without bias.
+func (c *Curve) SafeScalarBytes() int {
+	return c.params.SafeScalarBytes
+}
+
+// Order returns the order of the group.
+func (c *Curve) Order() *saferith.Modulus {
+	return c.q
+}
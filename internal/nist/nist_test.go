@@ -0,0 +1,337 @@
+package nist_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/nist"
+	"github.com/cronokirby/kyokusen/p256"
+	"github.com/cronokirby/kyokusen/p384"
+	"github.com/cronokirby/kyokusen/p521"
+	"github.com/cronokirby/saferith"
+)
+
+// These tests run against every curve, checking our results against the standard library.
+
+type testCurve struct {
+	curve    kyokusen.Curve
+	newField func() *nist.Field
+	ecdh     ecdh.Curve
+	elliptic elliptic.Curve
+	// The expected sizes of a field element, and of a scalar.
+	fieldBytes  int
+	scalarBytes int
+}
+
+var testCurves = []testCurve{
+	{p256.Curve{}, p256.NewField, ecdh.P256(), elliptic.P256(), 32, 32},
+	{p384.Curve{}, p384.NewField, ecdh.P384(), elliptic.P384(), 48, 48},
+	{p521.Curve{}, p521.NewField, ecdh.P521(), elliptic.P521(), 66, 66},
+}
+
+// iterations is the number of random inputs each test tries, for each curve.
+const iterations = 10
+
+func forEachCurve(t *testing.T, f func(t *testing.T, tc testCurve, r *rand.Rand)) {
+	for _, tc := range testCurves {
+		tc := tc
+		t.Run(tc.curve.Name(), func(t *testing.T) {
+			f(t, tc, rand.New(rand.NewSource(0)))
+		})
+	}
+}
+
+func randomField(r *rand.Rand, tc testCurve) *nist.Field {
+	data := make([]byte, tc.fieldBytes)
+	z := tc.newField()
+	for {
+		r.Read(data)
+		// Clearing the top bit makes values at least p rare, but they can still happen.
+		data[0] &= 0x7F
+		if z.UnmarshalBinary(data) == nil {
+			return z
+		}
+	}
+}
+
+func randomScalar(r *rand.Rand, tc testCurve) kyokusen.Scalar {
+	data := make([]byte, tc.curve.SafeScalarBytes())
+	r.Read(data)
+	return tc.curve.NewScalar().SetNat(new(saferith.Nat).SetBytes(data))
+}
+
+func randomPoint(r *rand.Rand, tc testCurve) *nist.Point {
+	return randomScalar(r, tc).ActOnBase().(*nist.Point)
+}
+
+func TestFieldMultiplyInverse(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		one := tc.newField().SetUint64(1)
+		for i := 0; i < iterations; i++ {
+			a := randomField(r, tc)
+			if tc.newField().Set(a).Invert().Mul(a).Eq(one) != 1 {
+				t.Errorf("%v * %v^-1 != 1", a, a)
+			}
+		}
+	})
+}
+
+func TestFieldSqrtOfSquare(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		for i := 0; i < iterations; i++ {
+			square := randomField(r, tc).Square()
+			if square.HasSqrt() != 1 {
+				t.Fatalf("%v should have a square root", square)
+			}
+			if tc.newField().Set(square).Sqrt().Square().Eq(square) != 1 {
+				t.Errorf("sqrt(%v)^2 is incorrect", square)
+			}
+		}
+	})
+}
+
+func TestFieldMarshalRoundtrip(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		for i := 0; i < iterations; i++ {
+			a := randomField(r, tc)
+			data, _ := a.MarshalBinary()
+			if len(data) != tc.fieldBytes {
+				t.Fatalf("field element has %d bytes, expected %d", len(data), tc.fieldBytes)
+			}
+			decoded := tc.newField()
+			if decoded.UnmarshalBinary(data) != nil || decoded.Eq(a) != 1 {
+				t.Errorf("%x didn't roundtrip", data)
+			}
+		}
+	})
+}
+
+func TestFieldRejectsUnreduced(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		p := tc.elliptic.Params().P
+		if tc.newField().UnmarshalBinary(p.FillBytes(make([]byte, tc.fieldBytes))) == nil {
+			t.Error("p should be rejected as a field element")
+		}
+	})
+}
+
+func TestScalarMarshalRoundtrip(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		for i := 0; i < iterations; i++ {
+			a := randomScalar(r, tc)
+			data, _ := a.MarshalBinary()
+			if len(data) != tc.scalarBytes {
+				t.Fatalf("scalar has %d bytes, expected %d", len(data), tc.scalarBytes)
+			}
+			decoded := tc.curve.NewScalar()
+			if decoded.UnmarshalBinary(data) != nil || !decoded.Equal(a) {
+				t.Errorf("%x didn't roundtrip", data)
+			}
+		}
+	})
+}
+
+func TestScalarRejectsUnreduced(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		q := tc.elliptic.Params().N
+		if tc.curve.NewScalar().UnmarshalBinary(q.FillBytes(make([]byte, tc.scalarBytes))) == nil {
+			t.Error("q should be rejected as a scalar")
+		}
+	})
+}
+
+func TestSizes(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		bits := tc.elliptic.Params().N.BitLen()
+		if tc.curve.ScalarBits() != bits {
+			t.Errorf("ScalarBits is %d, expected %d", tc.curve.ScalarBits(), bits)
+		}
+		// We need at least 128 bits past the size of the order, to avoid bias.
+		if tc.curve.SafeScalarBytes() < (bits+7)/8+16 {
+			t.Errorf("SafeScalarBytes %d is too small", tc.curve.SafeScalarBytes())
+		}
+		G := tc.curve.NewBasePoint().(*nist.Point)
+		compressed, _ := G.MarshalBinary()
+		uncompressed, _ := G.MarshalUncompressed()
+		if len(compressed) != 1+tc.fieldBytes || len(uncompressed) != 1+2*tc.fieldBytes {
+			t.Errorf("incorrect encoding sizes %d and %d", len(compressed), len(uncompressed))
+		}
+	})
+}
+
+func TestPointMarshalRoundtrip(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		for i := 0; i < iterations; i++ {
+			a := randomPoint(r, tc)
+			compressed, _ := a.MarshalBinary()
+			uncompressed, _ := a.MarshalUncompressed()
+			for _, data := range [][]byte{compressed, uncompressed} {
+				decoded := tc.curve.NewPoint()
+				if decoded.UnmarshalBinary(data) != nil || !decoded.Equal(a) {
+					t.Errorf("%x didn't roundtrip", data)
+				}
+			}
+		}
+	})
+}
+
+func TestUnmarshalRejectsInvalidPoints(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		G := tc.curve.NewBasePoint().(*nist.Point)
+		uncompressed, _ := G.MarshalUncompressed()
+		// Changing the y coordinate moves the point off the curve.
+		uncompressed[len(uncompressed)-1] ^= 1
+		compressed, _ := G.MarshalBinary()
+		// The base point uses a valid prefix, so we use an invalid one instead.
+		compressed[0] = 4
+		for _, data := range [][]byte{uncompressed, compressed, {0}, nil} {
+			if tc.curve.NewPoint().UnmarshalBinary(data) == nil {
+				t.Errorf("%x should be rejected", data)
+			}
+		}
+		if _, err := tc.curve.NewPoint().MarshalBinary(); err == nil {
+			t.Error("the identity point shouldn't be marshalled")
+		}
+	})
+}
+
+func TestActOnBaseMatchesECDH(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		for i := 0; i < iterations; i++ {
+			s := randomScalar(r, tc)
+			secret, _ := s.MarshalBinary()
+			key, err := tc.ecdh.NewPrivateKey(secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			public, _ := s.ActOnBase().(*nist.Point).MarshalUncompressed()
+			if !bytes.Equal(public, key.PublicKey().Bytes()) {
+				t.Errorf("%x * G: got %x, expected %x", secret, public, key.PublicKey().Bytes())
+			}
+		}
+	})
+}
+
+func TestActMatchesECDH(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		for i := 0; i < iterations/2; i++ {
+			s := randomScalar(r, tc)
+			secret, _ := s.MarshalBinary()
+			key, _ := tc.ecdh.NewPrivateKey(secret)
+			public, _ := randomPoint(r, tc).MarshalUncompressed()
+			peer, err := tc.ecdh.NewPublicKey(public)
+			if err != nil {
+				t.Fatal(err)
+			}
+			shared, err := key.ECDH(peer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			P := tc.curve.NewPoint()
+			if err := P.UnmarshalBinary(public); err != nil {
+				t.Fatal(err)
+			}
+			actual, _ := s.Act(P).(*nist.Point).MarshalUncompressed()
+			if !bytes.Equal(shared, actual[1:1+tc.fieldBytes]) {
+				t.Errorf("%x * %x: got %x, expected x = %x", secret, public, actual, shared)
+			}
+		}
+	})
+}
+
+func TestEncodingsMatchElliptic(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		curve := tc.elliptic
+		for i := 0; i < iterations; i++ {
+			a, b := randomPoint(r, tc), randomPoint(r, tc)
+			aBytes, _ := a.MarshalUncompressed()
+			bBytes, _ := b.MarshalUncompressed()
+			ax, ay := elliptic.Unmarshal(curve, aBytes)
+			bx, by := elliptic.Unmarshal(curve, bBytes)
+			if ax == nil || bx == nil {
+				t.Fatal("the standard library rejected our encoding")
+			}
+			compressed, _ := a.MarshalBinary()
+			if !bytes.Equal(compressed, elliptic.MarshalCompressed(curve, ax, ay)) {
+				t.Errorf("compressed encoding %x doesn't match", compressed)
+			}
+			sx, sy := curve.Add(ax, ay, bx, by)
+			sum, _ := a.Add(b).(*nist.Point).MarshalUncompressed()
+			if !bytes.Equal(sum, elliptic.Marshal(curve, sx, sy)) {
+				t.Errorf("%x + %x: got %x", aBytes, bBytes, sum)
+			}
+		}
+	})
+}
+
+func TestXScalarMatchesElliptic(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		for i := 0; i < iterations; i++ {
+			a := randomPoint(r, tc)
+			data, _ := a.MarshalUncompressed()
+			x, _ := elliptic.Unmarshal(tc.elliptic, data)
+			x.Mod(x, tc.elliptic.Params().N)
+			xScalar, _ := a.XScalar().MarshalBinary()
+			if new(big.Int).SetBytes(xScalar).Cmp(x) != 0 {
+				t.Errorf("XScalar of %x is %x", data, xScalar)
+			}
+		}
+		if !tc.curve.NewPoint().(*nist.Point).XScalar().IsZero() {
+			t.Error("the x coordinate of the identity should be zero")
+		}
+	})
+}
+
+func TestScalarActOrderIsIdentity(t *testing.T) {
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		minusOne := tc.curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).Negate()
+		if !minusOne.ActOnBase().Add(tc.curve.NewBasePoint()).IsIdentity() {
+			t.Error("(q - 1) * G + G should be the identity")
+		}
+	})
+}
+
+func TestMultiScalarMulUsesFullScalar(t *testing.T) {
+	// For P-521, the top bits of the scalar live past the first 512, which catches
+	// anything assuming scalars have at most 64 bytes.
+	forEachCurve(t, func(t *testing.T, tc testCurve, r *rand.Rand) {
+		s := tc.curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).Negate()
+		P := tc.curve.NewBasePoint()
+		expected := P.Negate()
+		scalars := []kyokusen.Scalar{s}
+		points := []kyokusen.Point{P}
+		if !kyokusen.MultiScalarMul(scalars, points).Equal(expected) {
+			t.Error("MultiScalarMul gave an incorrect result")
+		}
+		if !kyokusen.VartimeMultiScalarMul(scalars, points).Equal(expected) {
+			t.Error("VartimeMultiScalarMul gave an incorrect result")
+		}
+	})
+}
+
+func TestMixingCurvesPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("adding points from different curves should panic")
+		}
+	}()
+	p256.Curve{}.NewBasePoint().Add(p384.Curve{}.NewBasePoint())
+}
+
+func BenchmarkActOnBase(b *testing.B) {
+	for _, tc := range testCurves {
+		tc := tc
+		b.Run(tc.curve.Name(), func(b *testing.B) {
+			s := randomScalar(rand.New(rand.NewSource(0)), tc)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.ActOnBase()
+			}
+		})
+	}
+}
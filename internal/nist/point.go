@@ -0,0 +1,275 @@
+package nist
+
+import (
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Point represents a point on one of the curves.
+type Point struct {
+	c *Curve
+	// Internally, we represent this as a projective point (X : Y : Z).
+	// This corresponds to the affine point (X / Z, Y / Z), except when Z = 0,
+	// which corresponds to the point at infinity.
+	x *Field
+	y *Field
+	z *Field
+	// This is a flag indicating that this Point's value is normalized. This
+	// should be set exclusively based on which methods are called on a point,
+	// making it okay to branch on this value.
+	normalized bool
+}
+
+// castPoint converts a point implementing the generic interface to this specific type.
+//
+// Since implementors of the Point interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+// Points from a different NIST curve share this type, so we check the curve as well.
+func (c *Curve) castPoint(p kyokusen.Point) *Point {
+	casted, ok := p.(*Point)
+	if !ok || casted.c != c {
+		panic(fmt.Sprintf("failed to cast type to *%s.Point", c.prefix))
+	}
+	return casted
+}
+
+func (p *Point) normalize() {
+	if p.normalized {
+		return
+	}
+	// If Z != 0, then we want to get (X/Z : Y/Z : 1)
+	// If Z == 0, then we want to get (0 : 1 : 0)
+	zZero := p.z.EqZero()
+	zInverse := p.c.NewField().Set(p.z).Invert()
+	one := p.c.NewField().SetUint64(1)
+	p.x.Mul(zInverse)
+	p.y.Mul(zInverse)
+	p.x.CondAssign(zZero, p.z)
+	p.y.CondAssign(zZero, one)
+	p.z.CondAssign(1^zZero, one)
+	p.normalized = true
+}
+
+// NewPoint returns the identity point.
+func (c *Curve) NewPoint() *Point {
+	// (0 : 1 : 0) is the point at infinity, in projective coordinates.
+	return &Point{
+		c: c,
+		x: c.NewField(),
+		y: c.NewField().SetUint64(1),
+		z: c.NewField(),
+	}
+}
+
+func (p *Point) String() string {
+	return fmt.Sprintf("[%v : %v : %v]", p.x, p.y, p.z)
+}
+
+// MarshalBinary marshals a point using the SEC1 compressed encoding.
+//
+// The point at infinity can't be marshalled.
+func (p *Point) MarshalBinary() ([]byte, error) {
+	p.normalize()
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("%s: can't marshal point at infinity", p.c.prefix)
+	}
+	xBytes, _ := p.x.MarshalBinary()
+	out := make([]byte, 0, 1+p.c.params.FieldBytes)
+	out = append(out, 3-byte(p.y.IsEven()))
+	out = append(out, xBytes...)
+	return out, nil
+}
+
+// MarshalUncompressed marshals a point using the SEC1 uncompressed encoding.
+//
+// The point at infinity can't be marshalled.
+func (p *Point) MarshalUncompressed() ([]byte, error) {
+	p.normalize()
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("%s: can't marshal point at infinity", p.c.prefix)
+	}
+	xBytes, _ := p.x.MarshalBinary()
+	yBytes, _ := p.y.MarshalBinary()
+	out := make([]byte, 0, 1+2*p.c.params.FieldBytes)
+	out = append(out, 4)
+	out = append(out, xBytes...)
+	out = append(out, yBytes...)
+	return out, nil
+}
+
+// rhs calculates x^3 - 3x + b.
+func (c *Curve) rhs(x *Field) *Field {
+	out := c.NewField().Set(x).Square().Mul(x)
+	threeX := c.NewField().Set(x).Add(x).Add(x)
+	return out.Sub(threeX).Add(c.b)
+}
+
+// UnmarshalBinary unmarshals a point from either of the SEC1 encodings.
+//
+// Both the compressed and uncompressed encodings are accepted, and the point
+// must lie on the curve.
+func (p *Point) UnmarshalBinary(data []byte) error {
+	c := p.c
+	fieldBytes := c.params.FieldBytes
+	switch {
+	case len(data) == 1+fieldBytes && (data[0] == 2 || data[0] == 3):
+		return p.setX(data[1:], saferith.Choice(data[0]&1)^1)
+	case len(data) == 1+2*fieldBytes && data[0] == 4:
+		x, y := c.NewField(), c.NewField()
+		if err := x.UnmarshalBinary(data[1 : 1+fieldBytes]); err != nil {
+			return err
+		}
+		if err := y.UnmarshalBinary(data[1+fieldBytes:]); err != nil {
+			return err
+		}
+		if c.NewField().Set(y).Square().Eq(c.rhs(x)) != 1 {
+			return fmt.Errorf("%s.UnmarshalBinary: invalid point", c.prefix)
+		}
+		p.x, p.y, p.z = x, y, c.NewField().SetUint64(1)
+		p.normalized = true
+		return nil
+	default:
+		return fmt.Errorf("%s.UnmarshalBinary: invalid data", c.prefix)
+	}
+}
+
+// setX sets this point to the one with a given x coordinate, and a y coordinate of a given parity.
+//
+// This will return an error if x isn't a valid field element, or doesn't correspond
+// to a point on the curve.
+func (p *Point) setX(xData []byte, yShouldBeEven saferith.Choice) error {
+	x := p.c.NewField()
+	if err := x.UnmarshalBinary(xData); err != nil {
+		return err
+	}
+	y := p.c.rhs(x)
+	if y.HasSqrt() != 1 {
+		return fmt.Errorf("%s.UnmarshalBinary: invalid point", p.c.prefix)
+	}
+	y.Sqrt()
+	y.CondNegate(y.IsEven() ^ yShouldBeEven)
+	p.x, p.y, p.z = x, y, p.c.NewField().SetUint64(1)
+	p.normalized = true
+	return nil
+}
+
+func (p *Point) Curve() kyokusen.Curve {
+	return p.c.params.Curve
+}
+
+func (p1 *Point) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := p1.c.castPoint(other)
+
+	// This formula is taken from Algorithm 4 of https://eprint.iacr.org/2015/1060,
+	// which is complete for curves with a = -3.
+	c := p1.c
+	t0 := c.NewField().Set(p1.x).Mul(p2.x)
+	t1 := c.NewField().Set(p1.y).Mul(p2.y)
+	t2 := c.NewField().Set(p1.z).Mul(p2.z)
+
+	t3 := c.NewField().Set(p1.x).Add(p1.y)
+	t4 := c.NewField().Set(p2.x).Add(p2.y)
+	t3.Mul(t4)
+
+	t4.Set(t0).Add(t1)
+	t3.Sub(t4)
+	t4.Set(p1.y).Add(p1.z)
+
+	x := c.NewField().Set(p2.y).Add(p2.z)
+	t4.Mul(x)
+	x.Set(t1).Add(t2)
+
+	t4.Sub(x)
+	x.Set(p1.x).Add(p1.z)
+	y := c.NewField().Set(p2.x).Add(p2.z)
+
+	x.Mul(y)
+	y.Set(t0).Add(t2)
+	y.Negate().Add(x)
+
+	z := c.NewField().Set(c.b).Mul(t2)
+	x.Set(y).Sub(z)
+	z.Set(x).Add(x)
+
+	x.Add(z)
+	z.Set(t1).Sub(x)
+	x.Add(t1)
+
+	y.Mul(c.b)
+	t1.Set(t2).Add(t2)
+	t2.Add(t1)
+
+	y.Sub(t2)
+	y.Sub(t0)
+	t1.Set(y).Add(y)
+
+	y.Add(t1)
+	t1.Set(t0).Add(t0)
+	t0.Add(t1)
+
+	t0.Sub(t2)
+	t1.Set(t4).Mul(y)
+	t2.Set(t0).Mul(y)
+
+	y.Set(x).Mul(z)
+	y.Add(t2)
+	x.Mul(t3)
+
+	x.Sub(t1)
+	z.Mul(t4)
+	t1.Set(t3).Mul(t0)
+
+	z.Add(t1)
+
+	return &Point{p1.c, x, y, z, false}
+}
+
+func (p *Point) Sub(other kyokusen.Point) kyokusen.Point {
+	return p.Add(other.Negate())
+}
+
+func (p *Point) Negate() kyokusen.Point {
+	return &Point{
+		c: p.c,
+		x: p.c.NewField().Set(p.x),
+		y: p.c.NewField().Set(p.y).Negate(),
+		z: p.c.NewField().Set(p.z),
+	}
+}
+
+func (p1 *Point) Equal(other kyokusen.Point) bool {
+	p2 := p1.c.castPoint(other)
+	p1.normalize()
+	p2.normalize()
+	return (p1.x.Eq(p2.x) & p1.y.Eq(p2.y) & p1.z.Eq(p2.z)) == 1
+}
+
+func (p *Point) IsIdentity() bool {
+	// Whenever Z == 0, this is the point at infinity.
+	return p.z.EqZero() == 1
+}
+
+// XScalar returns the affine x coordinate of this point, reduced modulo the order of the group.
+//
+// For the identity point, this returns 0.
+func (p *Point) XScalar() kyokusen.Scalar {
+	p.normalize()
+	return p.c.NewScalar().SetNat(&p.x.nat)
+}
+
+// CondAssign conditionally modifies the contents of a point.
+func (p *Point) CondAssign(yes saferith.Choice, other *Point) *Point {
+	p.x.CondAssign(yes, other.x)
+	p.y.CondAssign(yes, other.y)
+	p.z.CondAssign(yes, other.z)
+	return p
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	return p.c.NewPoint().CondAssign(1, p).CondAssign(yes, p.c.castPoint(other))
+}
@@ -0,0 +1,155 @@
+package nist
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Scalar represents an integer modulo the order of one of the curves.
+type Scalar struct {
+	c   *Curve
+	nat saferith.Nat
+}
+
+func (s *Scalar) String() string {
+	return s.nat.String()
+}
+
+// NewScalar creates a new scalar, with its value set to 0.
+func (c *Curve) NewScalar() *Scalar {
+	s := &Scalar{c: c}
+	s.nat.Mod(&s.nat, c.q)
+	return s
+}
+
+// castScalar converts a scalar implementing the generic interface to this specific type.
+//
+// Since implementors of the Scalar interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+// Scalars from a different NIST curve share this type, so we check the curve as well.
+func (c *Curve) castScalar(s kyokusen.Scalar) *Scalar {
+	casted, ok := s.(*Scalar)
+	if !ok || casted.c != c {
+		panic(fmt.Sprintf("failed to cast type to *%s.Scalar", c.prefix))
+	}
+	return casted
+}
+
+// MarshalBinary returns the contents of this scalar as Big Endian bytes.
+func (s *Scalar) MarshalBinary() ([]byte, error) {
+	// This makes sure that the size of the scalar is padded to the right size.
+	s.nat.Mod(&s.nat, s.c.q)
+	return s.nat.Bytes(), nil
+}
+
+// UnmarshalBinary deserializes Big Endian bytes into this scalar.
+func (s *Scalar) UnmarshalBinary(data []byte) error {
+	if len(data) != s.c.params.ScalarBytes {
+		return fmt.Errorf("%s.Scalar.UnmarshalBinary: invalid data length", s.c.prefix)
+	}
+	s.nat.SetBytes(data)
+	if _, _, lt := s.nat.CmpMod(s.c.q); lt != 1 {
+		return fmt.Errorf("%s.Scalar.UnmarshalBinary: value is greater than order", s.c.prefix)
+	}
+	return nil
+}
+
+// Curve returns the curve associated with this scalar field.
+func (s *Scalar) Curve() kyokusen.Curve {
+	return s.c.params.Curve
+}
+
+func (s1 *Scalar) Add(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.ModAdd(&s1.nat, &s2.nat, s1.c.q)
+	return s1
+}
+
+func (s1 *Scalar) Sub(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.ModSub(&s1.nat, &s2.nat, s1.c.q)
+	return s1
+}
+
+func (s1 *Scalar) Negate() kyokusen.Scalar {
+	s1.nat.ModNeg(&s1.nat, s1.c.q)
+	return s1
+}
+
+func (s1 *Scalar) Mul(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.ModMul(&s1.nat, &s2.nat, s1.c.q)
+	return s1
+}
+
+func (s1 *Scalar) Invert() kyokusen.Scalar {
+	s1.nat.ModInverse(&s1.nat, s1.c.q)
+	return s1
+}
+
+func (s1 *Scalar) Equal(other kyokusen.Scalar) bool {
+	s2 := s1.c.castScalar(other)
+	return s1.nat.Eq(&s2.nat) == 1
+}
+
+func (s1 *Scalar) IsZero() bool {
+	return s1.nat.EqZero() == 1
+}
+
+func (s1 *Scalar) Set(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.SetNat(&s2.nat)
+	return s1
+}
+
+func (s1 *Scalar) SetNat(other *saferith.Nat) kyokusen.Scalar {
+	s1.nat.Mod(other, s1.c.q)
+	return s1
+}
+
+// window is the number of bits of the scalar we process at once when multiplying points.
+const window = 4
+
+// nibble returns the i-th group of 4 bits in a Big Endian number, starting from the least significant bits.
+func nibble(bytes []byte, i int) int32 {
+	b := bytes[len(bytes)-1-i/2]
+	return int32((b >> (4 * (i % 2))) & 0xF)
+}
+
+// Act calculates s * P, in constant-time.
+//
+// This processes the scalar 4 bits at a time, selecting from a table of small
+// multiples of P, reading every entry of the table each time.
+func (s *Scalar) Act(other kyokusen.Point) kyokusen.Point {
+	P := s.c.castPoint(other)
+	bytes, _ := s.MarshalBinary()
+
+	// table[j] = j * P
+	var table [1 << window]*Point
+	table[0] = s.c.NewPoint()
+	for j := 1; j < len(table); j++ {
+		table[j] = table[j-1].Add(P).(*Point)
+	}
+
+	acc := s.c.NewPoint()
+	selected := s.c.NewPoint()
+	for i := 2*len(bytes) - 1; i >= 0; i-- {
+		for j := 0; j < window; j++ {
+			acc = acc.Add(acc).(*Point)
+		}
+		d := nibble(bytes, i)
+		for j, entry := range table {
+			selected.CondAssign(saferith.Choice(subtle.ConstantTimeEq(d, int32(j))), entry)
+		}
+		acc = acc.Add(selected).(*Point)
+	}
+	return acc
+}
+
+// ActOnBase calculates s * G, where G is the generator of the group.
+func (s *Scalar) ActOnBase() kyokusen.Point {
+	return s.Act(s.c.basePoint)
+}
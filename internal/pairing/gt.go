@@ -0,0 +1,138 @@
+package pairing
+
+import (
+	"fmt"
+
+	"github.com/cronokirby/saferith"
+)
+
+// Fp12 describes the arithmetic needed from the extension of degree 12 containing GT.
+//
+// Like Element, E is the pointer type *T of the elements.
+type Fp12[T, E any] interface {
+	*T
+	Set(x E) E
+	Mul(a E) E
+	Conjugate() E
+	Invert() E
+	// Exp sets z <- z^e, returning z, where e is given as Big Endian bytes, in constant-time.
+	Exp(e []byte) E
+	Eq(x E) saferith.Choice
+	// Bytes encodes an element, writing its coefficients from the highest to the lowest.
+	Bytes() []byte
+	// SetBytes decodes an element, returning an error if any coefficient isn't canonical.
+	SetBytes(data []byte) error
+}
+
+// Scalar is the type of the exponents accepted by GT.Exp.
+type Scalar interface {
+	// MarshalBinary encodes a scalar as Big Endian bytes.
+	MarshalBinary() ([]byte, error)
+}
+
+// GTParams holds the constants of one target group.
+//
+// The implementations carry no data, so that each group is a distinct type,
+// without storing the constants in every element.
+type GTParams[T any] interface {
+	// One returns the identity element.
+	One() T
+	// Order returns the order r of the group, as Big Endian bytes.
+	Order() []byte
+	// Prefix returns the name of the package, to be used in error messages.
+	Prefix() string
+}
+
+// GT represents an element of the target group of a pairing.
+//
+// This is the subgroup of order r of the multiplicative group of Fp12.
+type GT[T any, E Fp12[T, E], S Scalar, P GTParams[T]] struct {
+	inner T
+}
+
+// NewGT returns the identity element of GT, 1.
+func NewGT[T any, E Fp12[T, E], S Scalar, P GTParams[T]]() *GT[T, E, S, P] {
+	var params P
+	return &GT[T, E, S, P]{inner: params.One()}
+}
+
+// SetFp12 sets z <- x, returning z.
+//
+// The caller is responsible for making sure that x lies in GT.
+func (z *GT[T, E, S, P]) SetFp12(x E) *GT[T, E, S, P] {
+	E(&z.inner).Set(x)
+	return z
+}
+
+// Set sets z <- a, returning z.
+func (z *GT[T, E, S, P]) Set(a *GT[T, E, S, P]) *GT[T, E, S, P] {
+	*z = *a
+	return z
+}
+
+// Mul sets z <- z * a, returning z.
+func (z *GT[T, E, S, P]) Mul(a *GT[T, E, S, P]) *GT[T, E, S, P] {
+	E(&z.inner).Mul(&a.inner)
+	return z
+}
+
+// Invert sets z <- z^-1, returning z.
+func (z *GT[T, E, S, P]) Invert() *GT[T, E, S, P] {
+	// Elements of GT lie in the cyclotomic subgroup, where inversion is conjugation.
+	E(&z.inner).Conjugate()
+	return z
+}
+
+// Exp sets z <- z^s, returning z, in constant-time.
+func (z *GT[T, E, S, P]) Exp(s S) *GT[T, E, S, P] {
+	bytes, _ := s.MarshalBinary()
+	E(&z.inner).Exp(bytes)
+	return z
+}
+
+// Equal checks if z = a, in constant-time.
+func (z *GT[T, E, S, P]) Equal(a *GT[T, E, S, P]) bool {
+	return E(&z.inner).Eq(&a.inner) == 1
+}
+
+// IsOne checks if z is the identity element of GT.
+func (z *GT[T, E, S, P]) IsOne() bool {
+	var params P
+	one := params.One()
+	return E(&z.inner).Eq(&one) == 1
+}
+
+// MarshalBinary encodes this element, as its 12 coefficients over the base field.
+//
+// The coefficients of the tower are written from the highest to the lowest,
+// each taking as many Big Endian bytes as an element of the base field.
+func (z *GT[T, E, S, P]) MarshalBinary() ([]byte, error) {
+	return E(&z.inner).Bytes(), nil
+}
+
+// UnmarshalBinary decodes an element, returning an error if it doesn't lie in GT.
+func (z *GT[T, E, S, P]) UnmarshalBinary(data []byte) error {
+	var params P
+	var out T
+	if err := E(&out).SetBytes(data); err != nil {
+		return fmt.Errorf("%s.GT.UnmarshalBinary: %w", params.Prefix(), err)
+	}
+	var check T
+	E(&check).Set(&out).Exp(params.Order())
+	one := params.One()
+	if E(&check).Eq(&one) != 1 {
+		return fmt.Errorf("%s.GT.UnmarshalBinary: element is not in GT", params.Prefix())
+	}
+	z.inner = out
+	return nil
+}
+
+// EasyPart sets f <- f^(p^6 - 1), returning f.
+//
+// This is the first part of the final exponentiation of a pairing over Fp12,
+// which sends f into the cyclotomic subgroup, where inversion is conjugation.
+func EasyPart[T any, E Fp12[T, E]](f E) E {
+	var inv T
+	E(&inv).Set(f).Invert()
+	return f.Conjugate().Mul(&inv)
+}
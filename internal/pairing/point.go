@@ -0,0 +1,172 @@
+// Package pairing implements the arithmetic shared by the pairing friendly curves.
+//
+// Both BLS12-381 and BN254 have groups G1 and G2 on curves y^2 = x^3 + b, over
+// a base field and a quadratic extension of it, and a target group GT inside
+// an extension of degree 12. The types here are generic over these fields, and
+// the bls12381 and bn254 packages instantiate them with their own.
+//
+// This package isn't intended to be used directly.
+package pairing
+
+import (
+	"crypto/subtle"
+
+	"github.com/cronokirby/saferith"
+)
+
+// Element describes the arithmetic needed from the field containing the coordinates of a point.
+//
+// E is the pointer type *T of the elements, as a separate parameter so that the
+// methods can refer to it. Every method modifies its receiver, and returns it,
+// except for Eq and EqZero. Invert should send 0 to 0.
+type Element[T, E any] interface {
+	*T
+	Set(x E) E
+	Add(a E) E
+	Sub(a E) E
+	Mul(a E) E
+	Negate() E
+	Invert() E
+	CondAssign(yes saferith.Choice, x E) E
+	Eq(x E) saferith.Choice
+	EqZero() saferith.Choice
+}
+
+// Curve holds the constants of a curve y^2 = x^3 + b, needed to operate on its points.
+type Curve[T any, E Element[T, E]] struct {
+	// B3 is 3 * b, which appears in the addition formula.
+	B3 T
+	// One is the element 1 of the field.
+	One T
+}
+
+// Point is a point on a curve y^2 = x^3 + b.
+//
+// This is a projective point (X : Y : Z), corresponding to the affine point
+// (X / Z, Y / Z), or to the point at infinity when Z = 0.
+type Point[T any, E Element[T, E]] struct {
+	X, Y, Z T
+}
+
+// Identity returns the point at infinity, (0 : 1 : 0).
+func (c *Curve[T, E]) Identity() Point[T, E] {
+	return Point[T, E]{Y: c.One}
+}
+
+// Affine returns the point (x : y : 1).
+func (c *Curve[T, E]) Affine(x, y *T) Point[T, E] {
+	return Point[T, E]{X: *x, Y: *y, Z: c.One}
+}
+
+// Add sets p <- a + b, returning p.
+//
+// This uses the complete addition formula for curves with a = 0, from algorithm 7
+// of https://eprint.iacr.org/2015/1060. This works for any inputs, including
+// the identity, or a = b, and runs in constant-time.
+func (c *Curve[T, E]) Add(p, a, b *Point[T, E]) *Point[T, E] {
+	var t0, t1, t2, t3, t4, x3, y3, z3, tmp T
+	b3 := E(&c.B3)
+	E(&t0).Set(&a.X).Mul(&b.X)
+	E(&t1).Set(&a.Y).Mul(&b.Y)
+	E(&t2).Set(&a.Z).Mul(&b.Z)
+	E(&t3).Set(&a.X).Add(&a.Y)
+	E(&t4).Set(&b.X).Add(&b.Y)
+	E(&t3).Mul(&t4)
+	E(&t4).Set(&t0).Add(&t1)
+	E(&t3).Sub(&t4)
+	E(&t4).Set(&a.Y).Add(&a.Z)
+	E(&x3).Set(&b.Y).Add(&b.Z)
+	E(&t4).Mul(&x3)
+	E(&x3).Set(&t1).Add(&t2)
+	E(&t4).Sub(&x3)
+	E(&x3).Set(&a.X).Add(&a.Z)
+	E(&y3).Set(&b.X).Add(&b.Z)
+	E(&x3).Mul(&y3)
+	E(&y3).Set(&t0).Add(&t2)
+	E(&y3).Set(E(&tmp).Set(&x3).Sub(&y3))
+	E(&x3).Set(&t0).Add(&t0)
+	E(&t0).Add(&x3)
+	E(&t2).Mul(b3)
+	E(&z3).Set(&t1).Add(&t2)
+	E(&t1).Sub(&t2)
+	E(&y3).Mul(b3)
+	E(&x3).Set(&t4).Mul(&y3)
+	E(&t2).Set(&t3).Mul(&t1)
+	E(&x3).Set(E(&tmp).Set(&t2).Sub(&x3))
+	E(&y3).Mul(&t0)
+	E(&t1).Mul(&z3)
+	E(&y3).Add(&t1)
+	E(&t0).Mul(&t3)
+	E(&z3).Mul(&t4)
+	E(&z3).Add(&t0)
+	p.X, p.Y, p.Z = x3, y3, z3
+	return p
+}
+
+// window is the number of bits of the scalar processed at once by Mul.
+const window = 4
+
+// Mul calculates k * p, where k is given as Big Endian bytes, in constant-time.
+//
+// Each step adds a multiple of p from a table of 16 entries, selected by
+// scanning through the whole table, so that the memory access pattern doesn't
+// depend on k.
+func (c *Curve[T, E]) Mul(p *Point[T, E], k []byte) Point[T, E] {
+	var table [1 << window]Point[T, E]
+	table[0] = c.Identity()
+	for j := 1; j < len(table); j++ {
+		c.Add(&table[j], &table[j-1], p)
+	}
+	acc := c.Identity()
+	var selected Point[T, E]
+	for i := 0; i < 2*len(k); i++ {
+		for j := 0; j < window; j++ {
+			c.Add(&acc, &acc, &acc)
+		}
+		d := int32((k[i/2] >> (window * (1 - i%2))) & 0xF)
+		for j := range table {
+			selected.CondAssign(saferith.Choice(subtle.ConstantTimeEq(d, int32(j))), &table[j])
+		}
+		c.Add(&acc, &acc, &selected)
+	}
+	return acc
+}
+
+// ToAffine returns the affine coordinates of p, or (0, 0) for the identity.
+func (p *Point[T, E]) ToAffine() (x, y T) {
+	var zInv T
+	E(&zInv).Set(&p.Z).Invert()
+	E(&x).Set(&p.X).Mul(&zInv)
+	E(&y).Set(&p.Y).Mul(&zInv)
+	return x, y
+}
+
+// Negate sets p <- -p, returning p.
+func (p *Point[T, E]) Negate() *Point[T, E] {
+	E(&p.Y).Negate()
+	return p
+}
+
+// Equal checks if p and q represent the same point, in constant-time.
+func (p *Point[T, E]) Equal(q *Point[T, E]) saferith.Choice {
+	// X1 / Z1 = X2 / Z2 is the same as X1 Z2 = X2 Z1, and similarly for Y.
+	var x1, x2, y1, y2 T
+	E(&x1).Set(&p.X).Mul(&q.Z)
+	E(&x2).Set(&q.X).Mul(&p.Z)
+	E(&y1).Set(&p.Y).Mul(&q.Z)
+	E(&y2).Set(&q.Y).Mul(&p.Z)
+	return E(&x1).Eq(&x2) & E(&y1).Eq(&y2)
+}
+
+// IsIdentity checks if p is the point at infinity, in constant-time.
+func (p *Point[T, E]) IsIdentity() saferith.Choice {
+	return E(&p.Z).EqZero()
+}
+
+// CondAssign sets p <- q, only if yes = 1, in constant-time.
+func (p *Point[T, E]) CondAssign(yes saferith.Choice, q *Point[T, E]) *Point[T, E] {
+	E(&p.X).CondAssign(yes, &q.X)
+	E(&p.Y).CondAssign(yes, &q.Y)
+	E(&p.Z).CondAssign(yes, &q.Z)
+	return p
+}
@@ -0,0 +1,118 @@
+// Package pasta implements the arithmetic shared by the Pallas and Vesta curves.
+//
+// The two curves form a cycle: the base field of each one is the scalar field
+// of the other. Both have the equation y^2 = x^3 + 5, and the generator (-1, 2),
+// so the only differences are which field plays which role, and the constants
+// used to hash to each curve. The point and scalar types are generic over
+// the pair of fields, and the pallas and vesta packages wrap one instantiation each.
+//
+// This package isn't intended to be used directly.
+package pasta
+
+import (
+	"strings"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/rfc9380"
+	"github.com/cronokirby/saferith"
+)
+
+// Curve holds the constants for one of the two curves.
+//
+// B selects the base field of the curve, and S its scalar field.
+type Curve[B, S fieldParams] struct {
+	// curve is the value returned by the Curve method of points and scalars.
+	curve kyokusen.Curve
+	name  string
+	// prefix is used in error messages, and matches the name of the wrapping package.
+	prefix string
+	// b is the constant term in the equation of the curve, y^2 = x^3 + b.
+	b *Field[B]
+	// b3 is 3 * b, which appears in the addition formula.
+	b3        *Field[B]
+	basePoint *Point[B, S]
+	iso       *isogeny[B]
+	suite     *rfc9380.Suite
+}
+
+// PallasCurve is the curve whose base field is Fp, and scalar field is Fq.
+type PallasCurve = Curve[fp, fq]
+
+// PallasPoint represents a point on the Pallas curve.
+type PallasPoint = Point[fp, fq]
+
+// PallasScalar represents an integer modulo the order of the Pallas group.
+type PallasScalar = Scalar[fp, fq]
+
+// VestaCurve is the curve whose base field is Fq, and scalar field is Fp.
+type VestaCurve = Curve[fq, fp]
+
+// VestaPoint represents a point on the Vesta curve.
+type VestaPoint = Point[fq, fp]
+
+// VestaScalar represents an integer modulo the order of the Vesta group.
+type VestaScalar = Scalar[fq, fp]
+
+// NewPallas creates the Pallas curve, with its points and scalars reporting curve as their curve.
+func NewPallas(curve kyokusen.Curve) *PallasCurve {
+	return newCurve[fp, fq](curve, "Pallas", &pallasIsoParams)
+}
+
+// NewVesta creates the Vesta curve, with its points and scalars reporting curve as their curve.
+func NewVesta(curve kyokusen.Curve) *VestaCurve {
+	return newCurve[fq, fp](curve, "Vesta", &vestaIsoParams)
+}
+
+func newCurve[B, S fieldParams](curve kyokusen.Curve, name string, iso *isoParams) *Curve[B, S] {
+	c := &Curve[B, S]{
+		curve:  curve,
+		name:   name,
+		prefix: strings.ToLower(name),
+		b:      newField[B]().SetUint64(5),
+		b3:     newField[B]().SetUint64(15),
+	}
+	c.basePoint = &Point[B, S]{
+		c:          c,
+		x:          newField[B]().SetUint64(1).Negate(),
+		y:          newField[B]().SetUint64(2),
+		z:          newField[B]().SetUint64(1),
+		normalized: true,
+	}
+	c.iso = newIsogeny[B](iso)
+	c.suite = c.newSuite()
+	return c
+}
+
+// NewField creates a new element of the base field, with its value set to 0.
+func (c *Curve[B, S]) NewField() *Field[B] {
+	return newField[B]()
+}
+
+// NewBasePoint returns a copy of the generator of the group.
+//
+// We return a copy, so that users can't modify the shared base point.
+func (c *Curve[B, S]) NewBasePoint() *Point[B, S] {
+	return c.NewPoint().CondAssign(1, c.basePoint)
+}
+
+// Name returns the name of this curve.
+func (c *Curve[B, S]) Name() string {
+	return c.name
+}
+
+// ScalarBits returns the number of bits in the order of the group.
+func (c *Curve[B, S]) ScalarBits() int {
+	return c.Order().BitLen()
+}
+
+// SafeScalarBytes returns the number of random bytes needed to sample a scalar without bias.
+func (c *Curve[B, S]) SafeScalarBytes() int {
+	// Both orders are close to 2^254, so we need extra bytes to avoid bias.
+	return 64
+}
+
+// Order returns the order of the group, which is the modulus of the other field.
+func (c *Curve[B, S]) Order() *saferith.Modulus {
+	var s S
+	return s.modulus()
+}
@@ -0,0 +1,224 @@
+package pasta
+
+import (
+	"fmt"
+
+	"github.com/cronokirby/saferith"
+)
+
+// FieldBytes is the number of bytes in the encoding of an element of either field.
+const FieldBytes = 32
+
+// FpModulus is the prime p, the base field of Pallas, and the order of Vesta.
+//
+// This is initialized directly, rather than in an init function, so that it's
+// available to the init functions of every other package.
+var FpModulus, _ = saferith.ModulusFromHex("40000000000000000000000000000000224698FC094CF91B992D30ED00000001")
+
+// FqModulus is the prime q, the base field of Vesta, and the order of Pallas.
+//
+// Like FpModulus, this is initialized directly.
+var FqModulus, _ = saferith.ModulusFromHex("40000000000000000000000000000000224698FC0994A8DD8C46EB2100000001")
+
+// fieldParams selects one of the two fields, as a type parameter.
+//
+// The implementations carry no data, so that each field is a distinct type,
+// without storing the modulus in every element.
+type fieldParams interface {
+	modulus() *saferith.Modulus
+	name() string
+}
+
+type fp struct{}
+
+func (fp) modulus() *saferith.Modulus {
+	return FpModulus
+}
+
+func (fp) name() string {
+	return "Fp"
+}
+
+type fq struct{}
+
+func (fq) modulus() *saferith.Modulus {
+	return FqModulus
+}
+
+func (fq) name() string {
+	return "Fq"
+}
+
+// Field represents an element of one of the two fields, selected by P.
+type Field[P fieldParams] struct {
+	nat saferith.Nat
+}
+
+// Fp represents an integer modulo p.
+type Fp = Field[fp]
+
+// Fq represents an integer modulo q.
+type Fq = Field[fq]
+
+// newField creates a new field element, with its value set to 0.
+func newField[P fieldParams]() *Field[P] {
+	var p P
+	z := new(Field[P])
+	// This will conveniently set the announced size, and mark this number as reduced.
+	z.nat.Mod(&z.nat, p.modulus())
+	return z
+}
+
+// NewFp creates a new element of Fp, with its value set to 0.
+func NewFp() *Fp {
+	return newField[fp]()
+}
+
+// NewFq creates a new element of Fq, with its value set to 0.
+func NewFq() *Fq {
+	return newField[fq]()
+}
+
+// fieldFromHex creates a new field element from a Big Endian hex string, panicking on failure.
+func fieldFromHex[P fieldParams](hex string) *Field[P] {
+	nat, err := new(saferith.Nat).SetHex(hex)
+	if err != nil {
+		panic(err)
+	}
+	return newField[P]().SetNat(nat)
+}
+
+func (z *Field[P]) modulus() *saferith.Modulus {
+	var p P
+	return p.modulus()
+}
+
+// Set calculates z <- x, returning z.
+func (z *Field[P]) Set(x *Field[P]) *Field[P] {
+	z.nat.SetNat(&x.nat)
+	return z
+}
+
+// SetUint64 calculates z <- x, returning z.
+func (z *Field[P]) SetUint64(x uint64) *Field[P] {
+	z.nat.SetUint64(x)
+	z.nat.Mod(&z.nat, z.modulus())
+	return z
+}
+
+// SetNat calculates z <- x mod the modulus, returning z.
+func (z *Field[P]) SetNat(x *saferith.Nat) *Field[P] {
+	z.nat.Mod(x, z.modulus())
+	return z
+}
+
+// Nat returns a copy of the value of z, as a number less than the modulus.
+func (z *Field[P]) Nat() *saferith.Nat {
+	return new(saferith.Nat).SetNat(&z.nat)
+}
+
+// CondAssign sets z <- x, only if yes = 1, in constant-time.
+func (z *Field[P]) CondAssign(yes saferith.Choice, x *Field[P]) *Field[P] {
+	z.nat.CondAssign(yes, &x.nat)
+	return z
+}
+
+// CondNegate sets z <- -z, only if yes = 1, in constant-time.
+func (z *Field[P]) CondNegate(yes saferith.Choice) *Field[P] {
+	negated := newField[P]().Set(z).Negate()
+	return z.CondAssign(yes, negated)
+}
+
+// String returns a string representation of this field element.
+func (z *Field[P]) String() string {
+	return z.nat.String()
+}
+
+// Add calculates z <- z + a, returning z.
+func (z *Field[P]) Add(a *Field[P]) *Field[P] {
+	z.nat.ModAdd(&z.nat, &a.nat, z.modulus())
+	return z
+}
+
+// Sub calculates z <- z - a, returning z.
+func (z *Field[P]) Sub(a *Field[P]) *Field[P] {
+	z.nat.ModSub(&z.nat, &a.nat, z.modulus())
+	return z
+}
+
+// Negate calculates z <- -z, returning z.
+func (z *Field[P]) Negate() *Field[P] {
+	z.nat.ModNeg(&z.nat, z.modulus())
+	return z
+}
+
+// Mul calculates z <- z * a, returning z.
+func (z *Field[P]) Mul(a *Field[P]) *Field[P] {
+	z.nat.ModMul(&z.nat, &a.nat, z.modulus())
+	return z
+}
+
+// Square calculates z <- z * z, returning z.
+func (z *Field[P]) Square() *Field[P] {
+	return z.Mul(z)
+}
+
+// Invert calculates z <- z^-1, returning z.
+//
+// If z = 0, the result is 0.
+func (z *Field[P]) Invert() *Field[P] {
+	z.nat.ModInverse(&z.nat, z.modulus())
+	return z
+}
+
+// Sqrt calculates z <- sqrt(z), returning a choice indicating if z was actually square.
+//
+// Since p - 1 and q - 1 are both divisible by 2^32, this uses Tonelli-Shanks, in constant-time.
+// If z isn't square, then z is left unmodified.
+func (z *Field[P]) Sqrt() saferith.Choice {
+	root := newField[P]()
+	root.nat.ModSqrt(&z.nat, z.modulus())
+	wasSquare := newField[P]().Set(root).Square().Eq(z)
+	z.CondAssign(wasSquare, root)
+	return wasSquare
+}
+
+// Eq checks if two field values are equal, in constant-time.
+func (z *Field[P]) Eq(x *Field[P]) saferith.Choice {
+	return z.nat.Eq(&x.nat)
+}
+
+// EqZero checks if a field value is equal to 0, in constant-time.
+func (z *Field[P]) EqZero() saferith.Choice {
+	return z.nat.EqZero()
+}
+
+// IsOdd returns a choice indicating if a field element is odd.
+func (z *Field[P]) IsOdd() saferith.Choice {
+	return saferith.Choice(z.nat.Byte(0) & 1)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// This encodes the field element as 32 Big Endian bytes.
+func (z *Field[P]) MarshalBinary() ([]byte, error) {
+	return z.nat.FillBytes(make([]byte, FieldBytes)), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// This expects exactly 32 Big Endian bytes, and will also return an error if the
+// resulting value is >= the modulus.
+func (z *Field[P]) UnmarshalBinary(data []byte) error {
+	var p P
+	if len(data) != FieldBytes {
+		return fmt.Errorf("pasta.%s.UnmarshalBinary: invalid data length", p.name())
+	}
+	var nat saferith.Nat
+	nat.SetBytes(data)
+	if _, _, lt := nat.CmpMod(p.modulus()); lt != 1 {
+		return fmt.Errorf("pasta.%s.UnmarshalBinary: value is greater than field prime", p.name())
+	}
+	z.nat.Mod(&nat, p.modulus())
+	return nil
+}
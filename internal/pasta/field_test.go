@@ -0,0 +1,62 @@
+package pasta
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/cronokirby/saferith"
+)
+
+func randomField[P fieldParams](r *rand.Rand) *Field[P] {
+	data := make([]byte, FieldBytes)
+	r.Read(data)
+	return newField[P]().SetNat(new(saferith.Nat).SetBytes(data))
+}
+
+func testSqrtOfSquare[P fieldParams](t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		square := randomField[P](r).Square()
+		root := newField[P]().Set(square)
+		if root.Sqrt() != 1 || root.Square().Eq(square) != 1 {
+			t.Fatalf("sqrt(%v) is incorrect", square)
+		}
+	}
+}
+
+func TestFpSqrtOfSquare(t *testing.T) {
+	testSqrtOfSquare[fp](t)
+}
+
+func TestFqSqrtOfSquare(t *testing.T) {
+	testSqrtOfSquare[fq](t)
+}
+
+func TestSqrtOfNonSquare(t *testing.T) {
+	// 5 isn't a square in either field, which is why neither curve has a point with x = 0.
+	for _, z := range []interface{ Sqrt() saferith.Choice }{NewFp().SetUint64(5), NewFq().SetUint64(5)} {
+		if z.Sqrt() == 1 {
+			t.Error("5 shouldn't have a square root")
+		}
+	}
+}
+
+func TestMapToCurveExceptionalCase(t *testing.T) {
+	// u = 0 makes the denominator of the simplified SWU map vanish.
+	pallas := NewPallas(nil)
+	P := pallas.mapToCurve(pallas.NewField())
+	data, err := P.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := pallas.NewPoint()
+	if decoded.UnmarshalBinary(data) != nil || !decoded.Equal(P) {
+		t.Error("mapping 0 to Pallas should produce a valid point")
+	}
+	vesta := NewVesta(nil)
+	Q := vesta.mapToCurve(vesta.NewField())
+	data, _ = Q.MarshalBinary()
+	if decoded := vesta.NewPoint(); decoded.UnmarshalBinary(data) != nil || !decoded.Equal(Q) {
+		t.Error("mapping 0 to Vesta should produce a valid point")
+	}
+}
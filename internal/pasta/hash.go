@@ -0,0 +1,156 @@
+package pasta
+
+import (
+	"crypto/sha256"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/rfc9380"
+	"github.com/cronokirby/saferith"
+)
+
+// This file implements hashing to both curves, following RFC 9380.
+//
+// Since both curves have j-invariant 0, the simplified SWU map can't be used directly.
+// Instead, like the Zcash implementation, we map to an isogenous curve,
+// y^2 = x^3 + A x + B, and then apply a 3-isogeny to get a point on the curve we want.
+// Both isogenous curves have B = 1265, and use Z = -13, but A and the isogeny differ.
+
+// isoParams holds the constants for hashing to one of the curves, as Big Endian hex.
+//
+// The isogeny sends (x, y) to (xNum(x) / xDen(x), y * yNum(x) / yDen(x)), where each
+// polynomial is given by its coefficients, starting with the constant term.
+type isoParams struct {
+	a                      string
+	xNum, xDen, yNum, yDen []string
+}
+
+// pallasIsoParams are the constants for hashing to Pallas.
+var pallasIsoParams = isoParams{
+	a: "18354A2EB0EA8C9C49BE2D7258370742B74134581A27A59F92BB4B0B657A014B",
+	xNum: []string{
+		"1C71C71C71C71C71C71C71C71C71C71C8102EEA8E7B06EB6EEBEC06955555580",
+		"17329B9EC525375398C7D7AC3D98FD13380AF066CFEB6D690EB64FAEF37EA4F7",
+		"3509AFD51872D88E267C7FFA51CF412A0F93B82EE4B994958CF863B02814FB76",
+		"0E38E38E38E38E38E38E38E38E38E38E4081775473D8375B775F6034AAAAAAAB",
+	},
+	xDen: []string{
+		"325669BECAECD5D11D13BF2A7F22B105B4ABF9FB9A1FC81C2AA3AF1EAE5B6604",
+		"1D572E7DDC099CFF5A607FCCE0494A799C434AC1C96B6980C47F2AB668BCD71F",
+		"01",
+	},
+	yNum: []string{
+		"025ED097B425ED097B425ED097B425ED0AC03E8E134EB3E493E53AB371C71C4F",
+		"3FB98FF0D2DDCADD303216CCE1DB9FF11765E924F745937802E2BE87D225B234",
+		"1A84D7EA8C396C47133E3FFD28E7A09507C9DC17725CCA4AC67C31D8140A7DBB",
+		"1A12F684BDA12F684BDA12F684BDA12F7642B01AD461BAD25AD985B5E38E38E4",
+	},
+	yDen: []string{
+		"40000000000000000000000000000000224698FC094CF91B992D30ECFFFFFDE5",
+		"17033D3C60C68173573B3D7F7D681310D976BBFABBC5661D4D90AB820B12320A",
+		"0C02C5BCCA0E6B7F0790BFB3506DEFB65941A3A4A97AA1B35A28279B1D1B42AE",
+		"01",
+	},
+}
+
+// vestaIsoParams are the constants for hashing to Vesta.
+var vestaIsoParams = isoParams{
+	a: "267F9B2EE592271A81639C4D96F787739673928C7D01B212C515AD7242EAA6B1",
+	xNum: []string{
+		"31C71C71C71C71C71C71C71C71C71C71E1C521A795AC8356FB539A6F0000002B",
+		"18760C7F7A9AD20DED7EE4A9CDF78F8FD59D03D23B39CB11AEAC67BBEB586A3D",
+		"1D935247B4473D17ACECF10F5F7C09A2216B8861EC72BD5D8B95C6AAF703BCC5",
+		"38E38E38E38E38E38E38E38E38E38E390205DD51CFA0961A43CD42C800000001",
+	},
+	xDen: []string{
+		"14735171EE5427780C621DE8B91C242A30CD6D53DF49D235F169C187D2533465",
+		"0A2DE485568125D51454798A5B5C56B2A3AD678129B604D3B7284F7EAF21A2E9",
+		"01",
+	},
+	yNum: []string{
+		"1ED097B425ED097B425ED097B425ED098BC32D36FB21A6A38F64842C55555533",
+		"19B0D87E16E2578866D1466E9DE10E6497A3CA5C24E9EA634986913AB4443034",
+		"2EC9A923DA239E8BD6767887AFBE04D121D910AEFB03B31D8BEE58E5FB81DE63",
+		"12F684BDA12F684BDA12F684BDA12F685601F4709A8ADCB36BEF1642AAAAAAAB",
+	},
+	yDen: []string{
+		"40000000000000000000000000000000224698FC0994A8DD8C46EB20FFFFFDE5",
+		"3D59F455CAFC7668252659BA2B546C7E926847FB9DDD76A1D43D449776F99D2F",
+		"2F44D6C801C1B8BF9E7EB64F890A820C06A767BFC35B5BAC58DFECCE86B2745E",
+		"01",
+	},
+}
+
+// isogeny holds the constants from isoParams, parsed as elements of the base field.
+type isogeny[B fieldParams] struct {
+	sswu *rfc9380.SSWU[Field[B], *Field[B]]
+	rfc9380.Isogeny[Field[B], *Field[B]]
+}
+
+func newIsogeny[B fieldParams](params *isoParams) *isogeny[B] {
+	parse := func(coeffs []string) []*Field[B] {
+		out := make([]*Field[B], len(coeffs))
+		for i, c := range coeffs {
+			out[i] = fieldFromHex[B](c)
+		}
+		return out
+	}
+	a := fieldFromHex[B](params.a)
+	b := newField[B]().SetUint64(1265)
+	z := newField[B]().SetUint64(13).Negate()
+	return &isogeny[B]{
+		sswu: rfc9380.NewSSWU(a, b, z, (*Field[B]).Sqrt, (*Field[B]).IsOdd),
+		Isogeny: rfc9380.Isogeny[Field[B], *Field[B]]{
+			XNum: parse(params.xNum),
+			XDen: parse(params.xDen),
+			YNum: parse(params.yNum),
+			YDen: parse(params.yDen),
+		},
+	}
+}
+
+// mapToCurve maps a field element to a point on the curve.
+func (c *Curve[B, S]) mapToCurve(u *Field[B]) *Point[B, S] {
+	x, y, z := c.iso.Map(c.iso.sswu.Map(u))
+	out := &Point[B, S]{c: c, x: x, y: y, z: z}
+	// If either denominator vanishes, the isogeny sends the point to the identity.
+	return out.CondAssign(z.EqZero(), c.NewPoint())
+}
+
+// newSuite creates the XMD:SHA-256_SSWU_RO_ suite for this curve, along with its nonuniform variant.
+func (c *Curve[B, S]) newSuite() *rfc9380.Suite {
+	var b B
+	return &rfc9380.Suite{
+		Expand: rfc9380.XMDExpander(sha256.New),
+		Field:  b.modulus(),
+		Degree: 1,
+		K:      128,
+		MapToCurve: func(u []*saferith.Nat) kyokusen.Point {
+			return c.mapToCurve(newField[B]().SetNat(u[0]))
+		},
+	}
+}
+
+// HashToCurve hashes a message to a point, using a domain separation tag.
+//
+// This implements the XMD:SHA-256_SSWU_RO_ suite for this curve, following RFC 9380.
+// Since both curves have prime order, there's no cofactor to clear. The Zcash
+// implementation expands messages with BLAKE2b instead, so its outputs differ.
+func (c *Curve[B, S]) HashToCurve(msg, dst []byte) (*Point[B, S], error) {
+	out, err := c.suite.HashToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*Point[B, S]), nil
+}
+
+// EncodeToCurve encodes a message as a point, using a domain separation tag.
+//
+// This implements the XMD:SHA-256_SSWU_NU_ suite for this curve, following RFC 9380.
+// Unlike HashToCurve, the result isn't uniformly distributed, but this is faster.
+func (c *Curve[B, S]) EncodeToCurve(msg, dst []byte) (*Point[B, S], error) {
+	out, err := c.suite.EncodeToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*Point[B, S]), nil
+}
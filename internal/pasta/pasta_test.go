@@ -0,0 +1,239 @@
+package pasta
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/cronokirby/saferith"
+)
+
+// These tests run against both curves, with the expected values for each one in curveVectors.
+
+type hashVector struct {
+	msg      string
+	expected string
+}
+
+type curveVectors struct {
+	// The expected outputs of HashToCurve and EncodeToCurve. These suites have no
+	// published vectors, and pasta_curves uses BLAKE2b instead, so these come from
+	// testdata/hashgen, a math/big transcription of RFC 9380, which only shares the
+	// isogeny constants with this package: go run ./testdata/hashgen
+	hashDST   string
+	hash      []hashVector
+	encodeDST string
+	encode    []hashVector
+	// multiples holds the encodings of G, 2G, and 3G, also printed by testdata/hashgen.
+	multiples []string
+	// unreduced is the modulus of the base field, as an x coordinate.
+	unreduced string
+}
+
+var pallasVectors = curveVectors{
+	hashDST: "QUUX-V01-CS02-with-pallas_XMD:SHA-256_SSWU_RO_",
+	hash: []hashVector{
+		{"", "8b1a52471f5a732ab1409d4f8c07e52766dddfab4a5c15fa865542246f95d908"},
+		{"abc", "712a9c93f30105962c2eedef107b0ca92e122ff600e790a31b4e240e331447b5"},
+		{"abcdef0123456789", "c91f3cf83016cd15939cf39fbd2353d7bbf24c18bf7835cd2c0a5c2394d9f319"},
+	},
+	encodeDST: "QUUX-V01-CS02-with-pallas_XMD:SHA-256_SSWU_NU_",
+	encode: []hashVector{
+		{"", "53fcf6252702ec5c332bd12dce55f4d564c710110c3d689867f3276baae67614"},
+		{"abc", "828d726a4f68834885886e91c46af841de702186f42db3d6b7b5fc1dad750f38"},
+	},
+	multiples: []string{
+		"00000000ed302d991bf94c09fc98462200000000000000000000000000000040",
+		"030000b067c50313fcac1144eee2fe0e0000000000000000000000000000001c",
+		"63d232eb3b8af0b75cfcf55ade47f6ff4cdf4e47a7454cb8ed67a9ba6f56e788",
+	},
+	unreduced: "01000000ed302d991bf94c09fc98462200000000000000000000000000000040",
+}
+
+var vestaVectors = curveVectors{
+	hashDST: "QUUX-V01-CS02-with-vesta_XMD:SHA-256_SSWU_RO_",
+	hash: []hashVector{
+		{"", "8d419dd6d673793a58d2f166658c2fe302505046cb88c0c2894ee421e48edd03"},
+		{"abc", "6ba2f65eea131ab6cbff882e8709221c5092be10f5c8c823990dcd8531ecd1b9"},
+		{"abcdef0123456789", "5433ceace72303a942bb2601771ae8b8fdac38d76b0bee556deb784a43cc04be"},
+	},
+	encodeDST: "QUUX-V01-CS02-with-vesta_XMD:SHA-256_SSWU_NU_",
+	encode: []hashVector{
+		{"", "985e0af6237a1bfa7f2079224ac625e60752106aa301da9654d3091c93f0a32c"},
+		{"abc", "9e107b61d95209be27aae991e6bcb91837bc346599fb862bef7703bf45904ea7"},
+	},
+	multiples: []string{
+		"0000000021eb468cdda89409fc98462200000000000000000000000000000040",
+		"03000070de065fede0093144eee2fe0e0000000000000000000000000000001c",
+		"5fce556feb6fee5a15560ddabae10224b026a5d0281af4c613955c39a8797837",
+	},
+	unreduced: "0100000021eb468cdda89409fc98462200000000000000000000000000000040",
+}
+
+// forBothCurves runs a test against Pallas and Vesta.
+//
+// Go doesn't let us pass around a generic function, so each test gets one closure per curve.
+func forBothCurves(t *testing.T, pallas, vesta func(t *testing.T)) {
+	t.Run("Pallas", pallas)
+	t.Run("Vesta", vesta)
+}
+
+func randomScalar[B, S fieldParams](c *Curve[B, S], r *rand.Rand) *Scalar[B, S] {
+	data := make([]byte, c.SafeScalarBytes())
+	r.Read(data)
+	return c.NewScalar().SetNat(new(saferith.Nat).SetBytes(data)).(*Scalar[B, S])
+}
+
+func testHashVectors[B, S fieldParams](t *testing.T, hash func(msg, dst []byte) (*Point[B, S], error), dst string, vectors []hashVector) {
+	for _, v := range vectors {
+		P, err := hash([]byte(v.msg), []byte(dst))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := P.MarshalBinary()
+		if hex.EncodeToString(data) != v.expected {
+			t.Errorf("%q: expected %s, got %x", v.msg, v.expected, data)
+		}
+	}
+}
+
+func TestHashToCurve(t *testing.T) {
+	pallas, vesta := NewPallas(nil), NewVesta(nil)
+	forBothCurves(t, func(t *testing.T) {
+		testHashVectors(t, pallas.HashToCurve, pallasVectors.hashDST, pallasVectors.hash)
+	}, func(t *testing.T) {
+		testHashVectors(t, vesta.HashToCurve, vestaVectors.hashDST, vestaVectors.hash)
+	})
+}
+
+func TestEncodeToCurve(t *testing.T) {
+	pallas, vesta := NewPallas(nil), NewVesta(nil)
+	forBothCurves(t, func(t *testing.T) {
+		testHashVectors(t, pallas.EncodeToCurve, pallasVectors.encodeDST, pallasVectors.encode)
+	}, func(t *testing.T) {
+		testHashVectors(t, vesta.EncodeToCurve, vestaVectors.encodeDST, vestaVectors.encode)
+	})
+}
+
+func testSmallMultiplesEncoding[B, S fieldParams](t *testing.T, c *Curve[B, S], expected []string) {
+	for i, e := range expected {
+		s := c.NewScalar().SetNat(new(saferith.Nat).SetUint64(uint64(i + 1)))
+		data, _ := s.ActOnBase().MarshalBinary()
+		if hex.EncodeToString(data) != e {
+			t.Errorf("%d * G: expected %s, got %x", i+1, e, data)
+		}
+	}
+}
+
+func TestSmallMultiplesEncoding(t *testing.T) {
+	forBothCurves(t, func(t *testing.T) {
+		testSmallMultiplesEncoding(t, NewPallas(nil), pallasVectors.multiples)
+	}, func(t *testing.T) {
+		testSmallMultiplesEncoding(t, NewVesta(nil), vestaVectors.multiples)
+	})
+}
+
+func testIdentityMarshalRoundtrip[B, S fieldParams](t *testing.T, c *Curve[B, S]) {
+	data, err := c.NewPoint().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(data) != "0000000000000000000000000000000000000000000000000000000000000000" {
+		t.Errorf("unexpected encoding of the identity: %x", data)
+	}
+	decoded := c.NewBasePoint()
+	if decoded.UnmarshalBinary(data) != nil || !decoded.IsIdentity() {
+		t.Error("failed to decode the identity")
+	}
+	if !c.NewPoint().XScalar().IsZero() {
+		t.Error("the x coordinate of the identity should be zero")
+	}
+}
+
+func TestIdentityMarshalRoundtrip(t *testing.T) {
+	forBothCurves(t, func(t *testing.T) {
+		testIdentityMarshalRoundtrip(t, NewPallas(nil))
+	}, func(t *testing.T) {
+		testIdentityMarshalRoundtrip(t, NewVesta(nil))
+	})
+}
+
+func testUnmarshalRejectsInvalidPoints[B, S fieldParams](t *testing.T, c *Curve[B, S], unreducedHex string) {
+	// x = 2 gives y^2 = 13, which isn't square in either field.
+	notOnCurve := make([]byte, FieldBytes)
+	notOnCurve[0] = 2
+	// The modulus of the base field isn't a canonical x coordinate.
+	unreduced, _ := hex.DecodeString(unreducedHex)
+	// x = 0, with y odd, doesn't correspond to any point.
+	oddZero := make([]byte, FieldBytes)
+	oddZero[FieldBytes-1] = 0x80
+	for _, data := range [][]byte{notOnCurve, unreduced, oddZero, {0}, nil} {
+		if c.NewPoint().UnmarshalBinary(data) == nil {
+			t.Errorf("%x should be rejected", data)
+		}
+	}
+}
+
+func TestUnmarshalRejectsInvalidPoints(t *testing.T) {
+	forBothCurves(t, func(t *testing.T) {
+		testUnmarshalRejectsInvalidPoints(t, NewPallas(nil), pallasVectors.unreduced)
+	}, func(t *testing.T) {
+		testUnmarshalRejectsInvalidPoints(t, NewVesta(nil), vestaVectors.unreduced)
+	})
+}
+
+func testScalarRejectsOrder[B, S fieldParams](t *testing.T, c *Curve[B, S]) {
+	if c.NewScalar().UnmarshalBinary(c.Order().Nat().FillBytes(make([]byte, FieldBytes))) == nil {
+		t.Error("the order should be rejected as a scalar")
+	}
+	minusOne := c.NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).Negate()
+	if !minusOne.ActOnBase().Add(c.basePoint).IsIdentity() {
+		t.Error("(q - 1) * G + G should be the identity")
+	}
+}
+
+func TestScalarRejectsOrder(t *testing.T) {
+	forBothCurves(t, func(t *testing.T) {
+		testScalarRejectsOrder(t, NewPallas(nil))
+	}, func(t *testing.T) {
+		testScalarRejectsOrder(t, NewVesta(nil))
+	})
+}
+
+func testCycleFieldRoundtrip[B, S fieldParams](t *testing.T, c *Curve[B, S]) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		a, b := randomScalar(c, r), randomScalar(c, r)
+		x := a.CycleField()
+		if !c.NewScalar().SetCycleField(x).Equal(a) {
+			t.Fatalf("%v didn't roundtrip", a)
+		}
+		// The conversion should respect the arithmetic on both sides.
+		product := c.NewScalar().Set(a).Mul(b).(*Scalar[B, S]).CycleField()
+		if product.Eq(x.Mul(b.CycleField())) != 1 {
+			t.Fatalf("the conversion of %v * %v is incorrect", a, b)
+		}
+	}
+	minusOne := newField[S]().SetUint64(1).Negate()
+	one := c.NewScalar().SetNat(new(saferith.Nat).SetUint64(1))
+	if !c.NewScalar().SetCycleField(minusOne).Add(one).IsZero() {
+		t.Error("-1 in the other base field should be -1 as a scalar")
+	}
+}
+
+func TestCycleFieldRoundtrip(t *testing.T) {
+	forBothCurves(t, func(t *testing.T) {
+		testCycleFieldRoundtrip(t, NewPallas(nil))
+	}, func(t *testing.T) {
+		testCycleFieldRoundtrip(t, NewVesta(nil))
+	})
+}
+
+func BenchmarkActOnBase(b *testing.B) {
+	c := NewPallas(nil)
+	s := randomScalar(c, rand.New(rand.NewSource(0)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.ActOnBase()
+	}
+}
@@ -0,0 +1,230 @@
+package pasta
+
+import (
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// PointBytes is the size of the encoding of a point.
+const PointBytes = 32
+
+// Point represents a point on one of the curves, with base field B, and scalar field S.
+type Point[B, S fieldParams] struct {
+	c *Curve[B, S]
+	// Internally, we represent this as a projective point (X : Y : Z).
+	// This corresponds to the affine point (X / Z, Y / Z), except when Z = 0,
+	// which corresponds to the point at infinity.
+	x *Field[B]
+	y *Field[B]
+	z *Field[B]
+	// This is a flag indicating that this Point's value is normalized. This
+	// should be set exclusively based on which methods are called on a point,
+	// making it okay to branch on this value.
+	normalized bool
+}
+
+// castPoint converts a point implementing the generic interface to this specific type.
+//
+// Since implementors of the Point interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+func (c *Curve[B, S]) castPoint(p kyokusen.Point) *Point[B, S] {
+	casted, ok := p.(*Point[B, S])
+	if !ok {
+		panic(fmt.Sprintf("failed to cast type to *%s.Point", c.prefix))
+	}
+	return casted
+}
+
+func (p *Point[B, S]) normalize() {
+	if p.normalized {
+		return
+	}
+	// If Z != 0, then we want to get (X/Z : Y/Z : 1)
+	// If Z == 0, then we want to get (0 : 1 : 0)
+	zZero := p.z.EqZero()
+	zInverse := newField[B]().Set(p.z).Invert()
+	one := newField[B]().SetUint64(1)
+	p.x.Mul(zInverse)
+	p.y.Mul(zInverse)
+	p.x.CondAssign(zZero, p.z)
+	p.y.CondAssign(zZero, one)
+	p.z.CondAssign(1^zZero, one)
+	p.normalized = true
+}
+
+// NewPoint returns the identity point.
+func (c *Curve[B, S]) NewPoint() *Point[B, S] {
+	// (0 : 1 : 0) is the point at infinity, in projective coordinates.
+	return &Point[B, S]{
+		c: c,
+		x: newField[B](),
+		y: newField[B]().SetUint64(1),
+		z: newField[B](),
+	}
+}
+
+func (p *Point[B, S]) String() string {
+	return fmt.Sprintf("[%v : %v : %v]", p.x, p.y, p.z)
+}
+
+// reverse returns a reversed copy of some bytes.
+func reverse(data []byte) []byte {
+	out := make([]byte, len(data))
+	for i, c := range data {
+		out[len(data)-1-i] = c
+	}
+	return out
+}
+
+// MarshalBinary marshals a point in the same way as the Zcash implementation of the Pasta curves.
+//
+// The affine x coordinate is encoded as 32 little endian bytes, and the top bit
+// holds the parity of the y coordinate. The identity is encoded as 32 zero bytes.
+func (p *Point[B, S]) MarshalBinary() ([]byte, error) {
+	p.normalize()
+	xBytes, _ := p.x.MarshalBinary()
+	out := reverse(xBytes)
+	// For the identity, both x and y.IsOdd() are 0, giving us the encoding we want.
+	isIdentity := p.z.EqZero()
+	out[PointBytes-1] |= byte(p.y.IsOdd()&(1^isIdentity)) << 7
+	return out, nil
+}
+
+// UnmarshalBinary unmarshals a point from the encoding produced by MarshalBinary.
+//
+// The x coordinate must be canonical, and the point must lie on the curve.
+func (p *Point[B, S]) UnmarshalBinary(data []byte) error {
+	if len(data) != PointBytes {
+		return fmt.Errorf("%s.UnmarshalBinary: invalid data length", p.c.prefix)
+	}
+	yShouldBeOdd := saferith.Choice(data[PointBytes-1] >> 7)
+	xBytes := reverse(data)
+	xBytes[0] &= 0x7F
+	x := newField[B]()
+	if err := x.UnmarshalBinary(xBytes); err != nil {
+		return err
+	}
+	if x.EqZero()&(1^yShouldBeOdd) == 1 {
+		*p = *p.c.NewPoint()
+		return nil
+	}
+	y := p.c.rhs(x)
+	if y.Sqrt() != 1 {
+		return fmt.Errorf("%s.UnmarshalBinary: invalid point", p.c.prefix)
+	}
+	y.CondNegate(y.IsOdd() ^ yShouldBeOdd)
+	p.x, p.y, p.z = x, y, newField[B]().SetUint64(1)
+	p.normalized = true
+	return nil
+}
+
+// rhs calculates x^3 + b.
+func (c *Curve[B, S]) rhs(x *Field[B]) *Field[B] {
+	return newField[B]().Set(x).Square().Mul(x).Add(c.b)
+}
+
+func (p *Point[B, S]) Curve() kyokusen.Curve {
+	return p.c.curve
+}
+
+func (p1 *Point[B, S]) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := p1.c.castPoint(other)
+
+	// This formula is taken from Algorithm 7 of https://eprint.iacr.org/2015/1060,
+	// which is complete for curves with a = 0.
+	t0 := newField[B]().Set(p1.x).Mul(p2.x)
+	t1 := newField[B]().Set(p1.y).Mul(p2.y)
+	t2 := newField[B]().Set(p1.z).Mul(p2.z)
+
+	t3 := newField[B]().Set(p1.x).Add(p1.y)
+	t4 := newField[B]().Set(p2.x).Add(p2.y)
+	t3.Mul(t4)
+
+	t4.Set(t0).Add(t1)
+	t3.Sub(t4)
+	t4.Set(p1.y).Add(p1.z)
+
+	x := newField[B]().Set(p2.y).Add(p2.z)
+	t4.Mul(x)
+	x.Set(t1).Add(t2)
+
+	t4.Sub(x)
+	x.Set(p1.x).Add(p1.z)
+	y := newField[B]().Set(p2.x).Add(p2.z)
+
+	x.Mul(y)
+	y.Set(t0).Add(t2)
+	y.Negate().Add(x)
+
+	x.Set(t0).Add(t0)
+	t0.Add(x)
+	t2.Mul(p1.c.b3)
+
+	z := newField[B]().Set(t1).Add(t2)
+	t1.Sub(t2)
+	y.Mul(p1.c.b3)
+
+	x.Set(y).Mul(t4)
+	t2.Set(t3).Mul(t1)
+	x.Negate().Add(t2)
+
+	y.Mul(t0)
+	t1.Mul(z)
+	y.Add(t1)
+
+	t0.Mul(t3)
+	z.Mul(t4)
+	z.Add(t0)
+
+	return &Point[B, S]{p1.c, x, y, z, false}
+}
+
+func (p *Point[B, S]) Sub(other kyokusen.Point) kyokusen.Point {
+	return p.Add(other.Negate())
+}
+
+func (p *Point[B, S]) Negate() kyokusen.Point {
+	return &Point[B, S]{
+		c: p.c,
+		x: newField[B]().Set(p.x),
+		y: newField[B]().Set(p.y).Negate(),
+		z: newField[B]().Set(p.z),
+	}
+}
+
+func (p1 *Point[B, S]) Equal(other kyokusen.Point) bool {
+	p2 := p1.c.castPoint(other)
+	p1.normalize()
+	p2.normalize()
+	return (p1.x.Eq(p2.x) & p1.y.Eq(p2.y) & p1.z.Eq(p2.z)) == 1
+}
+
+func (p *Point[B, S]) IsIdentity() bool {
+	// Whenever Z == 0, this is the point at infinity.
+	return p.z.EqZero() == 1
+}
+
+// XScalar returns the affine x coordinate of this point, reduced modulo the order of the group.
+//
+// For the identity point, this returns 0.
+func (p *Point[B, S]) XScalar() kyokusen.Scalar {
+	p.normalize()
+	return p.c.NewScalar().SetNat(p.x.Nat())
+}
+
+// CondAssign conditionally modifies the contents of a point.
+func (p *Point[B, S]) CondAssign(yes saferith.Choice, other *Point[B, S]) *Point[B, S] {
+	p.x.CondAssign(yes, other.x)
+	p.y.CondAssign(yes, other.y)
+	p.z.CondAssign(yes, other.z)
+	return p
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *Point[B, S]) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	return p.c.NewPoint().CondAssign(1, p).CondAssign(yes, p.c.castPoint(other))
+}
@@ -0,0 +1,171 @@
+package pasta
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Scalar represents an integer modulo the order of one of the curves.
+//
+// Since the order is the size of the other field, this is an element of S.
+type Scalar[B, S fieldParams] struct {
+	c   *Curve[B, S]
+	nat saferith.Nat
+}
+
+func (s *Scalar[B, S]) String() string {
+	return s.nat.String()
+}
+
+// NewScalar creates a new scalar, with its value set to 0.
+func (c *Curve[B, S]) NewScalar() *Scalar[B, S] {
+	s := &Scalar[B, S]{c: c}
+	s.nat.Mod(&s.nat, c.Order())
+	return s
+}
+
+// castScalar converts a scalar implementing the generic interface to this specific type.
+//
+// Since implementors of the Scalar interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+func (c *Curve[B, S]) castScalar(s kyokusen.Scalar) *Scalar[B, S] {
+	casted, ok := s.(*Scalar[B, S])
+	if !ok {
+		panic(fmt.Sprintf("failed to cast type to *%s.Scalar", c.prefix))
+	}
+	return casted
+}
+
+// SetCycleField sets this scalar to the value of an element of the base field of the other curve.
+//
+// Since the order of this curve is the size of that field, this conversion is exact.
+func (s *Scalar[B, S]) SetCycleField(x *Field[S]) *Scalar[B, S] {
+	s.nat.Mod(&x.nat, s.c.Order())
+	return s
+}
+
+// CycleField returns the value of this scalar, as an element of the base field of the other curve.
+//
+// This is the inverse of SetCycleField.
+func (s *Scalar[B, S]) CycleField() *Field[S] {
+	return newField[S]().SetNat(&s.nat)
+}
+
+// MarshalBinary returns the contents of this scalar as Big Endian bytes.
+func (s *Scalar[B, S]) MarshalBinary() ([]byte, error) {
+	return s.nat.FillBytes(make([]byte, FieldBytes)), nil
+}
+
+// UnmarshalBinary deserializes Big Endian bytes into this scalar.
+func (s *Scalar[B, S]) UnmarshalBinary(data []byte) error {
+	if len(data) != FieldBytes {
+		return fmt.Errorf("%s.Scalar.UnmarshalBinary: invalid data length", s.c.prefix)
+	}
+	var nat saferith.Nat
+	nat.SetBytes(data)
+	if _, _, lt := nat.CmpMod(s.c.Order()); lt != 1 {
+		return fmt.Errorf("%s.Scalar.UnmarshalBinary: value is greater than order", s.c.prefix)
+	}
+	s.nat.Mod(&nat, s.c.Order())
+	return nil
+}
+
+// Curve returns the curve associated with this scalar field.
+func (s *Scalar[B, S]) Curve() kyokusen.Curve {
+	return s.c.curve
+}
+
+func (s1 *Scalar[B, S]) Add(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.ModAdd(&s1.nat, &s2.nat, s1.c.Order())
+	return s1
+}
+
+func (s1 *Scalar[B, S]) Sub(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.ModSub(&s1.nat, &s2.nat, s1.c.Order())
+	return s1
+}
+
+func (s1 *Scalar[B, S]) Negate() kyokusen.Scalar {
+	s1.nat.ModNeg(&s1.nat, s1.c.Order())
+	return s1
+}
+
+func (s1 *Scalar[B, S]) Mul(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.ModMul(&s1.nat, &s2.nat, s1.c.Order())
+	return s1
+}
+
+func (s1 *Scalar[B, S]) Invert() kyokusen.Scalar {
+	s1.nat.ModInverse(&s1.nat, s1.c.Order())
+	return s1
+}
+
+func (s1 *Scalar[B, S]) Equal(other kyokusen.Scalar) bool {
+	s2 := s1.c.castScalar(other)
+	return s1.nat.Eq(&s2.nat) == 1
+}
+
+func (s1 *Scalar[B, S]) IsZero() bool {
+	return s1.nat.EqZero() == 1
+}
+
+func (s1 *Scalar[B, S]) Set(other kyokusen.Scalar) kyokusen.Scalar {
+	s2 := s1.c.castScalar(other)
+	s1.nat.SetNat(&s2.nat)
+	return s1
+}
+
+func (s1 *Scalar[B, S]) SetNat(other *saferith.Nat) kyokusen.Scalar {
+	s1.nat.Mod(other, s1.c.Order())
+	return s1
+}
+
+// window is the number of bits of the scalar we process at once when multiplying points.
+const window = 4
+
+// nibble returns the i-th group of 4 bits in a Big Endian number, starting from the least significant bits.
+func nibble(bytes []byte, i int) int32 {
+	b := bytes[len(bytes)-1-i/2]
+	return int32((b >> (4 * (i % 2))) & 0xF)
+}
+
+// Act calculates s * P, in constant-time.
+//
+// This uses a fixed window of 4 bits, with a table of the first 16 multiples of P.
+// Every entry is read for every window, so the access pattern doesn't depend on s.
+func (s *Scalar[B, S]) Act(other kyokusen.Point) kyokusen.Point {
+	P := s.c.castPoint(other)
+	bytes, _ := s.MarshalBinary()
+
+	// table[j] = j * P
+	var table [1 << window]*Point[B, S]
+	table[0] = s.c.NewPoint()
+	for j := 1; j < len(table); j++ {
+		table[j] = table[j-1].Add(P).(*Point[B, S])
+	}
+
+	acc := s.c.NewPoint()
+	selected := s.c.NewPoint()
+	for i := 2*len(bytes) - 1; i >= 0; i-- {
+		for j := 0; j < window; j++ {
+			acc = acc.Add(acc).(*Point[B, S])
+		}
+		d := nibble(bytes, i)
+		for j, entry := range table {
+			selected.CondAssign(saferith.Choice(subtle.ConstantTimeEq(d, int32(j))), entry)
+		}
+		acc = acc.Add(selected).(*Point[B, S])
+	}
+	return acc
+}
+
+// ActOnBase calculates s * G, where G is the generator of the group.
+func (s *Scalar[B, S]) ActOnBase() kyokusen.Point {
+	return s.Act(s.c.basePoint)
+}
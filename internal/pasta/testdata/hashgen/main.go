@@ -0,0 +1,328 @@
+// Command hashgen generates the hashing test vectors for Pallas and Vesta.
+//
+// Run it from the internal/pasta directory:
+//
+//	go run ./testdata/hashgen
+//
+// This prints the vectors used in pasta_test.go. It's a direct transcription of
+// RFC 9380, using math/big, without any of the tricks the real implementation
+// uses, like constant time arithmetic, straight-line SSWU, or projective coordinates.
+// The only thing shared with the real implementation is the constants of the
+// isogenous curves, and of the isogenies, which come from the Zcash implementation,
+// pasta_curves (https://github.com/zcash/pasta_curves). As a sanity check on these,
+// every point is checked to lie on the curve it should.
+//
+// These suites aren't registered anywhere, and aren't the ones pasta_curves
+// implements: it expands messages with BLAKE2b, rather than SHA-256, so its outputs
+// are different.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// curve holds the parameters of one of the two curves, y^2 = x^3 + 5.
+type curve struct {
+	name string
+	p    *big.Int
+	// a is the coefficient of x in the isogenous curve, y^2 = x^3 + a x + 1265.
+	a *big.Int
+	// The isogeny sends (x, y) to (xNum(x) / xDen(x), y * yNum(x) / yDen(x)),
+	// with the coefficients of each polynomial starting with the constant term.
+	xNum, xDen, yNum, yDen []*big.Int
+}
+
+func fromHex(s string) *big.Int {
+	out, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+	return out
+}
+
+func fromHexes(ss ...string) []*big.Int {
+	out := make([]*big.Int, len(ss))
+	for i, s := range ss {
+		out[i] = fromHex(s)
+	}
+	return out
+}
+
+var pallas = &curve{
+	name: "pallas",
+	p:    fromHex("40000000000000000000000000000000224698fc094cf91b992d30ed00000001"),
+	a:    fromHex("18354a2eb0ea8c9c49be2d7258370742b74134581a27a59f92bb4b0b657a014b"),
+	xNum: fromHexes(
+		"1c71c71c71c71c71c71c71c71c71c71c8102eea8e7b06eb6eebec06955555580",
+		"17329b9ec525375398c7d7ac3d98fd13380af066cfeb6d690eb64faef37ea4f7",
+		"3509afd51872d88e267c7ffa51cf412a0f93b82ee4b994958cf863b02814fb76",
+		"0e38e38e38e38e38e38e38e38e38e38e4081775473d8375b775f6034aaaaaaab",
+	),
+	xDen: fromHexes(
+		"325669becaecd5d11d13bf2a7f22b105b4abf9fb9a1fc81c2aa3af1eae5b6604",
+		"1d572e7ddc099cff5a607fcce0494a799c434ac1c96b6980c47f2ab668bcd71f",
+		"01",
+	),
+	yNum: fromHexes(
+		"025ed097b425ed097b425ed097b425ed0ac03e8e134eb3e493e53ab371c71c4f",
+		"3fb98ff0d2ddcadd303216cce1db9ff11765e924f745937802e2be87d225b234",
+		"1a84d7ea8c396c47133e3ffd28e7a09507c9dc17725cca4ac67c31d8140a7dbb",
+		"1a12f684bda12f684bda12f684bda12f7642b01ad461bad25ad985b5e38e38e4",
+	),
+	yDen: fromHexes(
+		"40000000000000000000000000000000224698fc094cf91b992d30ecfffffde5",
+		"17033d3c60c68173573b3d7f7d681310d976bbfabbc5661d4d90ab820b12320a",
+		"0c02c5bcca0e6b7f0790bfb3506defb65941a3a4a97aa1b35a28279b1d1b42ae",
+		"01",
+	),
+}
+
+var vesta = &curve{
+	name: "vesta",
+	p:    fromHex("40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001"),
+	a:    fromHex("267f9b2ee592271a81639c4d96f787739673928c7d01b212c515ad7242eaa6b1"),
+	xNum: fromHexes(
+		"31c71c71c71c71c71c71c71c71c71c71e1c521a795ac8356fb539a6f0000002b",
+		"18760c7f7a9ad20ded7ee4a9cdf78f8fd59d03d23b39cb11aeac67bbeb586a3d",
+		"1d935247b4473d17acecf10f5f7c09a2216b8861ec72bd5d8b95c6aaf703bcc5",
+		"38e38e38e38e38e38e38e38e38e38e390205dd51cfa0961a43cd42c800000001",
+	),
+	xDen: fromHexes(
+		"14735171ee5427780c621de8b91c242a30cd6d53df49d235f169c187d2533465",
+		"0a2de485568125d51454798a5b5c56b2a3ad678129b604d3b7284f7eaf21a2e9",
+		"01",
+	),
+	yNum: fromHexes(
+		"1ed097b425ed097b425ed097b425ed098bc32d36fb21a6a38f64842c55555533",
+		"19b0d87e16e2578866d1466e9de10e6497a3ca5c24e9ea634986913ab4443034",
+		"2ec9a923da239e8bd6767887afbe04d121d910aefb03b31d8bee58e5fb81de63",
+		"12f684bda12f684bda12f684bda12f685601f4709a8adcb36bef1642aaaaaaab",
+	),
+	yDen: fromHexes(
+		"40000000000000000000000000000000224698fc0994a8dd8c46eb20fffffde5",
+		"3d59f455cafc7668252659ba2b546c7e926847fb9ddd76a1d43d449776f99d2f",
+		"2f44d6c801c1b8bf9e7eb64f890a820c06a767bfc35b5bac58dfecce86b2745e",
+		"01",
+	),
+}
+
+// point is an affine point, with nil coordinates for the identity.
+type point struct {
+	x, y *big.Int
+}
+
+func (c *curve) mod(x *big.Int) *big.Int {
+	return x.Mod(x, c.p)
+}
+
+func (c *curve) inv(x *big.Int) *big.Int {
+	return new(big.Int).ModInverse(x, c.p)
+}
+
+// eval evaluates a polynomial, given by its coefficients, starting with the constant term.
+func (c *curve) eval(coeffs []*big.Int, x *big.Int) *big.Int {
+	out := new(big.Int)
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		out.Mul(out, x)
+		out.Add(out, coeffs[i])
+		c.mod(out)
+	}
+	return out
+}
+
+// g evaluates x^3 + a x + b.
+func (c *curve) g(a, b, x *big.Int) *big.Int {
+	return c.eval([]*big.Int{b, a, big.NewInt(0), big.NewInt(1)}, x)
+}
+
+func (c *curve) isSquare(x *big.Int) bool {
+	return big.Jacobi(x, c.p) >= 0
+}
+
+func (c *curve) sqrt(x *big.Int) *big.Int {
+	out := new(big.Int).ModSqrt(x, c.p)
+	if out == nil {
+		panic("not a square")
+	}
+	return out
+}
+
+func (c *curve) sgn0(x *big.Int) uint {
+	return x.Bit(0)
+}
+
+func (c *curve) onCurve(P point) bool {
+	if P.x == nil {
+		return true
+	}
+	lhs := c.mod(new(big.Int).Mul(P.y, P.y))
+	return lhs.Cmp(c.g(big.NewInt(0), big.NewInt(5), P.x)) == 0
+}
+
+func (c *curve) add(P, Q point) point {
+	if P.x == nil {
+		return Q
+	}
+	if Q.x == nil {
+		return P
+	}
+	var lambda *big.Int
+	if P.x.Cmp(Q.x) == 0 {
+		if new(big.Int).Add(P.y, Q.y).Cmp(c.p) == 0 || P.y.Sign() == 0 {
+			return point{}
+		}
+		// lambda = 3 x^2 / 2 y
+		num := new(big.Int).Mul(P.x, P.x)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(P.y, 1)
+		lambda = c.mod(num.Mul(num, c.inv(c.mod(den))))
+	} else {
+		num := new(big.Int).Sub(Q.y, P.y)
+		den := c.mod(new(big.Int).Sub(Q.x, P.x))
+		lambda = c.mod(num.Mul(num, c.inv(den)))
+	}
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, P.x)
+	x.Sub(x, Q.x)
+	c.mod(x)
+	y := new(big.Int).Sub(P.x, x)
+	y.Mul(y, lambda)
+	y.Sub(y, P.y)
+	c.mod(y)
+	return point{x, y}
+}
+
+// encode encodes a point as the little endian x coordinate, with the top bit holding the parity of y.
+func (c *curve) encode(P point) string {
+	out := make([]byte, 32)
+	if P.x == nil {
+		return hex.EncodeToString(out)
+	}
+	P.x.FillBytes(out)
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	out[31] |= byte(P.y.Bit(0)) << 7
+	return hex.EncodeToString(out)
+}
+
+// expandMessageXMD follows section 5.3.1 of RFC 9380, with SHA-256.
+func expandMessageXMD(msg, dst []byte, lenInBytes int) []byte {
+	const bInBytes, sInBytes = 32, 64
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+	msgPrime := make([]byte, sInBytes)
+	msgPrime = append(msgPrime, msg...)
+	msgPrime = append(msgPrime, byte(lenInBytes>>8), byte(lenInBytes), 0)
+	msgPrime = append(msgPrime, dstPrime...)
+	b0 := sha256.Sum256(msgPrime)
+	b1 := sha256.Sum256(append(append(b0[:], 1), dstPrime...))
+	out := append([]byte{}, b1[:]...)
+	prev := b1
+	for i := 2; i <= ell; i++ {
+		block := make([]byte, bInBytes)
+		for j := range block {
+			block[j] = b0[j] ^ prev[j]
+		}
+		prev = sha256.Sum256(append(append(block, byte(i)), dstPrime...))
+		out = append(out, prev[:]...)
+	}
+	return out[:lenInBytes]
+}
+
+// hashToField follows section 5.2 of RFC 9380, with L = 48.
+func (c *curve) hashToField(msg, dst []byte, count int) []*big.Int {
+	const L = 48
+	bytes := expandMessageXMD(msg, dst, count*L)
+	out := make([]*big.Int, count)
+	for i := range out {
+		out[i] = c.mod(new(big.Int).SetBytes(bytes[i*L : (i+1)*L]))
+	}
+	return out
+}
+
+// mapToCurve applies the simplified SWU map to the isogenous curve, following
+// section 6.6.2 of RFC 9380, and then the isogeny.
+func (c *curve) mapToCurve(u *big.Int) point {
+	A, B, Z := c.a, big.NewInt(1265), c.mod(big.NewInt(-13))
+	zu2 := c.mod(new(big.Int).Mul(Z, new(big.Int).Mul(u, u)))
+	// tv1 = 1 / (Z^2 u^4 + Z u^2), or 0 if that's 0
+	tv1 := c.mod(new(big.Int).Add(new(big.Int).Mul(zu2, zu2), zu2))
+	var x1 *big.Int
+	if tv1.Sign() == 0 {
+		x1 = c.mod(new(big.Int).Mul(B, c.inv(c.mod(new(big.Int).Mul(Z, A)))))
+	} else {
+		tv1 = c.inv(tv1)
+		x1 = new(big.Int).Neg(B)
+		x1.Mul(x1, c.inv(A))
+		x1.Mul(x1, new(big.Int).Add(tv1, big.NewInt(1)))
+		c.mod(x1)
+	}
+	var x, y *big.Int
+	if gx1 := c.g(A, B, x1); c.isSquare(gx1) {
+		x, y = x1, c.sqrt(gx1)
+	} else {
+		x = c.mod(new(big.Int).Mul(zu2, x1))
+		y = c.sqrt(c.g(A, B, x))
+	}
+	if c.sgn0(u) != c.sgn0(y) {
+		y = c.mod(y.Neg(y))
+	}
+	if c.mod(new(big.Int).Mul(y, y)).Cmp(c.g(A, B, x)) != 0 {
+		panic("the SWU map isn't on the isogenous curve")
+	}
+	xDen, yDen := c.eval(c.xDen, x), c.eval(c.yDen, x)
+	if xDen.Sign() == 0 || yDen.Sign() == 0 {
+		return point{}
+	}
+	outX := c.mod(new(big.Int).Mul(c.eval(c.xNum, x), c.inv(xDen)))
+	outY := new(big.Int).Mul(y, c.eval(c.yNum, x))
+	outY = c.mod(outY.Mul(outY, c.inv(yDen)))
+	P := point{outX, outY}
+	if !c.onCurve(P) {
+		panic("the isogeny doesn't map to the curve")
+	}
+	return P
+}
+
+func (c *curve) hashToCurve(msg, dst string) string {
+	u := c.hashToField([]byte(msg), []byte(dst), 2)
+	return c.encode(c.add(c.mapToCurve(u[0]), c.mapToCurve(u[1])))
+}
+
+func (c *curve) encodeToCurve(msg, dst string) string {
+	u := c.hashToField([]byte(msg), []byte(dst), 1)
+	return c.encode(c.mapToCurve(u[0]))
+}
+
+func (c *curve) printVectors() {
+	fmt.Printf("%s:\n", c.name)
+	hashDST := "QUUX-V01-CS02-with-" + c.name + "_XMD:SHA-256_SSWU_RO_"
+	fmt.Printf("  hash, with %q:\n", hashDST)
+	for _, msg := range []string{"", "abc", "abcdef0123456789"} {
+		fmt.Printf("    %q: %s\n", msg, c.hashToCurve(msg, hashDST))
+	}
+	encodeDST := "QUUX-V01-CS02-with-" + c.name + "_XMD:SHA-256_SSWU_NU_"
+	fmt.Printf("  encode, with %q:\n", encodeDST)
+	for _, msg := range []string{"", "abc"} {
+		fmt.Printf("    %q: %s\n", msg, c.encodeToCurve(msg, encodeDST))
+	}
+	fmt.Println("  multiples:")
+	G := point{c.mod(big.NewInt(-1)), big.NewInt(2)}
+	if !c.onCurve(G) {
+		panic("the generator isn't on the curve")
+	}
+	P := point{}
+	for i := 1; i <= 3; i++ {
+		P = c.add(P, G)
+		fmt.Printf("    %d: %s\n", i, c.encode(P))
+	}
+}
+
+func main() {
+	pallas.printVectors()
+	vesta.printVectors()
+}
@@ -0,0 +1,92 @@
+// Package wnaf implements variable-time scalar multiplication, using the width w NAF of each scalar.
+//
+// The curves implementing kyokusen.VartimeScalar share the recoding, and the
+// interleaving of several terms, from this package, and only provide the tables
+// of multiples for each point.
+//
+// The functions in this package run in variable-time, and must NEVER be used with secret scalars.
+//
+// This package isn't intended to be used directly.
+package wnaf
+
+import "math/big"
+
+// Recode computes the width w NAF of a non-negative number, with the least significant digit first.
+//
+// Each digit is either 0, or odd with an absolute value less than 2^(w - 1),
+// and any w consecutive digits have at most one non-zero digit.
+func Recode(k *big.Int, w uint) []int8 {
+	k = new(big.Int).Set(k)
+	modulus := int64(1) << w
+	out := make([]int8, 0, k.BitLen()+1)
+	for k.Sign() > 0 {
+		var digit int64
+		if k.Bit(0) == 1 {
+			digit = new(big.Int).And(k, big.NewInt(modulus-1)).Int64()
+			if digit >= modulus/2 {
+				digit -= modulus
+			}
+			k.Sub(k, big.NewInt(digit))
+		}
+		out = append(out, int8(digit))
+		k.Rsh(k, 1)
+	}
+	return out
+}
+
+// Group holds the operations Interleave needs on points of type P.
+//
+// These are passed as functions, since the point types of each curve have
+// different signatures: some modify their receiver, and others return a new point.
+// Double, Add, and Sub may overwrite their first argument, and return it,
+// but must leave their second argument untouched.
+type Group[P any] struct {
+	Identity func() P
+	Double   func(a P) P
+	Add, Sub func(a, b P) P
+}
+
+// Term is one of the terms of a sum of scalar multiplications.
+type Term[P any] struct {
+	// Digits is the NAF of the scalar, as returned by Recode.
+	Digits []int8
+	// Table holds the odd multiples P, 3P, 5P, ..., of the point we're multiplying,
+	// with at least 2^(w - 2) entries, for NAF width w.
+	Table []P
+	// Negate indicates that each digit should have its sign flipped.
+	Negate bool
+}
+
+// Interleave calculates the sum of several terms, sharing the doublings between them.
+func Interleave[P any](g *Group[P], terms []Term[P]) P {
+	length := 0
+	for _, t := range terms {
+		if len(t.Digits) > length {
+			length = len(t.Digits)
+		}
+	}
+	acc := g.Identity()
+	// We avoid doubling the identity, before any term has been added.
+	started := false
+	for i := length - 1; i >= 0; i-- {
+		if started {
+			acc = g.Double(acc)
+		}
+		for _, t := range terms {
+			if i >= len(t.Digits) || t.Digits[i] == 0 {
+				continue
+			}
+			d := t.Digits[i]
+			if t.Negate {
+				d = -d
+			}
+			if d > 0 {
+				acc = g.Add(acc, t.Table[d/2])
+			} else {
+				acc = g.Sub(acc, t.Table[-d/2])
+			}
+			started = true
+		}
+	}
+	return acc
+}
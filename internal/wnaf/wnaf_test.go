@@ -0,0 +1,96 @@
+package wnaf
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestRecodeRecombines(t *testing.T) {
+	err := quick.Check(func(data [32]byte) bool {
+		k := new(big.Int).SetBytes(data[:])
+		for _, w := range []uint{2, 5, 8} {
+			digits := Recode(k, w)
+			acc := new(big.Int)
+			for i := len(digits) - 1; i >= 0; i-- {
+				acc.Lsh(acc, 1)
+				acc.Add(acc, big.NewInt(int64(digits[i])))
+			}
+			if acc.Cmp(k) != 0 {
+				return false
+			}
+		}
+		return true
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecodeIsNonAdjacent(t *testing.T) {
+	err := quick.Check(func(data [32]byte) bool {
+		k := new(big.Int).SetBytes(data[:])
+		for _, w := range []uint{2, 5, 8} {
+			digits := Recode(k, w)
+			last := -int(w)
+			bound := 1 << (w - 1)
+			for i, digit := range digits {
+				d := int(digit)
+				if d == 0 {
+					continue
+				}
+				if d%2 == 0 || d >= bound || d <= -bound || i-last < int(w) {
+					return false
+				}
+				last = i
+			}
+		}
+		return true
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// integers is the additive group of the integers, which is enough to check the sums Interleave computes.
+var integers = &Group[*big.Int]{
+	Identity: func() *big.Int { return new(big.Int) },
+	Double:   func(a *big.Int) *big.Int { return a.Lsh(a, 1) },
+	Add:      func(a, b *big.Int) *big.Int { return a.Add(a, b) },
+	Sub:      func(a, b *big.Int) *big.Int { return a.Sub(a, b) },
+}
+
+// oddMultiples returns p, 3p, 5p, ..., (2^(w - 1) - 1)p.
+func oddMultiples(p int64, w uint) []*big.Int {
+	out := make([]*big.Int, 1<<(w-2))
+	for i := range out {
+		out[i] = big.NewInt(int64(2*i+1) * p)
+	}
+	return out
+}
+
+func TestInterleaveMatchesSum(t *testing.T) {
+	err := quick.Check(func(k1, k2 [32]byte, p1, p2 int32, negate bool) bool {
+		a, b := new(big.Int).SetBytes(k1[:]), new(big.Int).SetBytes(k2[:])
+		out := Interleave(integers, []Term[*big.Int]{
+			{Digits: Recode(a, 8), Table: oddMultiples(int64(p1), 8)},
+			{Digits: Recode(b, 5), Table: oddMultiples(int64(p2), 5), Negate: negate},
+		})
+		expected := new(big.Int).Mul(b, big.NewInt(int64(p2)))
+		if negate {
+			expected.Neg(expected)
+		}
+		expected.Add(expected, new(big.Int).Mul(a, big.NewInt(int64(p1))))
+		return out.Cmp(expected) == 0
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInterleaveOfNothingIsIdentity(t *testing.T) {
+	out := Interleave(integers, []Term[*big.Int]{{Digits: Recode(new(big.Int), 5), Table: oddMultiples(3, 5)}})
+	if out.Sign() != 0 {
+		t.Errorf("expected 0, got %v", out)
+	}
+}
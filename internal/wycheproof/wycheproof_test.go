@@ -0,0 +1,385 @@
+// Package wycheproof runs the test vectors from Project Wycheproof against this library.
+//
+// The vectors live in testdata, and were taken unmodified from https://github.com/C2SP/wycheproof.
+// Each vector is expected to be "valid", "invalid", or "acceptable". We must accept
+// the valid vectors, and reject the invalid ones. Acceptable vectors use legal, but
+// unusual, inputs, like compressed public keys, so we only report what we did with them.
+//
+// Running the tests with -v logs the outcome for every vector. The P-384 and P-521 files
+// take a few minutes to run, so they're skipped with -short.
+package wycheproof
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/ecdsa"
+	"github.com/cronokirby/kyokusen/edwards25519/ed25519"
+	"github.com/cronokirby/kyokusen/p256"
+	"github.com/cronokirby/kyokusen/p384"
+	"github.com/cronokirby/kyokusen/p521"
+	"github.com/cronokirby/kyokusen/secp256k1"
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// curves maps the names Wycheproof uses to our curves.
+var curves = map[string]kyokusen.Curve{
+	"secp256k1": secp256k1.Curve{},
+	"secp256r1": p256.Curve{},
+	"secp384r1": p384.Curve{},
+	"secp521r1": p521.Curve{},
+}
+
+// curveOIDs maps the names Wycheproof uses to the OIDs identifying each curve in public keys.
+var curveOIDs = map[string]asn1.ObjectIdentifier{
+	"secp256k1": {1, 3, 132, 0, 10},
+	"secp256r1": {1, 2, 840, 10045, 3, 1, 7},
+	"secp384r1": {1, 3, 132, 0, 34},
+	"secp521r1": {1, 3, 132, 0, 35},
+}
+
+// oidECPublicKey identifies elliptic curve public keys, as defined in RFC 5480.
+var oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+var hashes = map[string]func() hash.Hash{
+	"SHA-256": sha256.New,
+	"SHA-384": sha512.New384,
+	"SHA-512": sha512.New,
+}
+
+// testCase holds the fields common to every Wycheproof test vector.
+type testCase struct {
+	TcID    int      `json:"tcId"`
+	Comment string   `json:"comment"`
+	Flags   []string `json:"flags"`
+	Result  string   `json:"result"`
+}
+
+type ecdsaTestFile struct {
+	TestGroups []struct {
+		PublicKey struct {
+			Curve        string `json:"curve"`
+			Uncompressed string `json:"uncompressed"`
+		} `json:"publicKey"`
+		Sha   string `json:"sha"`
+		Tests []struct {
+			testCase
+			Msg string `json:"msg"`
+			Sig string `json:"sig"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+type ecdhTestFile struct {
+	TestGroups []struct {
+		Curve string `json:"curve"`
+		Tests []struct {
+			testCase
+			Public  string `json:"public"`
+			Private string `json:"private"`
+			Shared  string `json:"shared"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+type eddsaTestFile struct {
+	TestGroups []struct {
+		PublicKey struct {
+			Pk string `json:"pk"`
+		} `json:"publicKey"`
+		Tests []struct {
+			testCase
+			Msg string `json:"msg"`
+			Sig string `json:"sig"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// loadTestFile decodes one of the JSON files in testdata.
+func loadTestFile(t *testing.T, name string, out interface{}) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// skipIfSlow skips the files for P-384 and P-521 in short mode, since they take a while.
+func skipIfSlow(t *testing.T, name string) {
+	if testing.Short() && (strings.Contains(name, "secp384r1") || strings.Contains(name, "secp521r1")) {
+		t.Skip("skipping slow test vectors in short mode")
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// report keeps track of the outcomes of the vectors in a file.
+type report struct {
+	t          *testing.T
+	valid      int
+	invalid    int
+	accepted   int
+	rejected   int
+	unexpected int
+}
+
+// record checks the outcome of a vector, with err being nil if we accepted it.
+func (r *report) record(tc testCase, err error) {
+	outcome := "accepted"
+	if err != nil {
+		outcome = "rejected: " + err.Error()
+	}
+	r.t.Logf("tcId %d (%s, %v): %s vector was %s", tc.TcID, tc.Comment, tc.Flags, tc.Result, outcome)
+	switch tc.Result {
+	case "valid":
+		r.valid++
+		if err != nil {
+			r.unexpected++
+			r.t.Errorf("tcId %d (%s, %v): valid vector was rejected: %v", tc.TcID, tc.Comment, tc.Flags, err)
+		}
+	case "invalid":
+		r.invalid++
+		if err == nil {
+			r.unexpected++
+			r.t.Errorf("tcId %d (%s, %v): invalid vector was accepted", tc.TcID, tc.Comment, tc.Flags)
+		}
+	case "acceptable":
+		if err == nil {
+			r.accepted++
+		} else {
+			r.rejected++
+		}
+	default:
+		r.t.Fatalf("tcId %d: unknown result %q", tc.TcID, tc.Result)
+	}
+}
+
+func (r *report) summarize() {
+	r.t.Logf("%d valid, %d invalid, %d acceptable (%d accepted, %d rejected), %d unexpected outcomes",
+		r.valid, r.invalid, r.accepted+r.rejected, r.accepted, r.rejected, r.unexpected)
+}
+
+// scalarFromBig converts a number into a scalar, failing if it's not in [0, order).
+func scalarFromBig(curve kyokusen.Curve, x *big.Int) (kyokusen.Scalar, error) {
+	zeroData, _ := curve.NewScalar().MarshalBinary()
+	if x.Sign() < 0 || x.BitLen() > 8*len(zeroData) {
+		return nil, errors.New("scalar out of range")
+	}
+	s := curve.NewScalar()
+	if err := s.UnmarshalBinary(x.FillBytes(make([]byte, len(zeroData)))); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// parseDERSignature parses an ASN.1 DER encoded signature, as a sequence of two integers.
+func parseDERSignature(sig []byte) (r, s *big.Int, err error) {
+	r, s = new(big.Int), new(big.Int)
+	var inner cryptobyte.String
+	input := cryptobyte.String(sig)
+	if !input.ReadASN1(&inner, cryptobyte_asn1.SEQUENCE) || !input.Empty() ||
+		!inner.ReadASN1Integer(r) || !inner.ReadASN1Integer(s) || !inner.Empty() {
+		return nil, nil, errors.New("invalid ASN.1 signature")
+	}
+	return r, s, nil
+}
+
+// parseP1363Signature parses a signature as two fixed size Big Endian integers.
+func parseP1363Signature(curve kyokusen.Curve, sig []byte) (r, s *big.Int, err error) {
+	zeroData, _ := curve.NewScalar().MarshalBinary()
+	if len(sig) != 2*len(zeroData) {
+		return nil, nil, errors.New("invalid signature length")
+	}
+	r = new(big.Int).SetBytes(sig[:len(zeroData)])
+	s = new(big.Int).SetBytes(sig[len(zeroData):])
+	return r, s, nil
+}
+
+// verifyECDSA checks a signature on a message, going through the ecdsa package.
+func verifyECDSA(public kyokusen.Point, h func() hash.Hash, msg []byte, r, s *big.Int) error {
+	curve := public.Curve()
+	rScalar, err := scalarFromBig(curve, r)
+	if err != nil {
+		return err
+	}
+	sScalar, err := scalarFromBig(curve, s)
+	if err != nil {
+		return err
+	}
+	hasher := h()
+	hasher.Write(msg)
+	return ecdsa.Verify(public, hasher.Sum(nil), rScalar, sScalar)
+}
+
+func testECDSA(t *testing.T, name string, parse func(kyokusen.Curve, []byte) (r, s *big.Int, err error)) {
+	var file ecdsaTestFile
+	loadTestFile(t, name, &file)
+	r := report{t: t}
+	for _, group := range file.TestGroups {
+		curve, ok := curves[group.PublicKey.Curve]
+		if !ok {
+			t.Fatalf("unknown curve %q", group.PublicKey.Curve)
+		}
+		h, ok := hashes[group.Sha]
+		if !ok {
+			t.Fatalf("unknown hash function %q", group.Sha)
+		}
+		public := curve.NewPoint()
+		if err := public.UnmarshalBinary(mustDecodeHex(t, group.PublicKey.Uncompressed)); err != nil {
+			t.Fatalf("failed to decode public key: %v", err)
+		}
+		for _, test := range group.Tests {
+			sigR, sigS, err := parse(curve, mustDecodeHex(t, test.Sig))
+			if err == nil {
+				err = verifyECDSA(public, h, mustDecodeHex(t, test.Msg), sigR, sigS)
+			}
+			r.record(test.testCase, err)
+		}
+	}
+	r.summarize()
+}
+
+func TestECDSA(t *testing.T) {
+	for _, name := range []string{
+		"ecdsa_secp256k1_sha256_test.json",
+		"ecdsa_secp256k1_sha512_test.json",
+		"ecdsa_secp256r1_sha256_test.json",
+		"ecdsa_secp384r1_sha384_test.json",
+		"ecdsa_secp521r1_sha512_test.json",
+	} {
+		t.Run(name, func(t *testing.T) {
+			skipIfSlow(t, name)
+			testECDSA(t, name, func(_ kyokusen.Curve, sig []byte) (r, s *big.Int, err error) {
+				return parseDERSignature(sig)
+			})
+		})
+	}
+}
+
+func TestECDSAP1363(t *testing.T) {
+	for _, name := range []string{
+		"ecdsa_secp256k1_sha256_p1363_test.json",
+		"ecdsa_secp256r1_sha256_p1363_test.json",
+		"ecdsa_secp384r1_sha384_p1363_test.json",
+		"ecdsa_secp521r1_sha512_p1363_test.json",
+	} {
+		t.Run(name, func(t *testing.T) {
+			skipIfSlow(t, name)
+			testECDSA(t, name, parseP1363Signature)
+		})
+	}
+}
+
+// parsePublicKey parses an ASN.1 SubjectPublicKeyInfo structure, as defined in RFC 5480.
+//
+// Only public keys using the named curve are accepted.
+func parsePublicKey(curveName string, der []byte) (kyokusen.Point, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(der, &spki); err != nil || len(rest) != 0 {
+		return nil, errors.New("invalid ASN.1 public key")
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidECPublicKey) {
+		return nil, errors.New("not an elliptic curve public key")
+	}
+	var namedCurve asn1.ObjectIdentifier
+	if rest, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &namedCurve); err != nil || len(rest) != 0 {
+		return nil, errors.New("public key doesn't use a named curve")
+	}
+	if !namedCurve.Equal(curveOIDs[curveName]) {
+		return nil, errors.New("public key is for the wrong curve")
+	}
+	if spki.PublicKey.BitLength != 8*len(spki.PublicKey.Bytes) {
+		return nil, errors.New("invalid public key length")
+	}
+	public := curves[curveName].NewPoint()
+	if err := public.UnmarshalBinary(spki.PublicKey.Bytes); err != nil {
+		return nil, err
+	}
+	return public, nil
+}
+
+// ecdh calculates the x coordinate of secret * public.
+func ecdh(secret kyokusen.Scalar, public kyokusen.Point) ([]byte, error) {
+	// Our encodings all start with a single byte, followed by the x coordinate.
+	data, err := secret.Act(public).MarshalBinary()
+	if err != nil {
+		return nil, errors.New("shared secret is the identity")
+	}
+	return data[1:], nil
+}
+
+func TestECDH(t *testing.T) {
+	for _, name := range []string{
+		"ecdh_secp256k1_test.json",
+		"ecdh_secp256r1_test.json",
+		"ecdh_secp384r1_test.json",
+		"ecdh_secp521r1_test.json",
+	} {
+		t.Run(name, func(t *testing.T) {
+			skipIfSlow(t, name)
+			var file ecdhTestFile
+			loadTestFile(t, name, &file)
+			r := report{t: t}
+			for _, group := range file.TestGroups {
+				curve, ok := curves[group.Curve]
+				if !ok {
+					t.Fatalf("unknown curve %q", group.Curve)
+				}
+				for _, test := range group.Tests {
+					secret, err := scalarFromBig(curve, new(big.Int).SetBytes(mustDecodeHex(t, test.Private)))
+					if err != nil {
+						t.Fatalf("tcId %d: failed to decode private key: %v", test.TcID, err)
+					}
+					public, err := parsePublicKey(group.Curve, mustDecodeHex(t, test.Public))
+					var shared []byte
+					if err == nil {
+						shared, err = ecdh(secret, public)
+					}
+					if err == nil && hex.EncodeToString(shared) != test.Shared {
+						t.Errorf("tcId %d (%s): shared secret is %x, expected %s", test.TcID, test.Comment, shared, test.Shared)
+					}
+					r.record(test.testCase, err)
+				}
+			}
+			r.summarize()
+		})
+	}
+}
+
+func TestEd25519(t *testing.T) {
+	var file eddsaTestFile
+	loadTestFile(t, "ed25519_test.json", &file)
+	r := report{t: t}
+	for _, group := range file.TestGroups {
+		public := mustDecodeHex(t, group.PublicKey.Pk)
+		for _, test := range group.Tests {
+			err := ed25519.Verify(public, mustDecodeHex(t, test.Msg), mustDecodeHex(t, test.Sig))
+			r.record(test.testCase, err)
+		}
+	}
+	r.summarize()
+}
@@ -32,6 +32,10 @@ type Curve interface {
 	// reduction doesn't introduce any bias.
 	SafeScalarBytes() int
 	// Order returns a Modulus holding order of this group.
+	//
+	// If the curve has a cofactor, this is the order of the prime order subgroup
+	// generated by the base point, which scalars are taken modulo. Such curves
+	// should also implement CofactorCurve.
 	Order() *saferith.Modulus
 }
 
@@ -46,6 +50,7 @@ type Curve interface {
 // with elements of the same type. It's perfectly fine to cast incoming elements
 // to your concrete type. This interface is not designed to be able to handle
 // different Scalar types, but we can't encode that in the type system.
+// The group package provides a generic version of these interfaces which does.
 type Scalar interface {
 	// This should encode the Scalar as Big Endian bytes, without failure.
 	encoding.BinaryMarshaler
@@ -101,6 +106,7 @@ type Scalar interface {
 // with elements of the same type. It's perfectly fine to cast incoming elements
 // to your concrete type. This interface is not designed to be able to handle
 // different Point types, but we can't encode that in the type system.
+// The group package provides a generic version of these interfaces which does.
 type Point interface {
 	// You're free to implement the binary marshalling however you'd like.
 	//
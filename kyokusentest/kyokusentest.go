@@ -0,0 +1,568 @@
+// Package kyokusentest provides a conformance test suite for implementations of kyokusen.Curve.
+//
+// An implementation can be checked by calling TestCurve from one of its tests:
+//
+//	func TestConformance(t *testing.T) {
+//		kyokusentest.TestCurve(t, Curve{})
+//	}
+//
+// This checks that the points form a group, that the scalars form a field acting
+// on that group, that encodings roundtrip, and that the mutability contracts
+// documented on the kyokusen interfaces are respected. The optional interfaces
+// a curve implements, like CofactorCurve or VartimeScalar, are checked as well.
+package kyokusentest
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// checkCount is the number of random inputs each property is checked against.
+//
+// Some curves have slow scalar multiplication, so we use fewer than the default of quick.Check.
+const checkCount = 20
+
+var (
+	scalarType = reflect.TypeOf((*kyokusen.Scalar)(nil)).Elem()
+	pointType  = reflect.TypeOf((*kyokusen.Point)(nil)).Elem()
+)
+
+// tester holds the curve we're testing, along with the test it's being tested in.
+type tester struct {
+	t     *testing.T
+	curve kyokusen.Curve
+}
+
+// TestCurve runs the full conformance test suite against a curve.
+//
+// Each group of checks is run as a separate subtest.
+func TestCurve(t *testing.T, curve kyokusen.Curve) {
+	for _, test := range []struct {
+		name string
+		run  func(*tester)
+	}{
+		{"Parameters", (*tester).testParameters},
+		{"PointGroupLaws", (*tester).testPointGroupLaws},
+		{"ScalarFieldLaws", (*tester).testScalarFieldLaws},
+		{"ScalarAction", (*tester).testScalarAction},
+		{"ScalarEncoding", (*tester).testScalarEncoding},
+		{"PointEncoding", (*tester).testPointEncoding},
+		{"PointImmutability", (*tester).testPointImmutability},
+		{"ScalarMutability", (*tester).testScalarMutability},
+		{"FreshValues", (*tester).testFreshValues},
+		{"Cofactor", (*tester).testCofactor},
+		{"Vartime", (*tester).testVartime},
+		{"SelectablePoint", (*tester).testSelectablePoint},
+		{"MultiScalarMul", (*tester).testMultiScalarMul},
+		{"Hashing", (*tester).testHashing},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.run(&tester{t: t, curve: curve})
+		})
+	}
+}
+
+// scalarFromUint64 creates a scalar with a small value.
+func (tt *tester) scalarFromUint64(x uint64) kyokusen.Scalar {
+	return tt.curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(x))
+}
+
+// randomScalar generates a scalar, occasionally picking an edge case like 0, 1, or -1.
+func (tt *tester) randomScalar(r *rand.Rand) kyokusen.Scalar {
+	switch r.Intn(16) {
+	case 0:
+		return tt.curve.NewScalar()
+	case 1:
+		return tt.scalarFromUint64(1)
+	case 2:
+		return tt.scalarFromUint64(1).Negate()
+	}
+	data := make([]byte, tt.curve.SafeScalarBytes())
+	r.Read(data)
+	return tt.curve.NewScalar().SetNat(new(saferith.Nat).SetBytes(data))
+}
+
+// randomPoint generates a point, occasionally picking the identity, or the base point.
+func (tt *tester) randomPoint(r *rand.Rand) kyokusen.Point {
+	switch r.Intn(16) {
+	case 0:
+		return tt.curve.NewPoint()
+	case 1:
+		return tt.curve.NewBasePoint()
+	}
+	return tt.randomScalar(r).ActOnBase()
+}
+
+// check runs quick.Check on a property, whose arguments are all Scalars or Points of the curve.
+func (tt *tester) check(name string, f interface{}) {
+	fType := reflect.TypeOf(f)
+	config := &quick.Config{
+		MaxCount: checkCount,
+		Values: func(args []reflect.Value, r *rand.Rand) {
+			for i := range args {
+				switch fType.In(i) {
+				case scalarType:
+					args[i] = reflect.ValueOf(tt.randomScalar(r))
+				case pointType:
+					args[i] = reflect.ValueOf(tt.randomPoint(r))
+				default:
+					panic("kyokusentest: unsupported argument type " + fType.In(i).String())
+				}
+			}
+		},
+	}
+	if err := quick.Check(f, config); err != nil {
+		tt.t.Errorf("%s: %v", name, err)
+	}
+}
+
+func (tt *tester) testParameters() {
+	t, curve := tt.t, tt.curve
+	if curve.Name() == "" {
+		t.Error("curve should have a name")
+	}
+	if curve.ScalarBits() != curve.Order().BitLen() {
+		t.Errorf("ScalarBits is %d, but the order has %d bits", curve.ScalarBits(), curve.Order().BitLen())
+	}
+	// The bias from reducing random bytes is about (2^(8 * SafeScalarBytes) mod order) / 2^(8 * SafeScalarBytes).
+	// This is small either when there are extra bytes, or when the order is close to a power of 2,
+	// like for secp256k1, where the bias is around 2^-127.
+	safeBytes := curve.SafeScalarBytes()
+	power := new(saferith.Nat).SetBytes(append([]byte{1}, make([]byte, safeBytes)...))
+	if new(saferith.Nat).Mod(power, curve.Order()).TrueLen()+120 > 8*safeBytes {
+		t.Errorf("SafeScalarBytes is %d, which isn't enough to sample a scalar without bias", safeBytes)
+	}
+	if curve.NewPoint().Curve().Name() != curve.Name() {
+		t.Error("points should belong to the curve")
+	}
+	if curve.NewScalar().Curve().Name() != curve.Name() {
+		t.Error("scalars should belong to the curve")
+	}
+	if !curve.NewPoint().IsIdentity() {
+		t.Error("NewPoint should return the identity")
+	}
+	if curve.NewBasePoint().IsIdentity() {
+		t.Error("NewBasePoint should not return the identity")
+	}
+	if !curve.NewScalar().IsZero() {
+		t.Error("NewScalar should return 0")
+	}
+}
+
+func (tt *tester) testPointGroupLaws() {
+	tt.check("equal to itself", func(a kyokusen.Point) bool {
+		return a.Equal(a)
+	})
+	tt.check("addition commutative", func(a, b kyokusen.Point) bool {
+		return a.Add(b).Equal(b.Add(a))
+	})
+	tt.check("addition associative", func(a, b, c kyokusen.Point) bool {
+		return a.Add(b.Add(c)).Equal(a.Add(b).Add(c))
+	})
+	tt.check("identity", func(a kyokusen.Point) bool {
+		identity := tt.curve.NewPoint()
+		return a.Add(identity).Equal(a) && identity.Add(a).Equal(a)
+	})
+	tt.check("doubling", func(a kyokusen.Point) bool {
+		return a.Add(a).Equal(tt.scalarFromUint64(2).Act(a))
+	})
+	tt.check("self subtraction", func(a kyokusen.Point) bool {
+		return a.Sub(a).IsIdentity() && a.Add(a.Negate()).IsIdentity()
+	})
+	tt.check("subtraction adds negation", func(a, b kyokusen.Point) bool {
+		return a.Sub(b).Equal(a.Add(b.Negate()))
+	})
+	tt.check("double negation", func(a kyokusen.Point) bool {
+		return a.Negate().Negate().Equal(a)
+	})
+	tt.check("identity detection", func(a kyokusen.Point) bool {
+		return a.IsIdentity() == a.Equal(tt.curve.NewPoint())
+	})
+}
+
+func (tt *tester) testScalarFieldLaws() {
+	curve := tt.curve
+	tt.check("addition commutative", func(a, b kyokusen.Scalar) bool {
+		return curve.NewScalar().Set(a).Add(b).Equal(curve.NewScalar().Set(b).Add(a))
+	})
+	tt.check("addition associative", func(a, b, c kyokusen.Scalar) bool {
+		way1 := curve.NewScalar().Set(b).Add(c).Add(a)
+		way2 := curve.NewScalar().Set(a).Add(b).Add(c)
+		return way1.Equal(way2)
+	})
+	tt.check("zero", func(a kyokusen.Scalar) bool {
+		return curve.NewScalar().Set(a).Add(curve.NewScalar()).Equal(a)
+	})
+	tt.check("negation", func(a kyokusen.Scalar) bool {
+		return curve.NewScalar().Set(a).Negate().Add(a).IsZero()
+	})
+	tt.check("subtraction adds negation", func(a, b kyokusen.Scalar) bool {
+		way1 := curve.NewScalar().Set(a).Sub(b)
+		way2 := curve.NewScalar().Set(b).Negate().Add(a)
+		return way1.Equal(way2) && curve.NewScalar().Set(a).Sub(a).IsZero()
+	})
+	tt.check("multiplication commutative", func(a, b kyokusen.Scalar) bool {
+		return curve.NewScalar().Set(a).Mul(b).Equal(curve.NewScalar().Set(b).Mul(a))
+	})
+	tt.check("multiplication associative", func(a, b, c kyokusen.Scalar) bool {
+		way1 := curve.NewScalar().Set(b).Mul(c).Mul(a)
+		way2 := curve.NewScalar().Set(a).Mul(b).Mul(c)
+		return way1.Equal(way2)
+	})
+	tt.check("distributive", func(a, b, c kyokusen.Scalar) bool {
+		way1 := curve.NewScalar().Set(b).Add(c).Mul(a)
+		way2 := curve.NewScalar().Set(a).Mul(b).Add(curve.NewScalar().Set(a).Mul(c))
+		return way1.Equal(way2)
+	})
+	tt.check("one", func(a kyokusen.Scalar) bool {
+		return curve.NewScalar().Set(a).Mul(tt.scalarFromUint64(1)).Equal(a)
+	})
+	tt.check("inverse", func(a kyokusen.Scalar) bool {
+		if a.IsZero() {
+			return true
+		}
+		return curve.NewScalar().Set(a).Invert().Mul(a).Equal(tt.scalarFromUint64(1))
+	})
+	tt.check("zero detection", func(a kyokusen.Scalar) bool {
+		return a.IsZero() == a.Equal(curve.NewScalar())
+	})
+	order := curve.Order().Nat()
+	if !curve.NewScalar().SetNat(order).IsZero() {
+		tt.t.Error("the order should be reduced to 0")
+	}
+	minusOne := new(saferith.Nat).Sub(order, new(saferith.Nat).SetUint64(1), -1)
+	if !curve.NewScalar().SetNat(minusOne).Equal(tt.scalarFromUint64(1).Negate()) {
+		tt.t.Error("the order minus 1 should be -1")
+	}
+}
+
+func (tt *tester) testScalarAction() {
+	curve := tt.curve
+	tt.check("act is additive", func(a, b kyokusen.Scalar, p kyokusen.Point) bool {
+		ab := curve.NewScalar().Set(a).Add(b)
+		return ab.Act(p).Equal(a.Act(p).Add(b.Act(p)))
+	})
+	tt.check("act is multiplicative", func(a, b kyokusen.Scalar, p kyokusen.Point) bool {
+		ab := curve.NewScalar().Set(a).Mul(b)
+		return ab.Act(p).Equal(a.Act(b.Act(p)))
+	})
+	tt.check("act distributes over points", func(a kyokusen.Scalar, p, q kyokusen.Point) bool {
+		return a.Act(p.Add(q)).Equal(a.Act(p).Add(a.Act(q)))
+	})
+	tt.check("act on base", func(a kyokusen.Scalar) bool {
+		return a.ActOnBase().Equal(a.Act(curve.NewBasePoint()))
+	})
+	tt.check("act negation", func(a kyokusen.Scalar, p kyokusen.Point) bool {
+		return curve.NewScalar().Set(a).Negate().Act(p).Equal(a.Act(p).Negate())
+	})
+	tt.check("act edge cases", func(p kyokusen.Point) bool {
+		return curve.NewScalar().Act(p).IsIdentity() &&
+			tt.scalarFromUint64(1).Act(p).Equal(p) &&
+			tt.scalarFromUint64(1).Negate().Act(p).Equal(p.Negate())
+	})
+	tt.check("act on identity", func(a kyokusen.Scalar) bool {
+		return a.Act(curve.NewPoint()).IsIdentity()
+	})
+	minusOne := tt.scalarFromUint64(1).Negate()
+	if !minusOne.ActOnBase().Add(curve.NewBasePoint()).IsIdentity() {
+		tt.t.Error("the order times the base point should be the identity")
+	}
+}
+
+func (tt *tester) testScalarEncoding() {
+	curve := tt.curve
+	tt.check("roundtrip", func(a kyokusen.Scalar) bool {
+		data, err := a.MarshalBinary()
+		if err != nil {
+			return false
+		}
+		decoded := curve.NewScalar()
+		return decoded.UnmarshalBinary(data) == nil && decoded.Equal(a)
+	})
+	tt.check("big endian", func(a kyokusen.Scalar) bool {
+		data, err := a.MarshalBinary()
+		if err != nil {
+			return false
+		}
+		return curve.NewScalar().SetNat(new(saferith.Nat).SetBytes(data)).Equal(a)
+	})
+	tt.check("fixed length", func(a, b kyokusen.Scalar) bool {
+		aData, errA := a.MarshalBinary()
+		bData, errB := b.MarshalBinary()
+		return errA == nil && errB == nil && len(aData) == len(bData)
+	})
+	data, _ := curve.NewScalar().MarshalBinary()
+	if curve.NewScalar().UnmarshalBinary(curve.Order().Nat().FillBytes(make([]byte, len(data)))) == nil {
+		tt.t.Error("the order should be rejected as a scalar")
+	}
+	if curve.NewScalar().UnmarshalBinary(nil) == nil {
+		tt.t.Error("an empty scalar encoding should be rejected")
+	}
+}
+
+func (tt *tester) testPointEncoding() {
+	curve := tt.curve
+	tt.check("roundtrip", func(p kyokusen.Point) bool {
+		data, err := p.MarshalBinary()
+		if err != nil {
+			// Encoding the identity is allowed to fail, but nothing else.
+			return p.IsIdentity()
+		}
+		decoded := curve.NewPoint()
+		return decoded.UnmarshalBinary(data) == nil && decoded.Equal(p)
+	})
+	tt.check("canonical", func(p kyokusen.Point) bool {
+		data1, err1 := p.MarshalBinary()
+		data2, err2 := p.Add(curve.NewPoint()).MarshalBinary()
+		if err1 != nil || err2 != nil {
+			return p.IsIdentity()
+		}
+		return bytes.Equal(data1, data2)
+	})
+	if curve.NewPoint().UnmarshalBinary(nil) == nil {
+		tt.t.Error("an empty point encoding should be rejected")
+	}
+	data, _ := curve.NewBasePoint().MarshalBinary()
+	if curve.NewPoint().UnmarshalBinary(data[1:]) == nil {
+		tt.t.Error("a truncated point encoding should be rejected")
+	}
+	if curve.NewPoint().UnmarshalBinary(append(data, 0)) == nil {
+		tt.t.Error("a point encoding with trailing data should be rejected")
+	}
+}
+
+// encodePoint marshals a point, or returns nil if that fails.
+func encodePoint(p kyokusen.Point) []byte {
+	data, err := p.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// encodeScalar marshals a scalar, or returns nil if that fails.
+func encodeScalar(s kyokusen.Scalar) []byte {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (tt *tester) testPointImmutability() {
+	// The identity might not have an encoding, so we check Equal as well.
+	unchanged := func(p kyokusen.Point, data []byte, copied kyokusen.Point) bool {
+		return bytes.Equal(encodePoint(p), data) && p.Equal(copied)
+	}
+	tt.check("methods don't mutate", func(a, b kyokusen.Point) bool {
+		aData, bData := encodePoint(a), encodePoint(b)
+		aCopy, bCopy := tt.curve.NewPoint().Add(a), tt.curve.NewPoint().Add(b)
+		a.Add(b)
+		a.Sub(b)
+		a.Negate()
+		a.Equal(b)
+		a.IsIdentity()
+		a.XScalar()
+		a.MarshalBinary()
+		kyokusen.ClearCofactor(a)
+		kyokusen.IsTorsionFree(a)
+		return unchanged(a, aData, aCopy) && unchanged(b, bData, bCopy)
+	})
+	tt.check("adding to itself doesn't mutate", func(a kyokusen.Point) bool {
+		aData, aCopy := encodePoint(a), tt.curve.NewPoint().Add(a)
+		a.Add(a)
+		a.Sub(a)
+		return unchanged(a, aData, aCopy)
+	})
+	tt.check("XScalar is fresh", func(a kyokusen.Point) bool {
+		x := a.XScalar()
+		if x == nil {
+			return true
+		}
+		data := encodeScalar(x)
+		x.Add(tt.scalarFromUint64(1))
+		return bytes.Equal(encodeScalar(a.XScalar()), data)
+	})
+}
+
+func (tt *tester) testScalarMutability() {
+	curve := tt.curve
+	tt.check("act doesn't mutate", func(a kyokusen.Scalar, p kyokusen.Point) bool {
+		aData, pData := encodeScalar(a), encodePoint(p)
+		pCopy := curve.NewPoint().Add(p)
+		a.Act(p)
+		a.ActOnBase()
+		return bytes.Equal(encodeScalar(a), aData) && bytes.Equal(encodePoint(p), pData) && p.Equal(pCopy)
+	})
+	order := curve.Order()
+	tt.check("methods mutate the receiver", func(a, b kyokusen.Scalar) bool {
+		aNat := new(saferith.Nat).SetBytes(encodeScalar(a))
+		bNat := new(saferith.Nat).SetBytes(encodeScalar(b))
+		sum := curve.NewScalar().Set(a)
+		sum.Add(b)
+		product := curve.NewScalar().Set(a)
+		product.Mul(b)
+		difference := curve.NewScalar().Set(a)
+		difference.Sub(b)
+		negated := curve.NewScalar().Set(a)
+		negated.Negate()
+		return sum.Equal(curve.NewScalar().SetNat(new(saferith.Nat).ModAdd(aNat, bNat, order))) &&
+			product.Equal(curve.NewScalar().SetNat(new(saferith.Nat).ModMul(aNat, bNat, order))) &&
+			difference.Equal(curve.NewScalar().SetNat(new(saferith.Nat).ModSub(aNat, bNat, order))) &&
+			negated.Equal(curve.NewScalar().SetNat(new(saferith.Nat).ModNeg(aNat, order)))
+	})
+	tt.check("methods return the receiver", func(a, b kyokusen.Scalar) bool {
+		s := curve.NewScalar().Set(a)
+		return s.Add(b).Equal(s) && s.Mul(b).Equal(s) && s.Sub(b).Equal(s) && s.Negate().Equal(s)
+	})
+	tt.check("arguments aren't mutated", func(a, b kyokusen.Scalar) bool {
+		bData := encodeScalar(b)
+		s := curve.NewScalar().Set(a)
+		s.Add(b)
+		s.Sub(b)
+		s.Mul(b)
+		s.Equal(b)
+		s.Set(b)
+		s.Negate()
+		return bytes.Equal(encodeScalar(b), bData)
+	})
+	tt.check("set copies", func(a kyokusen.Scalar) bool {
+		aData := encodeScalar(a)
+		s := curve.NewScalar().Set(a)
+		s.Add(tt.scalarFromUint64(1))
+		return bytes.Equal(encodeScalar(a), aData)
+	})
+	tt.check("set nat copies", func(a kyokusen.Scalar) bool {
+		x := new(saferith.Nat).SetBytes(encodeScalar(a))
+		s := curve.NewScalar().SetNat(x)
+		s.Add(tt.scalarFromUint64(1))
+		return curve.NewScalar().SetNat(x).Equal(a)
+	})
+}
+
+func (tt *tester) testFreshValues() {
+	t, curve := tt.t, tt.curve
+	one := tt.scalarFromUint64(1)
+	two := tt.scalarFromUint64(2)
+	other := encodePoint(two.ActOnBase())
+
+	base := curve.NewBasePoint()
+	if err := base.UnmarshalBinary(other); err != nil {
+		t.Fatal(err)
+	}
+	if !curve.NewBasePoint().Equal(one.ActOnBase()) {
+		t.Error("NewBasePoint should return an independent copy of the base point")
+	}
+	if !one.ActOnBase().Equal(one.Act(curve.NewBasePoint())) {
+		t.Error("ActOnBase should not be affected by modifying a base point")
+	}
+
+	identity := curve.NewPoint()
+	if err := identity.UnmarshalBinary(other); err != nil {
+		t.Fatal(err)
+	}
+	if !curve.NewPoint().IsIdentity() {
+		t.Error("NewPoint should return an independent identity point")
+	}
+
+	zero := curve.NewScalar()
+	zero.Add(one)
+	if !curve.NewScalar().IsZero() {
+		t.Error("NewScalar should return an independent scalar")
+	}
+}
+
+func (tt *tester) testCofactor() {
+	curve := tt.curve
+	cofactor := kyokusen.Cofactor(curve)
+	if cofactor.EqZero() == 1 {
+		tt.t.Fatal("cofactor should not be 0")
+	}
+	tt.check("prime order points", func(p kyokusen.Point) bool {
+		return kyokusen.IsTorsionFree(p) && kyokusen.IsTorsionFree(kyokusen.ClearCofactor(p))
+	})
+	tt.check("clearing the cofactor", func(p kyokusen.Point) bool {
+		return kyokusen.ClearCofactor(p).Equal(curve.NewScalar().SetNat(cofactor).Act(p))
+	})
+	if !kyokusen.IsSmallOrder(curve.NewPoint()) {
+		tt.t.Error("the identity should have small order")
+	}
+	if kyokusen.IsSmallOrder(curve.NewBasePoint()) {
+		tt.t.Error("the base point should not have small order")
+	}
+}
+
+func (tt *tester) testVartime() {
+	tt.check("vartime act", func(a kyokusen.Scalar, p kyokusen.Point) bool {
+		return kyokusen.VartimeAct(a, p).Equal(a.Act(p))
+	})
+	tt.check("vartime double base mul", func(a, b kyokusen.Scalar, p kyokusen.Point) bool {
+		aData, bData, pData := encodeScalar(a), encodeScalar(b), encodePoint(p)
+		expected := a.ActOnBase().Add(b.Act(p))
+		ok := kyokusen.VartimeDoubleBaseMul(a, b, p).Equal(expected)
+		return ok && bytes.Equal(encodeScalar(a), aData) && bytes.Equal(encodeScalar(b), bData) &&
+			bytes.Equal(encodePoint(p), pData)
+	})
+}
+
+func (tt *tester) testSelectablePoint() {
+	if _, ok := tt.curve.NewPoint().(kyokusen.SelectablePoint); !ok {
+		tt.t.Skip("points don't implement kyokusen.SelectablePoint")
+	}
+	tt.check("select", func(a, b kyokusen.Point) bool {
+		aData, bData := encodePoint(a), encodePoint(b)
+		sa := a.(kyokusen.SelectablePoint)
+		ok := sa.CondSelect(0, b).Equal(a) && sa.CondSelect(1, b).Equal(b)
+		return ok && bytes.Equal(encodePoint(a), aData) && bytes.Equal(encodePoint(b), bData)
+	})
+}
+
+func (tt *tester) testMultiScalarMul() {
+	tt.check("multi-scalar multiplication", func(a, b, c kyokusen.Scalar, p, q, r kyokusen.Point) bool {
+		scalars := []kyokusen.Scalar{a, b, c}
+		points := []kyokusen.Point{p, q, r}
+		expected := a.Act(p).Add(b.Act(q)).Add(c.Act(r))
+		return kyokusen.MultiScalarMul(scalars, points).Equal(expected) &&
+			kyokusen.VartimeMultiScalarMul(scalars, points).Equal(expected)
+	})
+}
+
+func (tt *tester) testHashing() {
+	curve, ok := tt.curve.(kyokusen.HashableCurve)
+	if !ok {
+		tt.t.Skip("curve doesn't implement kyokusen.HashableCurve")
+	}
+	dst := []byte("kyokusentest")
+	for _, msg := range []string{"", "abc", "a slightly longer message to hash"} {
+		for name, hash := range map[string]func(msg, dst []byte) (kyokusen.Point, error){
+			"HashToCurve":   curve.HashToCurve,
+			"EncodeToCurve": curve.EncodeToCurve,
+		} {
+			p1, err := hash([]byte(msg), dst)
+			if err != nil {
+				tt.t.Fatalf("%s(%q): %v", name, msg, err)
+			}
+			p2, _ := hash([]byte(msg), dst)
+			if !p1.Equal(p2) {
+				tt.t.Errorf("%s(%q): hashing should be deterministic", name, msg)
+			}
+			if p1.Curve().Name() != curve.Name() || !kyokusen.IsTorsionFree(p1) {
+				tt.t.Errorf("%s(%q): result should be in the prime order subgroup", name, msg)
+			}
+			p3, _ := hash([]byte(msg), []byte("another tag"))
+			if p1.Equal(p3) {
+				tt.t.Errorf("%s(%q): result should depend on the domain separation tag", name, msg)
+			}
+		}
+	}
+	if _, err := curve.HashToCurve([]byte("abc"), nil); err == nil {
+		tt.t.Error("an empty domain separation tag should be rejected")
+	}
+}
@@ -0,0 +1,253 @@
+package kyokusentest_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/kyokusentest"
+	"github.com/cronokirby/kyokusen/ristretto255"
+	"github.com/cronokirby/saferith"
+)
+
+// brokenCurve wraps a correct curve, optionally breaking one of the contracts checked by TestCurve.
+type brokenCurve struct {
+	inner kyokusen.Curve
+	// mutatingAdd makes Point.Add modify its receiver, instead of returning a new point.
+	mutatingAdd bool
+	// sharedBase, if not nil, is returned by every call to NewBasePoint.
+	sharedBase *brokenPoint
+}
+
+func (c *brokenCurve) wrapPoint(p kyokusen.Point) *brokenPoint {
+	return &brokenPoint{curve: c, inner: p}
+}
+
+func (c *brokenCurve) wrapScalar(s kyokusen.Scalar) *brokenScalar {
+	return &brokenScalar{curve: c, inner: s}
+}
+
+func (c *brokenCurve) NewPoint() kyokusen.Point {
+	return c.wrapPoint(c.inner.NewPoint())
+}
+
+func (c *brokenCurve) NewBasePoint() kyokusen.Point {
+	if c.sharedBase != nil {
+		return c.sharedBase
+	}
+	return c.wrapPoint(c.inner.NewBasePoint())
+}
+
+func (c *brokenCurve) NewScalar() kyokusen.Scalar {
+	return c.wrapScalar(c.inner.NewScalar())
+}
+
+func (c *brokenCurve) Name() string {
+	return "broken " + c.inner.Name()
+}
+
+func (c *brokenCurve) ScalarBits() int {
+	return c.inner.ScalarBits()
+}
+
+func (c *brokenCurve) SafeScalarBytes() int {
+	return c.inner.SafeScalarBytes()
+}
+
+func (c *brokenCurve) Order() *saferith.Modulus {
+	return c.inner.Order()
+}
+
+type brokenPoint struct {
+	curve *brokenCurve
+	inner kyokusen.Point
+}
+
+func unwrapPoint(p kyokusen.Point) kyokusen.Point {
+	return p.(*brokenPoint).inner
+}
+
+func (p *brokenPoint) MarshalBinary() ([]byte, error) {
+	return p.inner.MarshalBinary()
+}
+
+func (p *brokenPoint) UnmarshalBinary(data []byte) error {
+	out := p.curve.inner.NewPoint()
+	if err := out.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	p.inner = out
+	return nil
+}
+
+func (p *brokenPoint) Curve() kyokusen.Curve {
+	return p.curve
+}
+
+func (p *brokenPoint) Add(other kyokusen.Point) kyokusen.Point {
+	sum := p.inner.Add(unwrapPoint(other))
+	if p.curve.mutatingAdd {
+		p.inner = sum
+		return p
+	}
+	return p.curve.wrapPoint(sum)
+}
+
+func (p *brokenPoint) Sub(other kyokusen.Point) kyokusen.Point {
+	return p.curve.wrapPoint(p.inner.Sub(unwrapPoint(other)))
+}
+
+func (p *brokenPoint) Negate() kyokusen.Point {
+	return p.curve.wrapPoint(p.inner.Negate())
+}
+
+func (p *brokenPoint) Equal(other kyokusen.Point) bool {
+	return p.inner.Equal(unwrapPoint(other))
+}
+
+func (p *brokenPoint) IsIdentity() bool {
+	return p.inner.IsIdentity()
+}
+
+func (p *brokenPoint) XScalar() kyokusen.Scalar {
+	x := p.inner.XScalar()
+	if x == nil {
+		return nil
+	}
+	return p.curve.wrapScalar(x)
+}
+
+type brokenScalar struct {
+	curve *brokenCurve
+	inner kyokusen.Scalar
+}
+
+func unwrapScalar(s kyokusen.Scalar) kyokusen.Scalar {
+	return s.(*brokenScalar).inner
+}
+
+func (s *brokenScalar) MarshalBinary() ([]byte, error) {
+	return s.inner.MarshalBinary()
+}
+
+func (s *brokenScalar) UnmarshalBinary(data []byte) error {
+	return s.inner.UnmarshalBinary(data)
+}
+
+func (s *brokenScalar) Curve() kyokusen.Curve {
+	return s.curve
+}
+
+func (s *brokenScalar) Add(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Add(unwrapScalar(other))
+	return s
+}
+
+func (s *brokenScalar) Sub(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Sub(unwrapScalar(other))
+	return s
+}
+
+func (s *brokenScalar) Negate() kyokusen.Scalar {
+	s.inner.Negate()
+	return s
+}
+
+func (s *brokenScalar) Mul(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Mul(unwrapScalar(other))
+	return s
+}
+
+func (s *brokenScalar) Invert() kyokusen.Scalar {
+	s.inner.Invert()
+	return s
+}
+
+func (s *brokenScalar) Equal(other kyokusen.Scalar) bool {
+	return s.inner.Equal(unwrapScalar(other))
+}
+
+func (s *brokenScalar) IsZero() bool {
+	return s.inner.IsZero()
+}
+
+func (s *brokenScalar) Set(other kyokusen.Scalar) kyokusen.Scalar {
+	s.inner.Set(unwrapScalar(other))
+	return s
+}
+
+func (s *brokenScalar) SetNat(x *saferith.Nat) kyokusen.Scalar {
+	s.inner.SetNat(x)
+	return s
+}
+
+func (s *brokenScalar) Act(p kyokusen.Point) kyokusen.Point {
+	return s.curve.wrapPoint(s.inner.Act(unwrapPoint(p)))
+}
+
+func (s *brokenScalar) ActOnBase() kyokusen.Point {
+	return s.curve.wrapPoint(s.inner.ActOnBase())
+}
+
+// brokenCurves creates each of the curves used by TestSuiteCatchesBrokenCurves, by name.
+var brokenCurves = map[string]func() kyokusen.Curve{
+	"correct": func() kyokusen.Curve {
+		return &brokenCurve{inner: ristretto255.Curve{}}
+	},
+	"mutating add": func() kyokusen.Curve {
+		return &brokenCurve{inner: ristretto255.Curve{}, mutatingAdd: true}
+	},
+	"shared base point": func() kyokusen.Curve {
+		c := &brokenCurve{inner: ristretto255.Curve{}}
+		c.sharedBase = c.wrapPoint(c.inner.NewBasePoint())
+		return c
+	},
+}
+
+// brokenCurveEnv holds the name of the curve TestHelperCurve should run the suite against.
+const brokenCurveEnv = "KYOKUSENTEST_BROKEN_CURVE"
+
+// TestHelperCurve isn't a real test, and is instead run in a subprocess by TestSuiteCatchesBrokenCurves.
+//
+// Since the suite reports its failures through a *testing.T, running it in a
+// separate process is the only way to observe them without failing this test.
+func TestHelperCurve(t *testing.T) {
+	name := os.Getenv(brokenCurveEnv)
+	if name == "" {
+		t.Skip("only run as a subprocess")
+	}
+	kyokusentest.TestCurve(t, brokenCurves[name]())
+}
+
+func TestSuiteCatchesBrokenCurves(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		// failure is the subtest which should fail, or "" if the suite should pass.
+		failure string
+	}{
+		{"correct", ""},
+		{"mutating add", "PointImmutability"},
+		{"shared base point", "FreshValues"},
+	} {
+		v := v
+		t.Run(v.name, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestHelperCurve$")
+			cmd.Env = append(os.Environ(), brokenCurveEnv+"="+v.name)
+			out, err := cmd.CombinedOutput()
+			if v.failure == "" {
+				if err != nil {
+					t.Fatalf("the suite should pass:\n%s", out)
+				}
+				return
+			}
+			if _, ok := err.(*exec.ExitError); !ok {
+				t.Fatalf("the suite should fail, got %v:\n%s", err, out)
+			}
+			if !strings.Contains(string(out), "--- FAIL: TestHelperCurve/"+v.failure) {
+				t.Errorf("the %s checks should fail:\n%s", v.failure, out)
+			}
+		})
+	}
+}
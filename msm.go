@@ -0,0 +1,327 @@
+package kyokusen
+
+import (
+	"crypto/subtle"
+	"math/bits"
+
+	"github.com/cronokirby/saferith"
+)
+
+// SelectablePoint is an optional interface for Points supporting constant-time selection.
+//
+// Generic algorithms, like MultiScalarMul, need this to run in constant-time.
+type SelectablePoint interface {
+	Point
+	// CondSelect returns a new Point, equal to other if yes = 1, and to this point otherwise.
+	//
+	// This should be done in constant-time, and shouldn't mutate either point.
+	CondSelect(yes saferith.Choice, other Point) Point
+}
+
+// MultiScalarMuler is an optional interface for Curves providing their own multi-scalar multiplication.
+//
+// MultiScalarMul and VartimeMultiScalarMul will defer to these methods, whenever
+// the Curve of the points implements this interface. An implementation is free to
+// fall back to PippengerMultiScalarMul, StrausMultiScalarMul, or
+// VartimePippengerMultiScalarMul for some inputs.
+type MultiScalarMuler interface {
+	// MultiScalarMul should behave like the function of the same name, in constant-time.
+	MultiScalarMul(scalars []Scalar, points []Point) Point
+	// VartimeMultiScalarMul should behave like the function of the same name.
+	//
+	// This may leak information about the scalars.
+	VartimeMultiScalarMul(scalars []Scalar, points []Point) Point
+}
+
+// checkMultiScalarMulArgs makes sure that the arguments to a multi-scalar multiplication are valid.
+func checkMultiScalarMulArgs(scalars []Scalar, points []Point) {
+	if len(scalars) != len(points) {
+		panic("kyokusen: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		panic("kyokusen: multi-scalar multiplication needs at least one point")
+	}
+}
+
+// MultiScalarMul calculates sum(scalars[i] * points[i]), in constant-time.
+//
+// The two slices must have the same non-zero length, otherwise this function panics.
+//
+// If the Curve implements MultiScalarMuler, that implementation is used. Otherwise,
+// we use PippengerMultiScalarMul, or StrausMultiScalarMul for fewer than
+// pippengerThreshold points, where the cost of summing the buckets dominates.
+func MultiScalarMul(scalars []Scalar, points []Point) Point {
+	checkMultiScalarMulArgs(scalars, points)
+	if curve, ok := points[0].Curve().(MultiScalarMuler); ok {
+		return curve.MultiScalarMul(scalars, points)
+	}
+	if len(points) < pippengerThreshold {
+		return StrausMultiScalarMul(scalars, points)
+	}
+	return PippengerMultiScalarMul(scalars, points)
+}
+
+// VartimeMultiScalarMul calculates sum(scalars[i] * points[i]), in variable-time.
+//
+// The time taken by this function depends on the value of the scalars, so this
+// must never be used with secret scalars.
+//
+// The two slices must have the same non-zero length, otherwise this function panics.
+//
+// If the Curve implements MultiScalarMuler, that implementation is used. Otherwise,
+// we use VartimePippengerMultiScalarMul.
+func VartimeMultiScalarMul(scalars []Scalar, points []Point) Point {
+	checkMultiScalarMulArgs(scalars, points)
+	if curve, ok := points[0].Curve().(MultiScalarMuler); ok {
+		return curve.VartimeMultiScalarMul(scalars, points)
+	}
+	return VartimePippengerMultiScalarMul(scalars, points)
+}
+
+// naiveMultiScalarMul calculates sum(scalars[i] * points[i]), using one Act per term.
+func naiveMultiScalarMul(scalars []Scalar, points []Point) Point {
+	acc := points[0].Curve().NewPoint()
+	for i, s := range scalars {
+		acc = acc.Add(s.Act(points[i]))
+	}
+	return acc
+}
+
+// scalarBytes returns the Big Endian bytes of each scalar.
+func scalarBytes(scalars []Scalar) [][]byte {
+	out := make([][]byte, len(scalars))
+	for i, s := range scalars {
+		// Implementations of Scalar are expected to marshal without failure.
+		out[i], _ = s.MarshalBinary()
+	}
+	return out
+}
+
+// window extracts the bits [start, start + width) of a Big Endian number.
+//
+// Bits past the end of the number are treated as 0. The time this takes only
+// depends on the length of data, and not its contents.
+func window(data []byte, start, width int) uint {
+	var out uint
+	for i := 0; i < width; i++ {
+		bit := start + i
+		byteIndex := len(data) - 1 - bit/8
+		if byteIndex < 0 {
+			break
+		}
+		out |= uint((data[byteIndex]>>(bit%8))&1) << i
+	}
+	return out
+}
+
+// doubleN calculates 2^n * p.
+func doubleN(p Point, n int) Point {
+	for i := 0; i < n; i++ {
+		p = p.Add(p)
+	}
+	return p
+}
+
+// strausWindow is the number of bits processed at a time in StrausMultiScalarMul.
+const strausWindow = 4
+
+// StrausMultiScalarMul calculates sum(scalars[i] * points[i]), in constant-time, using Straus' method.
+//
+// This uses a fixed window, with a table of multiples for each point, read in constant-time.
+// This requires the points to implement SelectablePoint. If they don't, we fall back
+// to summing the results of Scalar.Act, which is expected to be constant-time.
+//
+// The two slices must have the same non-zero length, otherwise this function panics.
+func StrausMultiScalarMul(scalars []Scalar, points []Point) Point {
+	checkMultiScalarMulArgs(scalars, points)
+	curve := points[0].Curve()
+	if _, ok := points[0].(SelectablePoint); !ok {
+		return naiveMultiScalarMul(scalars, points)
+	}
+
+	// tables[i][j] = j * points[i]
+	tables := make([][1 << strausWindow]SelectablePoint, len(points))
+	for i, p := range points {
+		tables[i][0] = curve.NewPoint().(SelectablePoint)
+		for j := 1; j < len(tables[i]); j++ {
+			tables[i][j] = tables[i][j-1].Add(p).(SelectablePoint)
+		}
+	}
+
+	data := scalarBytes(scalars)
+	windows := (curve.ScalarBits() + strausWindow - 1) / strausWindow
+	acc := curve.NewPoint()
+	for w := windows - 1; w >= 0; w-- {
+		acc = doubleN(acc, strausWindow)
+		for i := range points {
+			d := window(data[i], w*strausWindow, strausWindow)
+			selected := tables[i][0]
+			for j := 1; j < len(tables[i]); j++ {
+				yes := saferith.Choice(subtle.ConstantTimeEq(int32(d), int32(j)))
+				selected = selected.CondSelect(yes, tables[i][j]).(SelectablePoint)
+			}
+			acc = acc.Add(selected)
+		}
+	}
+	return acc
+}
+
+// pippengerThreshold is the number of points from which MultiScalarMul uses Pippenger's method.
+const pippengerThreshold = 64
+
+// pippengerCTWindow is the window size for PippengerMultiScalarMul.
+//
+// Since each point scans through every bucket, the cost per point grows with
+// the number of buckets, so the window stays small, regardless of the number of points.
+const pippengerCTWindow = 3
+
+// signedDigits recodes a Big Endian number into digits in [-2^(c - 1), 2^(c - 1)], of c bits each.
+//
+// The number is then sum(digits[i] * 2^(c * i)). The output has one more digit
+// than the number has windows, to absorb the final carry. This runs in constant-time.
+func signedDigits(data []byte, bits, c int) []int32 {
+	windows := (bits + c - 1) / c
+	out := make([]int32, windows+1)
+	var carry int32
+	for w := 0; w < windows; w++ {
+		d := int32(window(data, w*c, c)) + carry
+		// carry = 1 if d > 2^(c - 1), in which case we use d - 2^c instead.
+		carry = ((1 << (c - 1)) - d) >> 31 & 1
+		out[w] = d - carry<<c
+	}
+	out[windows] = carry
+	return out
+}
+
+// PippengerMultiScalarMul calculates sum(scalars[i] * points[i]), in constant-time, using Pippenger's bucket method.
+//
+// The scalars are recoded into signed digits, so that only half as many buckets
+// are needed. Each point, negated if its digit is negative, is added to the bucket
+// for the absolute value of its digit. That bucket is read, and written back,
+// by scanning through every bucket with CondSelect, so that the memory access
+// pattern doesn't depend on the scalars. Unlike VartimePippengerMultiScalarMul,
+// every bucket starts at the identity, and is always included in the sums.
+//
+// This requires the points to implement SelectablePoint. If they don't, we fall
+// back to summing the results of Scalar.Act, which is expected to be constant-time.
+//
+// The two slices must have the same non-zero length, otherwise this function panics.
+func PippengerMultiScalarMul(scalars []Scalar, points []Point) Point {
+	checkMultiScalarMulArgs(scalars, points)
+	curve := points[0].Curve()
+	identity, ok := curve.NewPoint().(SelectablePoint)
+	if !ok {
+		return naiveMultiScalarMul(scalars, points)
+	}
+	c := pippengerCTWindow
+	digits := make([][]int32, len(scalars))
+	for i, data := range scalarBytes(scalars) {
+		digits[i] = signedDigits(data, curve.ScalarBits(), c)
+	}
+	negated := make([]Point, len(points))
+	for i, p := range points {
+		negated[i] = p.Negate()
+	}
+
+	// buckets[0] collects the points with a digit of 0, and is never used.
+	buckets := make([]SelectablePoint, 1<<(c-1)+1)
+	acc := curve.NewPoint()
+	for w := len(digits[0]) - 1; w >= 0; w-- {
+		acc = doubleN(acc, c)
+		for j := range buckets {
+			buckets[j] = identity
+		}
+		for i, p := range points {
+			d := digits[i][w]
+			sign := d >> 31
+			abs := (d ^ sign) - sign
+			selected := buckets[0]
+			for j := 1; j < len(buckets); j++ {
+				yes := saferith.Choice(subtle.ConstantTimeEq(abs, int32(j)))
+				selected = selected.CondSelect(yes, buckets[j]).(SelectablePoint)
+			}
+			sum := selected.Add(p.(SelectablePoint).CondSelect(saferith.Choice(sign&1), negated[i]))
+			for j := range buckets {
+				yes := saferith.Choice(subtle.ConstantTimeEq(abs, int32(j)))
+				buckets[j] = buckets[j].CondSelect(yes, sum).(SelectablePoint)
+			}
+		}
+		// sum(j * buckets[j]) = sum over j of (buckets[j] + buckets[j + 1] + ...)
+		var running, total Point = identity, identity
+		for j := len(buckets) - 1; j >= 1; j-- {
+			running = running.Add(buckets[j])
+			total = total.Add(running)
+		}
+		acc = acc.Add(total)
+	}
+	return acc
+}
+
+// pippengerWindow chooses the window size for Pippenger's method, based on the number of points.
+func pippengerWindow(n int) int {
+	c := bits.Len(uint(n)) - 2
+	if c < 2 {
+		return 2
+	}
+	if c > 16 {
+		return 16
+	}
+	return c
+}
+
+// VartimePippengerMultiScalarMul calculates sum(scalars[i] * points[i]), using Pippenger's bucket method.
+//
+// The time taken by this function depends on the value of the scalars, so this
+// must never be used with secret scalars.
+//
+// The two slices must have the same non-zero length, otherwise this function panics.
+func VartimePippengerMultiScalarMul(scalars []Scalar, points []Point) Point {
+	checkMultiScalarMulArgs(scalars, points)
+	curve := points[0].Curve()
+	c := pippengerWindow(len(points))
+	data := scalarBytes(scalars)
+	windows := (curve.ScalarBits() + c - 1) / c
+
+	// A nil bucket stands in for the identity, letting us skip useless additions.
+	buckets := make([]Point, 1<<c)
+	acc := curve.NewPoint()
+	for w := windows - 1; w >= 0; w-- {
+		acc = doubleN(acc, c)
+		for i := range buckets {
+			buckets[i] = nil
+		}
+		for i, p := range points {
+			d := window(data[i], w*c, c)
+			if d == 0 {
+				continue
+			}
+			if buckets[d] == nil {
+				buckets[d] = p
+			} else {
+				buckets[d] = buckets[d].Add(p)
+			}
+		}
+		// sum(j * buckets[j]) = sum over j of (buckets[j] + buckets[j + 1] + ...)
+		var running, total Point
+		for j := len(buckets) - 1; j >= 1; j-- {
+			if buckets[j] != nil {
+				if running == nil {
+					running = buckets[j]
+				} else {
+					running = running.Add(buckets[j])
+				}
+			}
+			if running != nil {
+				if total == nil {
+					total = running
+				} else {
+					total = total.Add(running)
+				}
+			}
+		}
+		if total != nil {
+			acc = acc.Add(total)
+		}
+	}
+	return acc
+}
@@ -0,0 +1,164 @@
+package kyokusen_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/secp256k1"
+	"github.com/cronokirby/saferith"
+)
+
+func randomScalar(curve kyokusen.Curve) kyokusen.Scalar {
+	data := make([]byte, curve.SafeScalarBytes())
+	if _, err := rand.Read(data); err != nil {
+		panic(err)
+	}
+	return curve.NewScalar().SetNat(new(saferith.Nat).SetBytes(data))
+}
+
+func randomInputs(curve kyokusen.Curve, n int) ([]kyokusen.Scalar, []kyokusen.Point) {
+	scalars := make([]kyokusen.Scalar, n)
+	points := make([]kyokusen.Point, n)
+	for i := range scalars {
+		scalars[i] = randomScalar(curve)
+		points[i] = randomScalar(curve).ActOnBase()
+	}
+	return scalars, points
+}
+
+func naive(scalars []kyokusen.Scalar, points []kyokusen.Point) kyokusen.Point {
+	acc := points[0].Curve().NewPoint()
+	for i, s := range scalars {
+		acc = acc.Add(s.Act(points[i]))
+	}
+	return acc
+}
+
+func TestMultiScalarMulMatchesNaive(t *testing.T) {
+	curve := secp256k1.Curve{}
+	for _, n := range []int{1, 2, 5, 17} {
+		scalars, points := randomInputs(curve, n)
+		expected := naive(scalars, points)
+		if !kyokusen.MultiScalarMul(scalars, points).Equal(expected) {
+			t.Errorf("n = %d: MultiScalarMul doesn't match naive computation", n)
+		}
+		if !kyokusen.VartimeMultiScalarMul(scalars, points).Equal(expected) {
+			t.Errorf("n = %d: VartimeMultiScalarMul doesn't match naive computation", n)
+		}
+	}
+}
+
+func TestConstantTimeAlgorithmsMatchNaive(t *testing.T) {
+	curve := secp256k1.Curve{}
+	for _, n := range []int{1, 2, 5, 17} {
+		scalars, points := randomInputs(curve, n)
+		expected := naive(scalars, points)
+		if !kyokusen.StrausMultiScalarMul(scalars, points).Equal(expected) {
+			t.Errorf("n = %d: StrausMultiScalarMul doesn't match naive computation", n)
+		}
+		if !kyokusen.PippengerMultiScalarMul(scalars, points).Equal(expected) {
+			t.Errorf("n = %d: PippengerMultiScalarMul doesn't match naive computation", n)
+		}
+	}
+}
+
+func TestMultiScalarMulEdgeCases(t *testing.T) {
+	curve := secp256k1.Curve{}
+	zero := curve.NewScalar()
+	one := curve.NewScalar().SetNat(new(saferith.Nat).SetUint64(1))
+	minusOne := curve.NewScalar().Set(one).Negate()
+	g := curve.NewBasePoint()
+
+	scalars := []kyokusen.Scalar{zero, one, minusOne, one}
+	points := []kyokusen.Point{g, g, g, curve.NewPoint()}
+	if !kyokusen.MultiScalarMul(scalars, points).IsIdentity() {
+		t.Error("MultiScalarMul should cancel out to the identity")
+	}
+	if !kyokusen.VartimeMultiScalarMul(scalars, points).IsIdentity() {
+		t.Error("VartimeMultiScalarMul should cancel out to the identity")
+	}
+	if !kyokusen.PippengerMultiScalarMul(scalars, points).IsIdentity() {
+		t.Error("PippengerMultiScalarMul should cancel out to the identity")
+	}
+}
+
+func TestMultiScalarMulPanicsOnMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	curve := secp256k1.Curve{}
+	kyokusen.MultiScalarMul([]kyokusen.Scalar{curve.NewScalar()}, nil)
+}
+
+// overrideCurve wraps secp256k1, recording calls to its MultiScalarMuler implementation.
+type overrideCurve struct {
+	secp256k1.Curve
+	calls *int
+}
+
+func (c overrideCurve) MultiScalarMul(scalars []kyokusen.Scalar, points []kyokusen.Point) kyokusen.Point {
+	*c.calls++
+	return c.NewPoint()
+}
+
+func (c overrideCurve) VartimeMultiScalarMul(scalars []kyokusen.Scalar, points []kyokusen.Point) kyokusen.Point {
+	*c.calls++
+	return c.NewPoint()
+}
+
+type overridePoint struct {
+	*secp256k1.Point
+	curve overrideCurve
+}
+
+func (p overridePoint) Curve() kyokusen.Curve {
+	return p.curve
+}
+
+func TestMultiScalarMulUsesOverride(t *testing.T) {
+	curve := overrideCurve{calls: new(int)}
+	scalars, points := randomInputs(curve.Curve, 1)
+	points[0] = overridePoint{points[0].(*secp256k1.Point), curve}
+	kyokusen.MultiScalarMul(scalars, points)
+	kyokusen.VartimeMultiScalarMul(scalars, points)
+	if *curve.calls != 2 {
+		t.Errorf("expected override to be called twice, got %d", *curve.calls)
+	}
+}
+
+var benchmarkSizes = []int{1, 8, 64, 256}
+
+func benchmarkMultiScalarMul(b *testing.B, msm func([]kyokusen.Scalar, []kyokusen.Point) kyokusen.Point) {
+	for _, n := range benchmarkSizes {
+		scalars, points := randomInputs(secp256k1.Curve{}, n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				msm(scalars, points)
+			}
+		})
+	}
+}
+
+func BenchmarkNaiveMultiScalarMul(b *testing.B) {
+	benchmarkMultiScalarMul(b, naive)
+}
+
+func BenchmarkMultiScalarMul(b *testing.B) {
+	benchmarkMultiScalarMul(b, kyokusen.MultiScalarMul)
+}
+
+func BenchmarkVartimeMultiScalarMul(b *testing.B) {
+	benchmarkMultiScalarMul(b, kyokusen.VartimeMultiScalarMul)
+}
+
+func BenchmarkStrausMultiScalarMul(b *testing.B) {
+	benchmarkMultiScalarMul(b, kyokusen.StrausMultiScalarMul)
+}
+
+func BenchmarkPippengerMultiScalarMul(b *testing.B) {
+	benchmarkMultiScalarMul(b, kyokusen.PippengerMultiScalarMul)
+}
@@ -0,0 +1,91 @@
+// Package p256 implements the NIST P-256 curve, also known as secp256r1 or prime256v1.
+//
+// The arithmetic is shared with the other NIST curves, in internal/nist.
+package p256
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/nist"
+	"github.com/cronokirby/saferith"
+)
+
+// FieldBytes is the number of bytes in the field.
+const FieldBytes = 32
+
+// ScalarBytes is the number of bytes in the encoding of a scalar.
+const ScalarBytes = 32
+
+// CompressedBytes is the size of the SEC1 compressed encoding of a point.
+const CompressedBytes = 1 + FieldBytes
+
+// UncompressedBytes is the size of the SEC1 uncompressed encoding of a point.
+const UncompressedBytes = 1 + 2*FieldBytes
+
+var curve = nist.NewCurve(nist.Params{
+	Curve:       Curve{},
+	Name:        "P-256",
+	P:           "FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFF",
+	Q:           "FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551",
+	B:           "5AC635D8AA3A93E7B3EBBD55769886BC651D06B0CC53B0F63BCE3C3E27D2604B",
+	Gx:          "6B17D1F2E12C4247F8BCE6E563A440F277037D812DEB33A0F4A13945D898C296",
+	Gy:          "4FE342E2FE1A7F9B8EE7EB4A7C0F9E162BCE33576B315ECECBB6406837BF51F5",
+	FieldBytes:  FieldBytes,
+	ScalarBytes: ScalarBytes,
+	// The order of P-256 isn't close to 2^256, so we need extra bytes to avoid bias.
+	SafeScalarBytes: 64,
+})
+
+// Field represents an element in the prime field used by P-256.
+type Field = nist.Field
+
+// Point represents a point on the P-256 curve.
+type Point = nist.Point
+
+// Scalar represents an integer modulo the order of the P-256 group.
+type Scalar = nist.Scalar
+
+// NewField creates a new field element, with its value set to 0.
+func NewField() *Field {
+	return curve.NewField()
+}
+
+// NewPoint returns the P-256 identity point.
+func NewPoint() *Point {
+	return curve.NewPoint()
+}
+
+// NewScalar returns a new scalar, with its value set to 0.
+func NewScalar() *Scalar {
+	return curve.NewScalar()
+}
+
+// Curve represents the P-256 curve, implementing the kyokusen.Curve interface.
+type Curve struct{}
+
+func (Curve) NewPoint() kyokusen.Point {
+	return curve.NewPoint()
+}
+
+func (Curve) NewBasePoint() kyokusen.Point {
+	return curve.NewBasePoint()
+}
+
+func (Curve) NewScalar() kyokusen.Scalar {
+	return curve.NewScalar()
+}
+
+func (Curve) Name() string {
+	return curve.Name()
+}
+
+func (Curve) ScalarBits() int {
+	return curve.ScalarBits()
+}
+
+func (Curve) SafeScalarBytes() int {
+	return curve.SafeScalarBytes()
+}
+
+func (Curve) Order() *saferith.Modulus {
+	return curve.Order()
+}
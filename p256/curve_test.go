@@ -0,0 +1,11 @@
+package p256
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/kyokusentest"
+)
+
+func TestConformance(t *testing.T) {
+	kyokusentest.TestCurve(t, Curve{})
+}
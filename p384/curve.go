@@ -0,0 +1,90 @@
+// Package p384 implements the NIST P-384 curve, also known as secp384r1.
+//
+// The arithmetic is shared with the other NIST curves, in internal/nist.
+package p384
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/nist"
+	"github.com/cronokirby/saferith"
+)
+
+// FieldBytes is the number of bytes in the field.
+const FieldBytes = 48
+
+// ScalarBytes is the number of bytes in the encoding of a scalar.
+const ScalarBytes = 48
+
+// CompressedBytes is the size of the SEC1 compressed encoding of a point.
+const CompressedBytes = 1 + FieldBytes
+
+// UncompressedBytes is the size of the SEC1 uncompressed encoding of a point.
+const UncompressedBytes = 1 + 2*FieldBytes
+
+var curve = nist.NewCurve(nist.Params{
+	Curve:           Curve{},
+	Name:            "P-384",
+	P:               "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFFFF0000000000000000FFFFFFFF",
+	Q:               "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFC7634D81F4372DDF581A0DB248B0A77AECEC196ACCC52973",
+	B:               "B3312FA7E23EE7E4988E056BE3F82D19181D9C6EFE8141120314088F5013875AC656398D8A2ED19D2A85C8EDD3EC2AEF",
+	Gx:              "AA87CA22BE8B05378EB1C71EF320AD746E1D3B628BA79B9859F741E082542A385502F25DBF55296C3A545E3872760AB7",
+	Gy:              "3617DE4A96262C6F5D9E98BF9292DC29F8F41DBD289A147CE9DA3113B5F0B8C00A60B1CE1D7E819D7A431D7C90EA0E5F",
+	FieldBytes:      FieldBytes,
+	ScalarBytes:     ScalarBytes,
+	SafeScalarBytes: 80,
+})
+
+// Field represents an element in the prime field used by P-384.
+type Field = nist.Field
+
+// Point represents a point on the P-384 curve.
+type Point = nist.Point
+
+// Scalar represents an integer modulo the order of the P-384 group.
+type Scalar = nist.Scalar
+
+// NewField creates a new field element, with its value set to 0.
+func NewField() *Field {
+	return curve.NewField()
+}
+
+// NewPoint returns the P-384 identity point.
+func NewPoint() *Point {
+	return curve.NewPoint()
+}
+
+// NewScalar returns a new scalar, with its value set to 0.
+func NewScalar() *Scalar {
+	return curve.NewScalar()
+}
+
+// Curve represents the P-384 curve, implementing the kyokusen.Curve interface.
+type Curve struct{}
+
+func (Curve) NewPoint() kyokusen.Point {
+	return curve.NewPoint()
+}
+
+func (Curve) NewBasePoint() kyokusen.Point {
+	return curve.NewBasePoint()
+}
+
+func (Curve) NewScalar() kyokusen.Scalar {
+	return curve.NewScalar()
+}
+
+func (Curve) Name() string {
+	return curve.Name()
+}
+
+func (Curve) ScalarBits() int {
+	return curve.ScalarBits()
+}
+
+func (Curve) SafeScalarBytes() int {
+	return curve.SafeScalarBytes()
+}
+
+func (Curve) Order() *saferith.Modulus {
+	return curve.Order()
+}
@@ -0,0 +1,16 @@
+//go:build dudect
+
+package p384
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/internal/dudect"
+)
+
+// These check that operations on secret values don't leak timing information:
+//
+//	go test -tags dudect -run ConstantTime -v ./p384
+func TestConstantTime(t *testing.T) {
+	dudect.TestCurve(t, Curve{})
+}
@@ -0,0 +1,90 @@
+// Package p521 implements the NIST P-521 curve, also known as secp521r1.
+//
+// The arithmetic is shared with the other NIST curves, in internal/nist.
+package p521
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/nist"
+	"github.com/cronokirby/saferith"
+)
+
+// FieldBytes is the number of bytes in the field.
+const FieldBytes = 66
+
+// ScalarBytes is the number of bytes in the encoding of a scalar.
+const ScalarBytes = 66
+
+// CompressedBytes is the size of the SEC1 compressed encoding of a point.
+const CompressedBytes = 1 + FieldBytes
+
+// UncompressedBytes is the size of the SEC1 uncompressed encoding of a point.
+const UncompressedBytes = 1 + 2*FieldBytes
+
+var curve = nist.NewCurve(nist.Params{
+	Curve:           Curve{},
+	Name:            "P-521",
+	P:               "1FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+	Q:               "1FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFA51868783BF2F966B7FCC0148F709A5D03BB5C9B8899C47AEBB6FB71E91386409",
+	B:               "0051953EB9618E1C9A1F929A21A0B68540EEA2DA725B99B315F3B8B489918EF109E156193951EC7E937B1652C0BD3BB1BF073573DF883D2C34F1EF451FD46B503F00",
+	Gx:              "00C6858E06B70404E9CD9E3ECB662395B4429C648139053FB521F828AF606B4D3DBAA14B5E77EFE75928FE1DC127A2FFA8DE3348B3C1856A429BF97E7E31C2E5BD66",
+	Gy:              "011839296A789A3BC0045C8A5FB42C7D1BD998F54449579B446817AFBD17273E662C97EE72995EF42640C550B9013FAD0761353C7086A272C24088BE94769FD16650",
+	FieldBytes:      FieldBytes,
+	ScalarBytes:     ScalarBytes,
+	SafeScalarBytes: 98,
+})
+
+// Field represents an element in the prime field used by P-521.
+type Field = nist.Field
+
+// Point represents a point on the P-521 curve.
+type Point = nist.Point
+
+// Scalar represents an integer modulo the order of the P-521 group.
+type Scalar = nist.Scalar
+
+// NewField creates a new field element, with its value set to 0.
+func NewField() *Field {
+	return curve.NewField()
+}
+
+// NewPoint returns the P-521 identity point.
+func NewPoint() *Point {
+	return curve.NewPoint()
+}
+
+// NewScalar returns a new scalar, with its value set to 0.
+func NewScalar() *Scalar {
+	return curve.NewScalar()
+}
+
+// Curve represents the P-521 curve, implementing the kyokusen.Curve interface.
+type Curve struct{}
+
+func (Curve) NewPoint() kyokusen.Point {
+	return curve.NewPoint()
+}
+
+func (Curve) NewBasePoint() kyokusen.Point {
+	return curve.NewBasePoint()
+}
+
+func (Curve) NewScalar() kyokusen.Scalar {
+	return curve.NewScalar()
+}
+
+func (Curve) Name() string {
+	return curve.Name()
+}
+
+func (Curve) ScalarBits() int {
+	return curve.ScalarBits()
+}
+
+func (Curve) SafeScalarBytes() int {
+	return curve.SafeScalarBytes()
+}
+
+func (Curve) Order() *saferith.Modulus {
+	return curve.Order()
+}
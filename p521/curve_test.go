@@ -0,0 +1,11 @@
+package p521
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/kyokusentest"
+)
+
+func TestConformance(t *testing.T) {
+	kyokusentest.TestCurve(t, Curve{})
+}
@@ -0,0 +1,11 @@
+package pallas
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/kyokusentest"
+)
+
+func TestConformance(t *testing.T) {
+	kyokusentest.TestCurve(t, Curve{})
+}
@@ -0,0 +1,20 @@
+package rfc6979
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/cronokirby/saferith"
+)
+
+// These tests use unexported functions, so they live in this package, unlike
+// the ones in rfc6979_test.go, which use curves importing this package.
+
+func TestBits2Int(t *testing.T) {
+	// With qlen = 163, bits2int keeps the leftmost 163 bits of the 256 bit input.
+	data, _ := hex.DecodeString("AF2BDBE1AA9B6EC1E2ADE1D694F41FC71A831D0268E9891562113D8A62ADD1BF")
+	expected, _ := new(saferith.Nat).SetHex("05795EDF0D54DB760F156F0EB4A7A0FE38D418E813")
+	if bits2int(data, 163).Eq(expected) != 1 {
+		t.Error("incorrect bits2int result")
+	}
+}
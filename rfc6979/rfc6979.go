@@ -0,0 +1,119 @@
+// Package rfc6979 implements deterministic nonce generation, following RFC 6979.
+//
+// The nonces produced here work with any kyokusen.Curve, and are suitable for
+// ECDSA, as well as other signature schemes that need a secret nonce scalar.
+package rfc6979
+
+import (
+	"crypto/hmac"
+	"hash"
+	"io"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// ExtraBytes is the amount of randomness used by Hedged.
+const ExtraBytes = 32
+
+// bits2int converts a string of bytes into a number of at most qlen bits.
+//
+// This follows section 2.3.2 of RFC 6979.
+func bits2int(data []byte, qlen int) *saferith.Nat {
+	out := new(saferith.Nat).SetBytes(data)
+	if excess := 8*len(data) - qlen; excess > 0 {
+		out.Rsh(out, uint(excess), qlen)
+	} else {
+		out.Resize(qlen)
+	}
+	return out
+}
+
+// int2octets converts a number into a string of rlen bytes.
+//
+// This follows section 2.3.3 of RFC 6979.
+func int2octets(x *saferith.Nat, rlen int) []byte {
+	return x.FillBytes(make([]byte, rlen))
+}
+
+// bits2octets converts a string of bytes into a number modulo q, and then back into bytes.
+//
+// This follows section 2.3.4 of RFC 6979.
+func bits2octets(data []byte, q *saferith.Modulus, rlen int) []byte {
+	z := bits2int(data, q.BitLen())
+	z.Mod(z, q)
+	return int2octets(z, rlen)
+}
+
+// Nonce derives a nonce from a secret key and a digest, following RFC 6979.
+//
+// The hash function h is used for HMAC_DRBG, and should usually be the same
+// hash function used to produce the digest.
+//
+// The result is always a non-zero Scalar.
+func Nonce(h func() hash.Hash, secret kyokusen.Scalar, digest []byte) kyokusen.Scalar {
+	return NonceWithExtra(h, secret, digest, nil)
+}
+
+// NonceWithExtra derives a nonce from a secret key and a digest, mixing in additional data.
+//
+// This follows the variant in section 3.6 of RFC 6979, where the extra data
+// is appended to the input of the first HMAC_DRBG step. With no extra data,
+// this is the same as Nonce.
+func NonceWithExtra(h func() hash.Hash, secret kyokusen.Scalar, digest []byte, extra []byte) kyokusen.Scalar {
+	curve := secret.Curve()
+	q := curve.Order()
+	qlen := curve.ScalarBits()
+	rlen := (qlen + 7) / 8
+
+	secretBytes, _ := secret.MarshalBinary()
+	x := int2octets(new(saferith.Nat).SetBytes(secretBytes), rlen)
+	h1 := bits2octets(digest, q, rlen)
+
+	hlen := h().Size()
+	V := make([]byte, hlen)
+	K := make([]byte, hlen)
+	for i := range V {
+		V[i] = 0x01
+	}
+
+	mac := func(key []byte, chunks ...[]byte) []byte {
+		m := hmac.New(h, key)
+		for _, chunk := range chunks {
+			m.Write(chunk)
+		}
+		return m.Sum(nil)
+	}
+
+	K = mac(K, V, []byte{0x00}, x, h1, extra)
+	V = mac(K, V)
+	K = mac(K, V, []byte{0x01}, x, h1, extra)
+	V = mac(K, V)
+
+	for {
+		T := make([]byte, 0, rlen+hlen)
+		for len(T) < rlen {
+			V = mac(K, V)
+			T = append(T, V...)
+		}
+		k := bits2int(T, qlen)
+		_, _, lt := k.CmpMod(q)
+		if lt == 1 && k.EqZero() != 1 {
+			return curve.NewScalar().SetNat(k)
+		}
+		K = mac(K, V, []byte{0x00})
+		V = mac(K, V)
+	}
+}
+
+// Hedged derives a nonce from a secret key and a digest, mixing in fresh randomness.
+//
+// This protects against fault attacks on deterministic signatures, while
+// still producing a safe nonce if the source of randomness is bad.
+func Hedged(rand io.Reader, h func() hash.Hash, secret kyokusen.Scalar, digest []byte) (kyokusen.Scalar, error) {
+	extra := make([]byte, ExtraBytes)
+	if _, err := io.ReadFull(rand, extra); err != nil {
+		return nil, err
+	}
+	return NonceWithExtra(h, secret, digest, extra), nil
+}
@@ -0,0 +1,239 @@
+package rfc6979_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/ecdsa"
+	"github.com/cronokirby/kyokusen/p256"
+	"github.com/cronokirby/kyokusen/p384"
+	"github.com/cronokirby/kyokusen/p521"
+	"github.com/cronokirby/kyokusen/rfc6979"
+	"github.com/cronokirby/kyokusen/secp256k1"
+	"github.com/cronokirby/saferith"
+)
+
+func scalarFromHex(t *testing.T, curve kyokusen.Curve, s string) kyokusen.Scalar {
+	nat, err := new(saferith.Nat).SetHex(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return curve.NewScalar().SetNat(nat)
+}
+
+func hexBytes(t *testing.T, s string) []byte {
+	out, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// RFC 6979 doesn't include vectors for secp256k1. Instead, we use the vectors
+// shared by Trezor and CoreBitcoin, which are the de facto standard for this curve.
+func TestNonceSecp256k1Vectors(t *testing.T) {
+	vectors := []struct {
+		key, msg, nonce string
+	}{
+		{
+			"CCA9FBCC1B41E5A95D369EAA6DDCFF73B61A4EFAA279CFC6567E8DAA39CBAF50",
+			"sample",
+			"2DF40CA70E639D89528A6B670D9D48D9165FDC0FEBC0974056BDCE192B8E16A3",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"Satoshi Nakamoto",
+			"8F8A276C19F4149656B280621E358CCE24F5F52542772691EE69063B74F15D15",
+		},
+		{
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364140",
+			"Satoshi Nakamoto",
+			"33A19B60E25FB6F4435AF53A3D42D493644827367E6453928554F43E49AA6F90",
+		},
+		{
+			"F8B8AF8CE3C7CCA5E300D33939540C10D45CE001B8F252BFBC57BA0342904181",
+			"Alan Turing",
+			"525A82B70E67874398067543FD84C83D30C175FDC45FDEEE082FE13B1D7CFDF1",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"All those moments will be lost in time, like tears in rain. Time to die...",
+			"38AA22D72376B4DBC472E06C3BA403EE0A394DA63FC58D88686C611ABA98D6B3",
+		},
+		{
+			"E91671C46231F833A6406CCBEA0E3E392C76C167BAC1CB013F6F1013980455C2",
+			"There is a computer disease that anybody who works with computers knows about. It's a very serious disease and it interferes completely with the work. The trouble with computers is that you 'play' with them!",
+			"1F4B84C23A86A221D233F2521BE018D9318639D5B8BBD6374A8A59232D16AD3D",
+		},
+	}
+	curve := secp256k1.Curve{}
+	for i, v := range vectors {
+		secret := scalarFromHex(t, curve, v.key)
+		digest := sha256.Sum256([]byte(v.msg))
+		k := rfc6979.Nonce(sha256.New, secret, digest[:])
+		if !k.Equal(scalarFromHex(t, curve, v.nonce)) {
+			t.Errorf("vector %d: incorrect nonce", i)
+		}
+	}
+}
+
+// These vectors come from RFC 6979, section A.2.5.
+func TestNonceP256Vectors(t *testing.T) {
+	vectors := []struct {
+		msg, nonce string
+	}{
+		{"sample", "A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60"},
+		{"test", "D16B6AE827F17175E040871A1C7EC3500192C4C92677336EC2537ACAEE0008E0"},
+	}
+	curve := p256.Curve{}
+	secret := scalarFromHex(t, curve, "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+	for i, v := range vectors {
+		digest := sha256.Sum256([]byte(v.msg))
+		k := rfc6979.Nonce(sha256.New, secret, digest[:])
+		if !k.Equal(scalarFromHex(t, curve, v.nonce)) {
+			t.Errorf("vector %d: incorrect nonce", i)
+		}
+	}
+}
+
+// rfc6979Signature is a vector from RFC 6979, given by the resulting ECDSA signature (r, s).
+type rfc6979Signature struct {
+	h    func() hash.Hash
+	msg  string
+	r, s string
+}
+
+// testNonceSignatureVectors checks the nonces against vectors from RFC 6979, for a given secret key.
+//
+// Since s = (e + r x) / k, the signature determines the nonce, as k = (e + r x) / s.
+// We check that this matches our nonce, and that r is the x coordinate of k G.
+func testNonceSignatureVectors(t *testing.T, curve kyokusen.Curve, secretHex string, vectors []rfc6979Signature) {
+	secret := scalarFromHex(t, curve, secretHex)
+	for i, v := range vectors {
+		hasher := v.h()
+		hasher.Write([]byte(v.msg))
+		digest := hasher.Sum(nil)
+		k := rfc6979.Nonce(v.h, secret, digest)
+
+		r := scalarFromHex(t, curve, v.r)
+		expected := curve.NewScalar().Set(r).Mul(secret).Add(ecdsa.DigestToScalar(curve, digest))
+		expected.Mul(scalarFromHex(t, curve, v.s).Invert())
+		if !k.Equal(expected) {
+			t.Errorf("vector %d: incorrect nonce", i)
+		}
+		if !k.ActOnBase().XScalar().Equal(r) {
+			t.Errorf("vector %d: incorrect r", i)
+		}
+	}
+}
+
+// These vectors come from RFC 6979, section A.2.6, using SHA-256 and SHA-512.
+//
+// With SHA-512, the digest is longer than the 384 bits of the order, and needs to be truncated.
+func TestNonceP384Vectors(t *testing.T) {
+	testNonceSignatureVectors(t, p384.Curve{},
+		"6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5",
+		[]rfc6979Signature{
+			{
+				sha256.New,
+				"sample",
+				"21B13D1E013C7FA1392D03C5F99AF8B30C570C6F98D4EA8E354B63A21D3DAA33BDE1E888E63355D92FA2B3C36D8FB2CD",
+				"F3AA443FB107745BF4BD77CB3891674632068A10CA67E3D45DB2266FA7D1FEEBEFDC63ECCD1AC42EC0CB8668A4FA0AB0",
+			},
+			{
+				sha512.New,
+				"sample",
+				"ED0959D5880AB2D869AE7F6C2915C6D60F96507F9CB3E047C0046861DA4A799CFE30F35CC900056D7C99CD7882433709",
+				"512C8CCEEE3890A84058CE1E22DBC2198F42323CE8ACA9135329F03C068E5112DC7CC3EF3446DEFCEB01A45C2667FDD5",
+			},
+			{
+				sha256.New,
+				"test",
+				"6D6DEFAC9AB64DABAFE36C6BF510352A4CC27001263638E5B16D9BB51D451559F918EEDAF2293BE5B475CC8F0188636B",
+				"2D46F3BECBCC523D5F1A1256BF0C9B024D879BA9E838144C8BA6BAEB4B53B47D51AB373F9845C0514EEFB14024787265",
+			},
+			{
+				sha512.New,
+				"test",
+				"A0D5D090C9980FAF3C2CE57B7AE951D31977DD11C775D314AF55F76C676447D06FB6495CD21B4B6E340FC236584FB277",
+				"976984E59B4C77B0E8E4460DCA3D9F20E07B9BB1F63BEEFAF576F6B2E8B224634A2092CD3792E0159AD9CEE37659C736",
+			},
+		})
+}
+
+// These vectors come from RFC 6979, section A.2.7, using SHA-256 and SHA-512.
+//
+// The order has 521 bits, which isn't a multiple of 8, so the outputs of HMAC_DRBG
+// need to be truncated to a number of bits that doesn't fill a whole byte.
+func TestNonceP521Vectors(t *testing.T) {
+	testNonceSignatureVectors(t, p521.Curve{},
+		"0FAD06DAA62BA3B25D2FB40133DA757205DE67F5BB0018FEE8C86E1B68C7E75CAA896EB32F1F47C70855836A6D16FCC1466F6D8FBEC67DB89EC0C08B0E996B83538",
+		[]rfc6979Signature{
+			{
+				sha256.New,
+				"sample",
+				"1511BB4D675114FE266FC4372B87682BAECC01D3CC62CF2303C92B3526012659D16876E25C7C1E57648F23B73564D67F61C6F14D527D54972810421E7D87589E1A7",
+				"04A171143A83163D6DF460AAF61522695F207A58B95C0644D87E52AA1A347916E4F7A72930B1BC06DBE22CE3F58264AFD23704CBB63B29B931F7DE6C9D949A7ECFC",
+			},
+			{
+				sha512.New,
+				"sample",
+				"0C328FAFCBD79DD77850370C46325D987CB525569FB63C5D3BC53950E6D4C5F174E25A1EE9017B5D450606ADD152B534931D7D4E8455CC91F9B15BF05EC36E377FA",
+				"0617CCE7CF5064806C467F678D3B4080D6F1CC50AF26CA209417308281B68AF282623EAA63E5B5C0723D8B8C37FF0777B1A20F8CCB1DCCC43997F1EE0E44DA4A67A",
+			},
+			{
+				sha256.New,
+				"test",
+				"00E871C4A14F993C6C7369501900C4BC1E9C7B0B4BA44E04868B30B41D8071042EB28C4C250411D0CE08CD197E4188EA4876F279F90B3D8D74A3C76E6F1E4656AA8",
+				"0CD52DBAA33B063C3A6CD8058A1FB0A46A4754B034FCC644766CA14DA8CA5CA9FDE00E88C1AD60CCBA759025299079D7A427EC3CC5B619BFBC828E7769BCD694E86",
+			},
+			{
+				sha512.New,
+				"test",
+				"13E99020ABF5CEE7525D16B69B229652AB6BDF2AFFCAEF38773B4B7D08725F10CDB93482FDCC54EDCEE91ECA4166B2A7C6265EF0CE2BD7051B7CEF945BABD47EE6D",
+				"1FBD0013C674AA79CB39849527916CE301C66EA7CE8B80682786AD60F98F7E78A19CA69EFF5C57400E3B3A0AD66CE0978214D13BAF4E9AC60752F7B155E2DE4DCE3",
+			},
+		})
+}
+
+func TestNonceWithExtraSecp256k1Vector(t *testing.T) {
+	curve := secp256k1.Curve{}
+	secret := scalarFromHex(t, curve, "0011111111111111111111111111111111111111111111111111111111111111")
+	digest := hexBytes(t, "0000000000000000000000000000000000000000000000000000000000000001")
+	extra := hexBytes(t, "0000000000000000000000000000000000000000000000000000000000000002")
+
+	k := rfc6979.Nonce(sha256.New, secret, digest)
+	if !k.Equal(scalarFromHex(t, curve, "154E92760F77AD9AF6B547EDD6F14AD0FAE023EB2221BC8BE2911675D8A686A3")) {
+		t.Error("incorrect nonce without extra data")
+	}
+	k = rfc6979.NonceWithExtra(sha256.New, secret, digest, extra)
+	if !k.Equal(scalarFromHex(t, curve, "67893461ADE51CDE61824B20BC293B585D058E6B9F40FB68453D5143F15116AE")) {
+		t.Error("incorrect nonce with extra data")
+	}
+}
+
+func TestHedgedUsesRandomness(t *testing.T) {
+	curve := secp256k1.Curve{}
+	secret := scalarFromHex(t, curve, "0011111111111111111111111111111111111111111111111111111111111111")
+	digest := sha256.Sum256([]byte("sample"))
+
+	extra := bytes.Repeat([]byte{0x02}, rfc6979.ExtraBytes)
+	k, err := rfc6979.Hedged(bytes.NewReader(extra), sha256.New, secret, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k.Equal(rfc6979.NonceWithExtra(sha256.New, secret, digest[:], extra)) {
+		t.Error("hedged nonce doesn't match nonce with the same extra data")
+	}
+	if k.Equal(rfc6979.Nonce(sha256.New, secret, digest[:])) {
+		t.Error("hedged nonce matches deterministic nonce")
+	}
+	if _, err := rfc6979.Hedged(bytes.NewReader(nil), sha256.New, secret, digest[:]); err == nil {
+		t.Error("hedged nonce should fail without randomness")
+	}
+}
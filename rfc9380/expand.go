@@ -0,0 +1,120 @@
+package rfc9380
+
+import (
+	"errors"
+	"hash"
+	"io"
+)
+
+// oversizeDSTPrefix is prepended to domain separation tags longer than 255 bytes, before hashing them.
+//
+// This follows section 5.3.3 of RFC 9380.
+const oversizeDSTPrefix = "H2C-OVERSIZE-DST-"
+
+// Expander expands a message into length uniformly random bytes, using a domain separation tag.
+//
+// This is the expand_message function from section 5.3 of RFC 9380.
+type Expander func(msg, dst []byte, length int) ([]byte, error)
+
+// XOF is an extendable output function, such as SHAKE128.
+//
+// The ShakeHash type from golang.org/x/crypto/sha3 implements this interface.
+type XOF interface {
+	io.Writer
+	io.Reader
+}
+
+// XMDExpander returns an Expander using expand_message_xmd, with a given hash function.
+func XMDExpander(h func() hash.Hash) Expander {
+	return func(msg, dst []byte, length int) ([]byte, error) {
+		return ExpandMessageXMD(h, msg, dst, length)
+	}
+}
+
+// XOFExpander returns an Expander using expand_message_xof, with a given extendable output function.
+//
+// The parameter k is the target security level of the suite, in bits.
+func XOFExpander(newXOF func() XOF, k int) Expander {
+	return func(msg, dst []byte, length int) ([]byte, error) {
+		return ExpandMessageXOF(newXOF, k, msg, dst, length)
+	}
+}
+
+// ExpandMessageXMD implements expand_message_xmd from section 5.3.1 of RFC 9380.
+//
+// This produces length uniformly random bytes, from a message and a domain
+// separation tag, using a Merkle-Damgård hash function, such as SHA-256.
+func ExpandMessageXMD(h func() hash.Hash, msg, dst []byte, length int) ([]byte, error) {
+	if len(dst) == 0 {
+		return nil, errors.New("rfc9380.ExpandMessageXMD: empty domain separation tag")
+	}
+	hasher := h()
+	bInBytes := hasher.Size()
+	sInBytes := hasher.BlockSize()
+	if len(dst) > 255 {
+		hasher.Write([]byte(oversizeDSTPrefix))
+		hasher.Write(dst)
+		dst = hasher.Sum(nil)
+	}
+	ell := (length + bInBytes - 1) / bInBytes
+	if ell > 255 || length > 65535 {
+		return nil, errors.New("rfc9380.ExpandMessageXMD: requested length is too large")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	hasher.Reset()
+	hasher.Write(make([]byte, sInBytes))
+	hasher.Write(msg)
+	hasher.Write([]byte{byte(length >> 8), byte(length), 0})
+	hasher.Write(dstPrime)
+	b0 := hasher.Sum(nil)
+
+	out := make([]byte, 0, ell*bInBytes)
+	bi := make([]byte, bInBytes)
+	for i := 1; i <= ell; i++ {
+		// b_i = H((b_0 xor b_(i - 1)) || i || DST'), where b_0 xor b_0 is taken to be b_0.
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		hasher.Reset()
+		hasher.Write(bi)
+		hasher.Write([]byte{byte(i)})
+		hasher.Write(dstPrime)
+		bi = hasher.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+	return out[:length], nil
+}
+
+// ExpandMessageXOF implements expand_message_xof from section 5.3.2 of RFC 9380.
+//
+// This produces length uniformly random bytes, from a message and a domain
+// separation tag, using an extendable output function, such as SHAKE128.
+// The parameter k is the target security level of the suite, in bits.
+func ExpandMessageXOF(newXOF func() XOF, k int, msg, dst []byte, length int) ([]byte, error) {
+	if len(dst) == 0 {
+		return nil, errors.New("rfc9380.ExpandMessageXOF: empty domain separation tag")
+	}
+	if len(dst) > 255 {
+		x := newXOF()
+		x.Write([]byte(oversizeDSTPrefix))
+		x.Write(dst)
+		dst = make([]byte, (2*k+7)/8)
+		if _, err := io.ReadFull(x, dst); err != nil {
+			return nil, err
+		}
+	}
+	if length > 65535 {
+		return nil, errors.New("rfc9380.ExpandMessageXOF: requested length is too large")
+	}
+	x := newXOF()
+	x.Write(msg)
+	x.Write([]byte{byte(length >> 8), byte(length)})
+	x.Write(dst)
+	x.Write([]byte{byte(len(dst))})
+	out := make([]byte, length)
+	if _, err := io.ReadFull(x, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package rfc9380
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// expandVectors holds the test vectors for an expander, from appendix K of RFC 9380.
+type expandVectors struct {
+	DST   string
+	K     int
+	Tests []struct {
+		LenInBytes   string `json:"len_in_bytes"`
+		Msg          string
+		UniformBytes string `json:"uniform_bytes"`
+	}
+}
+
+func checkExpandVectors(t *testing.T, path string, expand Expander) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors expandVectors
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range vectors.Tests {
+		length, _ := strconv.ParseInt(v.LenInBytes, 0, 32)
+		expected, _ := hex.DecodeString(v.UniformBytes)
+		actual, err := expand([]byte(v.Msg), []byte(vectors.DST), int(length))
+		if err != nil {
+			t.Fatalf("%s %d: %v", path, i, err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("%s %d: incorrect output %x", path, i, actual)
+		}
+	}
+}
+
+func TestExpandMessageXMDVectors(t *testing.T) {
+	checkExpandVectors(t, "testdata/expand_message_xmd_SHA256_38.json", XMDExpander(sha256.New))
+	// This uses a domain separation tag longer than 255 bytes.
+	checkExpandVectors(t, "testdata/expand_message_xmd_SHA256_256.json", XMDExpander(sha256.New))
+	checkExpandVectors(t, "testdata/expand_message_xmd_SHA512_38.json", XMDExpander(sha512.New))
+}
+
+func TestExpandMessageXOFVectors(t *testing.T) {
+	shake128 := func() XOF { return sha3.NewShake128() }
+	shake256 := func() XOF { return sha3.NewShake256() }
+	checkExpandVectors(t, "testdata/expand_message_xof_SHAKE128_36.json", XOFExpander(shake128, 128))
+	// This uses a domain separation tag longer than 255 bytes.
+	checkExpandVectors(t, "testdata/expand_message_xof_SHAKE128_256.json", XOFExpander(shake128, 128))
+	checkExpandVectors(t, "testdata/expand_message_xof_SHAKE256_36.json", XOFExpander(shake256, 256))
+}
+
+func TestExpandRejectsBadParameters(t *testing.T) {
+	xmd := XMDExpander(sha256.New)
+	xof := XOFExpander(func() XOF { return sha3.NewShake128() }, 128)
+	for name, expand := range map[string]Expander{"XMD": xmd, "XOF": xof} {
+		if _, err := expand([]byte("msg"), nil, 32); err == nil {
+			t.Errorf("%s: empty domain separation tag should be rejected", name)
+		}
+		if _, err := expand([]byte("msg"), []byte("DST"), 65536); err == nil {
+			t.Errorf("%s: length above 65535 should be rejected", name)
+		}
+	}
+}
+
+func TestExpandMessageXMDLength(t *testing.T) {
+	if _, err := ExpandMessageXMD(sha256.New, nil, []byte("test"), 256*32); err == nil {
+		t.Error("requesting too many bytes should fail")
+	}
+	out, err := ExpandMessageXMD(sha256.New, nil, make([]byte, 256), 100)
+	if err != nil || len(out) != 100 {
+		t.Error("long domain separation tags should be hashed")
+	}
+}
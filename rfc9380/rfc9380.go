@@ -0,0 +1,92 @@
+// Package rfc9380 implements hashing to elliptic curves, following RFC 9380.
+//
+// This provides the building blocks shared by every suite: expanding messages
+// into uniformly random bytes, with expand_message_xmd or expand_message_xof,
+// and hashing those bytes into a field, with hash_to_field. A Suite combines
+// these with a map from the field to a curve, to implement hash_to_curve and
+// encode_to_curve. For that map, SSWU implements the simplified SWU map over
+// any field providing the arithmetic in Element, and Isogeny implements the
+// rational maps used for curves where that map doesn't apply directly.
+//
+// Curves supporting a suite expose it through the kyokusen.HashableCurve interface.
+package rfc9380
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Suite describes a hash to curve suite, as in section 8 of RFC 9380.
+//
+// This provides both the random oracle variant of the suite, with HashToCurve,
+// and the nonuniform variant, with EncodeToCurve.
+type Suite struct {
+	// Expand is used to expand messages into uniformly random bytes.
+	Expand Expander
+	// Field is the characteristic p of the base field of the curve.
+	Field *saferith.Modulus
+	// Degree is the extension degree m of the base field, over the field of order p.
+	Degree int
+	// K is the target security level of the suite, in bits.
+	K int
+	// MapToCurve maps an element of the base field, given by its m coordinates, to a point on the curve.
+	MapToCurve func(u []*saferith.Nat) kyokusen.Point
+	// ClearCofactor sends a point on the curve into the prime order subgroup.
+	//
+	// If this is nil, kyokusen.ClearCofactor is used instead.
+	ClearCofactor func(kyokusen.Point) kyokusen.Point
+}
+
+// HashToField implements hash_to_field from section 5.2 of RFC 9380.
+//
+// This produces count elements of the base field, each given by its m
+// coordinates, reduced modulo p.
+func (s *Suite) HashToField(msg, dst []byte, count int) ([][]*saferith.Nat, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8)
+	L := (s.Field.BitLen() + s.K + 7) / 8
+	bytes, err := s.Expand(msg, dst, count*s.Degree*L)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]*saferith.Nat, count)
+	for i := range out {
+		out[i] = make([]*saferith.Nat, s.Degree)
+		for j := range out[i] {
+			offset := L * (j + i*s.Degree)
+			tv := new(saferith.Nat).SetBytes(bytes[offset : offset+L])
+			out[i][j] = tv.Mod(tv, s.Field)
+		}
+	}
+	return out, nil
+}
+
+func (s *Suite) clearCofactor(p kyokusen.Point) kyokusen.Point {
+	if s.ClearCofactor != nil {
+		return s.ClearCofactor(p)
+	}
+	return kyokusen.ClearCofactor(p)
+}
+
+// HashToCurve implements hash_to_curve from section 3 of RFC 9380.
+//
+// This is the random oracle variant of the suite: the result is
+// indistinguishable from a uniformly random point in the prime order subgroup.
+func (s *Suite) HashToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	u, err := s.HashToField(msg, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	return s.clearCofactor(s.MapToCurve(u[0]).Add(s.MapToCurve(u[1]))), nil
+}
+
+// EncodeToCurve implements encode_to_curve from section 3 of RFC 9380.
+//
+// This is the nonuniform variant of the suite, which is faster than HashToCurve,
+// but only produces a fraction of the points in the prime order subgroup.
+func (s *Suite) EncodeToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	u, err := s.HashToField(msg, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return s.clearCofactor(s.MapToCurve(u[0])), nil
+}
@@ -0,0 +1,110 @@
+package rfc9380
+
+import "github.com/cronokirby/saferith"
+
+// Element describes the arithmetic which SSWU and Isogeny need from a field.
+//
+// E is the pointer type *T of the elements, as a separate parameter so that the
+// methods can refer to it. Every method modifies its receiver, and returns it,
+// except for EqZero. Invert should send 0 to 0, and the zero value of T should
+// be usable as the receiver of Set.
+type Element[T, E any] interface {
+	*T
+	Set(x E) E
+	Add(a E) E
+	Mul(a E) E
+	Square() E
+	Negate() E
+	Invert() E
+	CondAssign(yes saferith.Choice, x E) E
+	EqZero() saferith.Choice
+}
+
+// SSWU implements the simplified SWU map, for a curve y^2 = x^3 + A x + B, with A and B non-zero.
+//
+// This is map_to_curve_simple_swu, from section 6.6.2 of RFC 9380. Curves with
+// A = 0 or B = 0 can use this map on an isogenous curve, followed by an Isogeny.
+type SSWU[T any, E Element[T, E]] struct {
+	a, b, z E
+	// c1 = -B / A, and c2 = B / (Z A), which don't depend on the input.
+	c1, c2 E
+	sqrt   func(E) saferith.Choice
+	sgn0   func(E) saferith.Choice
+}
+
+// NewSSWU creates the simplified SWU map for the curve y^2 = x^3 + A x + B.
+//
+// The constant z must be a non-square satisfying the criteria from section 6.6.2.
+//
+// The field's square root and sign are passed as functions, since their
+// signatures vary between implementations. The function sqrt sets its argument
+// to a square root of itself, returning 1 if one exists, and can leave any
+// value in its argument otherwise. The function sgn0 implements sgn0 from
+// section 4.1. Both need to run in constant-time.
+func NewSSWU[T any, E Element[T, E]](a, b, z E, sqrt, sgn0 func(E) saferith.Choice) *SSWU[T, E] {
+	c1 := E(new(T)).Set(a).Invert().Mul(b).Negate()
+	c2 := E(new(T)).Set(z).Mul(a).Invert().Mul(b)
+	return &SSWU[T, E]{a: a, b: b, z: z, c1: c1, c2: c2, sqrt: sqrt, sgn0: sgn0}
+}
+
+// rhs calculates x^3 + A x + B.
+func (m *SSWU[T, E]) rhs(x E) E {
+	out := E(new(T)).Set(x).Square().Add(m.a).Mul(x)
+	return out.Add(m.b)
+}
+
+// Map sends a field element u to a point (x, y) on the curve, in constant-time.
+func (m *SSWU[T, E]) Map(u E) (x, y E) {
+	// tv1 = Z^2 u^4 + Z u^2
+	zu2 := E(new(T)).Set(u).Square().Mul(m.z)
+	tv1 := E(new(T)).Set(zu2).Square().Add(zu2)
+	// x1 = (-B / A) (1 + 1 / tv1), or B / (Z A) if tv1 = 0
+	x1 := E(new(T)).Set(tv1).Invert().Mul(m.c1).Add(m.c1)
+	x1.CondAssign(tv1.EqZero(), m.c2)
+	// x2 = Z u^2 x1
+	x2 := E(new(T)).Set(zu2).Mul(x1)
+
+	y1 := m.rhs(x1)
+	y2 := m.rhs(x2)
+	x1Square := m.sqrt(y1)
+	m.sqrt(y2)
+
+	x = E(new(T)).Set(x2).CondAssign(x1Square, x1)
+	y = E(new(T)).Set(y2).CondAssign(x1Square, y1)
+	negY := E(new(T)).Set(y).Negate()
+	y.CondAssign(m.sgn0(u)^m.sgn0(y), negY)
+	return x, y
+}
+
+// Isogeny is a rational map between curves, as used in section 6.6.3 of RFC 9380.
+//
+// This sends (x, y) to (XNum(x) / XDen(x), y * YNum(x) / YDen(x)), where each
+// polynomial is given by its coefficients, starting with the constant term.
+type Isogeny[T any, E Element[T, E]] struct {
+	XNum, XDen, YNum, YDen []E
+}
+
+// eval evaluates a polynomial, given by its coefficients, at x.
+func eval[T any, E Element[T, E]](coeffs []E, x E) E {
+	out := E(new(T)).Set(coeffs[len(coeffs)-1])
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		out.Mul(x).Add(coeffs[i])
+	}
+	return out
+}
+
+// Map applies the isogeny to an affine point (x, y), returning projective coordinates (X : Y : Z).
+//
+// The result is (xNum yDen : y yNum xDen : xDen yDen), which avoids inversions.
+// If either denominator vanishes, then Z = 0, and the image of the point is
+// the identity; callers need to handle this case, in constant-time.
+func (iso *Isogeny[T, E]) Map(x, y E) (X, Y, Z E) {
+	xNum := eval[T](iso.XNum, x)
+	xDen := eval[T](iso.XDen, x)
+	yNum := eval[T](iso.YNum, x)
+	yDen := eval[T](iso.YDen, x)
+	Z = E(new(T)).Set(xDen).Mul(yDen)
+	X = xNum.Mul(yDen)
+	Y = E(new(T)).Set(y).Mul(yNum).Mul(xDen)
+	return X, Y, Z
+}
@@ -0,0 +1,159 @@
+package rfc9380
+
+import (
+	"testing"
+
+	"github.com/cronokirby/saferith"
+)
+
+// toyP is a small prime, with toyP = 3 mod 4, so that square roots are easy.
+const toyP = 10007
+
+// toyField is a small field, used to check the maps against every possible input.
+//
+// None of this is constant-time, which doesn't matter for tests.
+type toyField struct {
+	v uint64
+}
+
+func toy(v uint64) *toyField {
+	return &toyField{v % toyP}
+}
+
+func (z *toyField) Set(x *toyField) *toyField {
+	z.v = x.v
+	return z
+}
+
+func (z *toyField) Add(a *toyField) *toyField {
+	z.v = (z.v + a.v) % toyP
+	return z
+}
+
+func (z *toyField) Mul(a *toyField) *toyField {
+	z.v = (z.v * a.v) % toyP
+	return z
+}
+
+func (z *toyField) Square() *toyField {
+	return z.Mul(z)
+}
+
+func (z *toyField) Negate() *toyField {
+	z.v = (toyP - z.v) % toyP
+	return z
+}
+
+func (z *toyField) pow(e uint64) *toyField {
+	base := *z
+	z.v = 1
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			z.Mul(&base)
+		}
+		base.Square()
+	}
+	return z
+}
+
+func (z *toyField) Invert() *toyField {
+	return z.pow(toyP - 2)
+}
+
+func (z *toyField) CondAssign(yes saferith.Choice, x *toyField) *toyField {
+	if yes == 1 {
+		z.v = x.v
+	}
+	return z
+}
+
+func (z *toyField) EqZero() saferith.Choice {
+	if z.v == 0 {
+		return 1
+	}
+	return 0
+}
+
+func (z *toyField) eq(x *toyField) bool {
+	return z.v == x.v
+}
+
+func toySqrt(z *toyField) saferith.Choice {
+	root := toy(z.v).pow((toyP + 1) / 4)
+	wasSquare := toy(root.v).Square().eq(z)
+	z.Set(root)
+	if wasSquare {
+		return 1
+	}
+	return 0
+}
+
+func toySgn0(z *toyField) saferith.Choice {
+	return saferith.Choice(z.v & 1)
+}
+
+func isSquare(x *toyField) bool {
+	return toySqrt(toy(x.v)) == 1
+}
+
+// findZ implements find_z_sswu from appendix H.2 of RFC 9380, by brute force.
+func findZ(a, b *toyField) *toyField {
+	g := func(x *toyField) *toyField {
+		return toy(x.v).Square().Add(a).Mul(x).Add(b)
+	}
+	for i := uint64(1); i < toyP; i++ {
+		for _, z := range []*toyField{toy(i), toy(i).Negate()} {
+			if isSquare(z) || z.eq(toy(1).Negate()) {
+				continue
+			}
+			irreducible := true
+			for x := uint64(0); x < toyP && irreducible; x++ {
+				irreducible = !g(toy(x)).eq(z)
+			}
+			if irreducible && isSquare(g(toy(b.v).Mul(toy(z.v).Mul(a).Invert()))) {
+				return z
+			}
+		}
+	}
+	panic("no valid Z")
+}
+
+func TestSSWUMapsOntoCurve(t *testing.T) {
+	a, b := toy(3), toy(5)
+	m := NewSSWU(a, b, findZ(a, b), toySqrt, toySgn0)
+	for i := uint64(0); i < toyP; i++ {
+		u := toy(i)
+		x, y := m.Map(u)
+		rhs := toy(x.v).Square().Add(a).Mul(x).Add(b)
+		if !toy(y.v).Square().eq(rhs) {
+			t.Fatalf("u = %d: (%d, %d) isn't on the curve", i, x.v, y.v)
+		}
+		if toySgn0(y) != toySgn0(u) {
+			t.Fatalf("u = %d: the sign of y = %d doesn't match", i, y.v)
+		}
+	}
+}
+
+func TestIsogenyMap(t *testing.T) {
+	// This sends (x, y) to ((x^2 + 1) / (2x), y (x + 3) / 4).
+	iso := &Isogeny[toyField, *toyField]{
+		XNum: []*toyField{toy(1), toy(0), toy(1)},
+		XDen: []*toyField{toy(0), toy(2)},
+		YNum: []*toyField{toy(3), toy(1)},
+		YDen: []*toyField{toy(4)},
+	}
+	for _, v := range []struct{ x, y uint64 }{{1, 2}, {5, 7}, {100, 9999}} {
+		x, y := toy(v.x), toy(v.y)
+		X, Y, Z := iso.Map(x, y)
+		Zinv := toy(Z.v).Invert()
+		expectedX := toy(v.x*v.x + 1).Mul(toy(2 * v.x).Invert())
+		expectedY := toy(v.y).Mul(toy(v.x + 3)).Mul(toy(4).Invert())
+		if !X.Mul(Zinv).eq(expectedX) || !Y.Mul(Zinv).eq(expectedY) {
+			t.Errorf("(%d, %d) was mapped incorrectly", v.x, v.y)
+		}
+	}
+	// The denominator of x vanishes at x = 0.
+	if _, _, Z := iso.Map(toy(0), toy(1)); Z.EqZero() != 1 {
+		t.Error("x = 0 should be sent to the identity")
+	}
+}
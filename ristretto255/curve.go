@@ -0,0 +1,41 @@
+// Package ristretto255 implements the ristretto255 prime order group, following RFC 9496.
+//
+// This group is built on top of the edwards25519 curve, but hides its cofactor,
+// giving us a group of prime order with canonical encodings.
+package ristretto255
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// Curve represents the ristretto255 group, implementing the kyokusen.Curve interface.
+type Curve struct{}
+
+func (Curve) NewPoint() kyokusen.Point {
+	return NewPoint()
+}
+
+func (Curve) NewBasePoint() kyokusen.Point {
+	return NewBasePoint()
+}
+
+func (Curve) NewScalar() kyokusen.Scalar {
+	return NewScalar()
+}
+
+func (Curve) Name() string {
+	return "ristretto255"
+}
+
+func (Curve) ScalarBits() int {
+	return 253
+}
+
+func (Curve) SafeScalarBytes() int {
+	return 64
+}
+
+func (Curve) Order() *saferith.Modulus {
+	return l
+}
@@ -0,0 +1,228 @@
+package ristretto255
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/curve25519"
+	"github.com/cronokirby/saferith"
+)
+
+// PointBytes is the number of bytes in the encoding of a point.
+const PointBytes = 32
+
+// Constants from section 4.1 of RFC 9496.
+var (
+	sqrtADMinusOne  = curve25519.FieldFromHex("376931BF2B8348AC0F3CFCC931F5D1FDAF9D8E0C1B7854BD7E97F6A0497B2E1B")
+	invSqrtAMinusD  = curve25519.FieldFromHex("786C8905CFAFFCA216C27B91FE01D8409D2F16175A4172BE99C8FDAA805D40EA")
+	oneMinusDSquare = curve25519.FieldFromHex("029072A8B2B3E0D79994ABDDBE70DFE42C81A138CD5E350FE27C09C1945FC176")
+	dMinusOneSquare = curve25519.FieldFromHex("5968B37AF66C22414CDCD32F529B4EEBD29E4A2CB01E199931AD5AAA44ED4D20")
+)
+
+// Point represents an element of the ristretto255 group.
+//
+// Internally, this is a point on edwards25519, representing its equivalence class.
+type Point struct {
+	inner curve25519.Point
+}
+
+// NewPoint returns the identity element of the group.
+func NewPoint() *Point {
+	return &Point{inner: *curve25519.NewIdentity()}
+}
+
+// NewBasePoint returns the generator of the group.
+func NewBasePoint() *Point {
+	return &Point{inner: *curve25519.NewBasePoint()}
+}
+
+// castPoint converts a point implementing the generic interface to this specific type.
+//
+// Since implementors of the Point interface are only expected to work with
+// their own type, we are allowed to cast the interface at the beginning of our methods.
+func castPoint(p kyokusen.Point) *Point {
+	casted, ok := p.(*Point)
+	if !ok {
+		panic("failed to cast type to *ristretto255.Point")
+	}
+	return casted
+}
+
+func (p *Point) String() string {
+	data, _ := p.MarshalBinary()
+	return fmt.Sprintf("%x", data)
+}
+
+// MarshalBinary encodes this point into 32 bytes, following section 4.3.2 of RFC 9496.
+//
+// The encoding is canonical, and the identity point can be encoded.
+func (p *Point) MarshalBinary() ([]byte, error) {
+	x0, y0, z0, t0 := &p.inner.X, &p.inner.Y, &p.inner.Z, &p.inner.T
+
+	u1 := curve25519.NewField().Set(z0).Add(y0)
+	u1.Mul(curve25519.NewField().Set(z0).Sub(y0))
+	u2 := curve25519.NewField().Set(x0).Mul(y0)
+
+	invSqrt := curve25519.NewField()
+	invSqrt.SqrtRatio(curve25519.NewField().SetUint64(1), curve25519.NewField().Set(u2).Square().Mul(u1))
+	den1 := curve25519.NewField().Set(invSqrt).Mul(u1)
+	den2 := curve25519.NewField().Set(invSqrt).Mul(u2)
+	zInv := curve25519.NewField().Set(den1).Mul(den2).Mul(t0)
+
+	ix0 := curve25519.NewField().Set(x0).Mul(curve25519.SqrtM1)
+	iy0 := curve25519.NewField().Set(y0).Mul(curve25519.SqrtM1)
+	enchantedDenominator := curve25519.NewField().Set(den1).Mul(invSqrtAMinusD)
+
+	rotate := curve25519.NewField().Set(t0).Mul(zInv).IsNegative()
+	x := curve25519.NewField().Set(x0).CondAssign(rotate, iy0)
+	y := curve25519.NewField().Set(y0).CondAssign(rotate, ix0)
+	denInv := curve25519.NewField().Set(den2).CondAssign(rotate, enchantedDenominator)
+
+	y.CondNegate(x.Mul(zInv).IsNegative())
+	s := curve25519.NewField().Set(z0).Sub(y).Mul(denInv).Abs()
+	return s.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a point from 32 bytes, following section 4.3.1 of RFC 9496.
+//
+// This will return an error for any encoding that isn't canonical.
+func (p *Point) UnmarshalBinary(data []byte) error {
+	if len(data) != PointBytes {
+		return errors.New("ristretto255.Point.UnmarshalBinary: invalid data length")
+	}
+	s := curve25519.NewField()
+	if err := s.SetCanonicalBytes(data); err != nil {
+		return errors.New("ristretto255.Point.UnmarshalBinary: non-canonical encoding")
+	}
+	if s.IsNegative() == 1 {
+		return errors.New("ristretto255.Point.UnmarshalBinary: non-canonical encoding")
+	}
+
+	one := curve25519.NewField().SetUint64(1)
+	ss := curve25519.NewField().Set(s).Square()
+	u1 := curve25519.NewField().Set(one).Sub(ss)
+	u2 := curve25519.NewField().Set(one).Add(ss)
+	u2Square := curve25519.NewField().Set(u2).Square()
+
+	// v = -(d * u1^2) - u2^2
+	v := curve25519.NewField().Set(u1).Square().Mul(curve25519.D).Negate().Sub(u2Square)
+
+	invSqrt := curve25519.NewField()
+	wasSquare := invSqrt.SqrtRatio(one, curve25519.NewField().Set(v).Mul(u2Square))
+
+	denX := curve25519.NewField().Set(invSqrt).Mul(u2)
+	denY := curve25519.NewField().Set(invSqrt).Mul(denX).Mul(v)
+
+	x := curve25519.NewField().Set(s).Add(s).Mul(denX).Abs()
+	y := curve25519.NewField().Set(u1).Mul(denY)
+	t := curve25519.NewField().Set(x).Mul(y)
+
+	if (wasSquare & (1 ^ t.IsNegative()) & (1 ^ y.EqZero())) != 1 {
+		return errors.New("ristretto255.Point.UnmarshalBinary: invalid encoding")
+	}
+	p.inner.X.Set(x)
+	p.inner.Y.Set(y)
+	p.inner.Z.Set(one)
+	p.inner.T.Set(t)
+	return nil
+}
+
+// mapToPoint implements the MAP function from section 4.3.4 of RFC 9496.
+func mapToPoint(t *curve25519.Field) *curve25519.Point {
+	one := curve25519.NewField().SetUint64(1)
+	minusOne := curve25519.NewField().Set(one).Negate()
+	d := curve25519.D
+
+	r := curve25519.NewField().Set(t).Square().Mul(curve25519.SqrtM1)
+	u := curve25519.NewField().Set(r).Add(one).Mul(oneMinusDSquare)
+	v := curve25519.NewField().Set(r).Mul(d).Add(one).Negate()
+	v.Mul(curve25519.NewField().Set(r).Add(d))
+
+	s := curve25519.NewField()
+	wasSquare := s.SqrtRatio(u, v)
+	sPrime := curve25519.NewField().Set(s).Mul(t).Abs().Negate()
+	s.CondAssign(1^wasSquare, sPrime)
+	c := curve25519.NewField().Set(r).CondAssign(wasSquare, minusOne)
+
+	N := curve25519.NewField().Set(r).Sub(one).Mul(c).Mul(dMinusOneSquare).Sub(v)
+
+	w0 := curve25519.NewField().Set(s).Add(s).Mul(v)
+	w1 := curve25519.NewField().Set(N).Mul(sqrtADMinusOne)
+	sSquare := curve25519.NewField().Set(s).Square()
+	w2 := curve25519.NewField().Set(one).Sub(sSquare)
+	w3 := curve25519.NewField().Set(one).Add(sSquare)
+
+	var out curve25519.Point
+	out.X.Set(w0).Mul(w3)
+	out.Y.Set(w2).Mul(w1)
+	out.Z.Set(w1).Mul(w3)
+	out.T.Set(w0).Mul(w2)
+	return &out
+}
+
+// SetUniformBytes sets this point using the one-way map from 64 bytes, following
+// section 4.3.4 of RFC 9496.
+//
+// If the input is uniformly random, then so is the resulting point.
+func (p *Point) SetUniformBytes(data []byte) error {
+	if len(data) != UniformBytes {
+		return errors.New("ristretto255.Point.SetUniformBytes: invalid data length")
+	}
+	t1 := curve25519.NewField().SetBytesMasked(data[:32])
+	t2 := curve25519.NewField().SetBytesMasked(data[32:])
+	p.inner.Add(mapToPoint(t1), mapToPoint(t2))
+	return nil
+}
+
+func (*Point) Curve() kyokusen.Curve {
+	return Curve{}
+}
+
+func (p1 *Point) Add(other kyokusen.Point) kyokusen.Point {
+	p2 := castPoint(other)
+	out := NewPoint()
+	out.inner.Add(&p1.inner, &p2.inner)
+	return out
+}
+
+func (p1 *Point) Sub(other kyokusen.Point) kyokusen.Point {
+	return p1.Add(other.Negate())
+}
+
+func (p *Point) Negate() kyokusen.Point {
+	out := NewPoint()
+	out.inner.Negate(&p.inner)
+	return out
+}
+
+// Equal checks if two points are equal, following section 4.3.3 of RFC 9496.
+//
+// This runs in constant-time.
+func (p1 *Point) Equal(other kyokusen.Point) bool {
+	p2 := castPoint(other)
+	x1y2 := curve25519.NewField().Set(&p1.inner.X).Mul(&p2.inner.Y)
+	y1x2 := curve25519.NewField().Set(&p1.inner.Y).Mul(&p2.inner.X)
+	y1y2 := curve25519.NewField().Set(&p1.inner.Y).Mul(&p2.inner.Y)
+	x1x2 := curve25519.NewField().Set(&p1.inner.X).Mul(&p2.inner.X)
+	return (x1y2.Eq(y1x2) | y1y2.Eq(x1x2)) == 1
+}
+
+func (p *Point) IsIdentity() bool {
+	// The identity is represented by one of the 4 torsion points with X = 0 or Y = 0.
+	return (p.inner.X.EqZero() | p.inner.Y.EqZero()) == 1
+}
+
+// XScalar returns nil, since ristretto255 has no meaningful x coordinate.
+func (p *Point) XScalar() kyokusen.Scalar {
+	return nil
+}
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	out := &Point{inner: p.inner}
+	out.inner.CondAssign(yes, &castPoint(other).inner)
+	return out
+}
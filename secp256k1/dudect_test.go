@@ -0,0 +1,65 @@
+//go:build dudect
+
+package secp256k1
+
+// These tests check that operations on secret values don't leak timing information.
+//
+// They take a while, and can be flaky on a busy machine, so they only run with
+// the dudect build tag:
+//
+//	go test -tags dudect -run ConstantTime -v ./secp256k1
+//
+// Each test compares a fixed secret against random secrets, and logs the resulting
+// t-statistic. A test fails if that statistic goes above dudect.Threshold. The
+// operations on scalars and points are covered by dudect.TestCurve, and we check
+// the field arithmetic they rely on here.
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/cronokirby/kyokusen/internal/dudect"
+	"github.com/cronokirby/saferith"
+)
+
+// dudectField returns 0 for the fixed class, and a random field element otherwise.
+//
+// This always generates a random value, even for the fixed class, so that the work
+// done between measurements, which disturbs the caches, is the same for both.
+func dudectField(r *rand.Rand, fixed bool) *Field {
+	z := randomFieldElement(r, FieldBytes)
+	return z.CondAssign(saferithChoice(fixed), NewField())
+}
+
+func saferithChoice(b bool) saferith.Choice {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestConstantTime(t *testing.T) {
+	dudect.TestCurve(t, Curve{})
+}
+
+func TestConstantTimeFieldInvert(t *testing.T) {
+	dudect.Check(t, dudectField, func(z *Field) {
+		z.Invert()
+	})
+}
+
+func TestConstantTimeFieldSqrt(t *testing.T) {
+	dudect.Check(t, dudectField, func(z *Field) {
+		z.Sqrt()
+	})
+}
+
+func TestConstantTimeFieldUnmarshalBinary(t *testing.T) {
+	dudect.Check(t, func(r *rand.Rand, fixed bool) []byte {
+		data, _ := dudectField(r, fixed).MarshalBinary()
+		return data
+	}, func(data []byte) {
+		var z Field
+		z.UnmarshalBinary(data)
+	})
+}
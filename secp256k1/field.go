@@ -1,8 +1,11 @@
 package secp256k1
 
 import (
+	"encoding/binary"
 	"errors"
+	"math/bits"
 
+	"github.com/cronokirby/kyokusen/internal/limbs"
 	"github.com/cronokirby/saferith"
 )
 
@@ -10,17 +13,19 @@ import (
 const fieldBytes = 32
 
 // p is the modulus for the field used in secp256k1.
-var p *saferith.Modulus
+//
+// This is initialized directly, rather than in an init function, so that it's
+// available to the init functions of every other file in this package.
+var p, _ = saferith.ModulusFromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F")
 
-// pDiv2 is (p - 1) / 2, useful for checking if a value has a square root
-var pDiv2 *saferith.Nat
+// pLimbs is p, as 64 bit limbs, in little endian order.
+var pLimbs = [4]uint64{0xFFFFFFFEFFFFFC2F, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF}
 
-func init() {
-	pNat, _ := new(saferith.Nat).SetHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F")
-	p = saferith.ModulusFromNat(pNat)
-	pNat.Rsh(pNat, 1, p.BitLen())
-	pDiv2 = pNat
-}
+// pComplement is 2^256 - p = 2^32 + 977.
+//
+// Because p has this special form, we have 2^256 = pComplement mod p, which lets
+// us reduce large numbers by folding their top limbs back into the bottom ones.
+const pComplement = 0x1000003D1
 
 // FieldBytes is the number of bytes in the field.
 const FieldBytes = 32
@@ -28,33 +33,49 @@ const FieldBytes = 32
 // Field represents an element in the prime field used by secp256k1.
 //
 // This field is used later to implement point operations on the curve.
+//
+// Internally, this is represented with 4 64 bit limbs, in little endian order.
+// The value is always kept fully reduced modulo p.
 type Field struct {
-	nat saferith.Nat
+	limbs [4]uint64
 }
 
 // NewField creates a new field element, with its value set to 0.
 func NewField() *Field {
-	var nat saferith.Nat
-	// This will conveniently set the announced size, and mark this number as reduced modulo p.
-	nat.Mod(&nat, p)
-	return &Field{nat: nat}
+	return &Field{}
+}
+
+// fieldFromNat creates a new field element, from a number, which gets reduced modulo p.
+func fieldFromNat(x *saferith.Nat) *Field {
+	var reduced saferith.Nat
+	reduced.Mod(x, p)
+	z := NewField()
+	z.setBytes(reduced.FillBytes(make([]byte, FieldBytes)))
+	return z
+}
+
+// nat converts this field element into a number.
+func (z *Field) nat() *saferith.Nat {
+	bytes, _ := z.MarshalBinary()
+	return new(saferith.Nat).SetBytes(bytes)
 }
 
 // Set calculates z <- x, returning z.
 func (z *Field) Set(x *Field) *Field {
-	z.nat.SetNat(&x.nat)
+	z.limbs = x.limbs
 	return z
 }
 
 // SetUint64 calculates z <- x, returning z.
 func (z *Field) SetUint64(x uint64) *Field {
-	z.nat.SetUint64(x)
+	// Since p > 2^64, x is always reduced.
+	z.limbs = [4]uint64{x, 0, 0, 0}
 	return z
 }
 
 // CondAssign sets z <- x, only if yes = 1, in constant-time.
 func (z *Field) CondAssign(yes saferith.Choice, x *Field) *Field {
-	z.nat.CondAssign(yes, &x.nat)
+	condAssignLimbs(yes, &z.limbs, &x.limbs)
 	return z
 }
 
@@ -66,47 +87,115 @@ func (z *Field) CondNegate(yes saferith.Choice) *Field {
 
 // String returns a string representation of this field element.
 func (z *Field) String() string {
-	return z.nat.String()
+	return z.nat().String()
 }
 
 // Add calculates z <- z + a, returning z.
 func (z *Field) Add(a *Field) *Field {
-	z.nat.ModAdd(&z.nat, &a.nat, p)
-	return z
+	var l [4]uint64
+	var carry uint64
+	l[0], carry = bits.Add64(z.limbs[0], a.limbs[0], 0)
+	l[1], carry = bits.Add64(z.limbs[1], a.limbs[1], carry)
+	l[2], carry = bits.Add64(z.limbs[2], a.limbs[2], carry)
+	l[3], carry = bits.Add64(z.limbs[3], a.limbs[3], carry)
+	return z.reduce(carry, l)
 }
 
 // Add calculates z <- z + a, returning z.
 //
 // This may be faster than Add.
 func (z *Field) AddU64(a uint64) *Field {
-	z.nat.ModAdd(&z.nat, new(saferith.Nat).SetUint64(a), p)
-	return z
+	var l [4]uint64
+	var carry uint64
+	l[0], carry = bits.Add64(z.limbs[0], a, 0)
+	l[1], carry = bits.Add64(z.limbs[1], 0, carry)
+	l[2], carry = bits.Add64(z.limbs[2], 0, carry)
+	l[3], carry = bits.Add64(z.limbs[3], 0, carry)
+	return z.reduce(carry, l)
 }
 
 // Sub calculates z <- z - a, returning z.
 func (z *Field) Sub(a *Field) *Field {
-	z.nat.ModSub(&z.nat, &a.nat, p)
+	var l [4]uint64
+	var borrow uint64
+	l[0], borrow = bits.Sub64(z.limbs[0], a.limbs[0], 0)
+	l[1], borrow = bits.Sub64(z.limbs[1], a.limbs[1], borrow)
+	l[2], borrow = bits.Sub64(z.limbs[2], a.limbs[2], borrow)
+	l[3], borrow = bits.Sub64(z.limbs[3], a.limbs[3], borrow)
+	// If we borrowed, then we need to add p back, which is the same as subtracting 2^256 - p.
+	var b uint64
+	z.limbs[0], b = bits.Sub64(l[0], limbs.Mask(saferith.Choice(borrow))&pComplement, 0)
+	z.limbs[1], b = bits.Sub64(l[1], 0, b)
+	z.limbs[2], b = bits.Sub64(l[2], 0, b)
+	z.limbs[3], _ = bits.Sub64(l[3], 0, b)
 	return z
 }
 
 // Sub calculates z <- -z, returning z.
 func (z *Field) Negate() *Field {
-	z.nat.ModNeg(&z.nat, p)
+	x := *z
+	z.limbs = [4]uint64{}
+	return z.Sub(&x)
+}
+
+// reduce sets z <- (carry * 2^256 + l) mod p, assuming that this value is < 2p.
+func (z *Field) reduce(carry uint64, l [4]uint64) *Field {
+	condSubtract(&z.limbs, carry, l, &pLimbs)
 	return z
 }
 
+// reduceWide sets z <- (hi * 2^256 + lo) mod p, returning z.
+func (z *Field) reduceWide(hi, lo [4]uint64) *Field {
+	// First, we fold hi back in, using 2^256 = pComplement, giving us a 5 limb result.
+	var l [4]uint64
+	var top uint64
+	var mulHi, mulLo, carry uint64
+	for i := range l {
+		mulHi, mulLo = bits.Mul64(hi[i], pComplement)
+		l[i], carry = bits.Add64(lo[i], mulLo, 0)
+		mulHi += carry
+		l[i], carry = bits.Add64(l[i], top, 0)
+		top = mulHi + carry
+	}
+	return z.reduceNarrow(top, l)
+}
+
+// reduceNarrow sets z <- (top * 2^256 + l) mod p, where top < 2^64, returning z.
+func (z *Field) reduceNarrow(top uint64, l [4]uint64) *Field {
+	// We fold top back in. This can only carry if the bottom limbs were
+	// almost 2^256, in which case the result after folding the carry is small.
+	mulHi, mulLo := bits.Mul64(top, pComplement)
+	var carry uint64
+	l[0], carry = bits.Add64(l[0], mulLo, 0)
+	l[1], carry = bits.Add64(l[1], mulHi, carry)
+	l[2], carry = bits.Add64(l[2], 0, carry)
+	l[3], carry = bits.Add64(l[3], 0, carry)
+	l[0], carry = bits.Add64(l[0], limbs.Mask(saferith.Choice(carry))&pComplement, 0)
+	l[1], carry = bits.Add64(l[1], 0, carry)
+	l[2], carry = bits.Add64(l[2], 0, carry)
+	l[3], carry = bits.Add64(l[3], 0, carry)
+	return z.reduce(carry, l)
+}
+
 // Mul calculates z <- z * a, returning z.
 func (z *Field) Mul(a *Field) *Field {
-	z.nat.ModMul(&z.nat, &a.nat, p)
-	return z
+	r := mul256(&z.limbs, &a.limbs)
+	return z.reduceWide([4]uint64{r[4], r[5], r[6], r[7]}, [4]uint64{r[0], r[1], r[2], r[3]})
 }
 
 // MulU64 calculates z <- z * a, returning z.
 //
 // This is more efficient than Mul.
 func (z *Field) MulU64(a uint64) *Field {
-	z.nat.ModMul(&z.nat, new(saferith.Nat).SetUint64(a), p)
-	return z
+	var l [4]uint64
+	var carry uint64
+	for i := range l {
+		hi, lo := bits.Mul64(z.limbs[i], a)
+		var c uint64
+		l[i], c = bits.Add64(lo, carry, 0)
+		carry = hi + c
+	}
+	return z.reduceNarrow(carry, l)
 }
 
 // Square calculates z <- z * z, returning z.
@@ -114,20 +203,55 @@ func (z *Field) Square() *Field {
 	return z.Mul(z)
 }
 
+// squareN calculates z <- z^(2^n), returning z.
+func (z *Field) squareN(n int) *Field {
+	for i := 0; i < n; i++ {
+		z.Square()
+	}
+	return z
+}
+
+// powChain calculates the powers x^(2^n - 1) for the n used in the addition chains
+// for inversion and square roots.
+//
+// This follows the same chain as libsecp256k1, with xn = x^(2^n - 1).
+func powChain(x *Field) (x2, x3, x22, x223 *Field) {
+	x2 = NewField().Set(x).Square().Mul(x)
+	x3 = NewField().Set(x2).Square().Mul(x)
+	x6 := NewField().Set(x3).squareN(3).Mul(x3)
+	x9 := NewField().Set(x6).squareN(3).Mul(x3)
+	x11 := NewField().Set(x9).squareN(2).Mul(x2)
+	x22 = NewField().Set(x11).squareN(11).Mul(x11)
+	x44 := NewField().Set(x22).squareN(22).Mul(x22)
+	x88 := NewField().Set(x44).squareN(44).Mul(x44)
+	x176 := NewField().Set(x88).squareN(88).Mul(x88)
+	x220 := NewField().Set(x176).squareN(44).Mul(x44)
+	x223 = NewField().Set(x220).squareN(3).Mul(x3)
+	return x2, x3, x22, x223
+}
+
 // Invert calculates z <- z^-1, returning z.
+//
+// This computes z^(p - 2), using a fixed addition chain, so it runs in constant-time.
+// If z = 0, the result is 0.
 func (z *Field) Invert() *Field {
-	z.nat.ModInverse(&z.nat, p)
+	x := NewField().Set(z)
+	x2, _, x22, x223 := powChain(x)
+	z.Set(x223).squareN(23).Mul(x22)
+	z.squareN(5).Mul(x)
+	z.squareN(3).Mul(x2)
+	z.squareN(2).Mul(x)
 	return z
 }
 
 // Eq checks if two field values are equal, in constant-time.
 func (z *Field) Eq(x *Field) saferith.Choice {
-	return z.nat.Eq(&x.nat)
+	return eqLimbs(&z.limbs, &x.limbs)
 }
 
 // Eq checks if a field value is equal to 0, in constant-time.
 func (z *Field) EqZero() saferith.Choice {
-	return z.nat.EqZero()
+	return limbs.IsZero(z.limbs[0] | z.limbs[1] | z.limbs[2] | z.limbs[3])
 }
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
@@ -135,11 +259,18 @@ func (z *Field) EqZero() saferith.Choice {
 // This encodes the field element as big endian bytes. The result will always occupy
 // 32 bytes of space.
 func (z *Field) MarshalBinary() ([]byte, error) {
-	// We do this to make sure that the announced size is padded to the size of p,
-	// in case z was created wihout calling NewField, or something like that.
-	// Since z will always be reduced modulo p, this doesn't actually cost anything.
-	z.nat.Mod(&z.nat, p)
-	return z.nat.Bytes(), nil
+	out := make([]byte, FieldBytes)
+	for i, limb := range z.limbs {
+		binary.BigEndian.PutUint64(out[FieldBytes-8*(i+1):], limb)
+	}
+	return out, nil
+}
+
+// setBytes sets z to the value of 32 Big Endian bytes, without any reduction.
+func (z *Field) setBytes(data []byte) {
+	for i := range z.limbs {
+		z.limbs[i] = binary.BigEndian.Uint64(data[FieldBytes-8*(i+1):])
+	}
 }
 
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
@@ -147,30 +278,39 @@ func (z *Field) MarshalBinary() ([]byte, error) {
 // This expects exactly 32 Big Endian bytes, and will also return an error if the
 // resulting value is >= the field modulus.
 func (z *Field) UnmarshalBinary(data []byte) error {
-	if len(data) != p.BitLen()/8 {
+	if len(data) != FieldBytes {
 		return errors.New("secp256k1.Field.UnmarshalBinary: invalid data length")
 	}
-	z.nat.SetBytes(data)
-	if _, _, lt := z.nat.CmpMod(p); lt != 1 {
+	var unreduced Field
+	unreduced.setBytes(data)
+	// Reducing changes the value exactly when it is >= p.
+	var reduced Field
+	reduced.reduce(0, unreduced.limbs)
+	if reduced.Eq(&unreduced) != 1 {
 		return errors.New("secp256k1.Field.UnmarshalBinary: value is greater than field prime")
 	}
+	z.Set(&unreduced)
 	return nil
 }
 
 // HasSqrt checks if a field value has a valid square root.
 func (z *Field) HasSqrt() saferith.Choice {
-	check := new(saferith.Nat).Exp(&z.nat, pDiv2, p)
-	one := new(saferith.Nat).SetUint64(1)
-	return check.Eq(one) | check.EqZero()
+	root := NewField().Set(z).Sqrt()
+	return root.Square().Eq(z)
 }
 
 // Sqrt calculates z <- sqrt(z), if such a value exists. Otherwise, the result is undefined.
+//
+// This computes z^((p + 1) / 4), using a fixed addition chain, so it runs in constant-time.
 func (z *Field) Sqrt() *Field {
-	z.nat.ModSqrt(&z.nat, p)
+	x2, _, x22, x223 := powChain(z)
+	z.Set(x223).squareN(23).Mul(x22)
+	z.squareN(6).Mul(x2)
+	z.squareN(2)
 	return z
 }
 
 // IsEven returns a choice indicating if a field element is even.
 func (z *Field) IsEven() saferith.Choice {
-	return saferith.Choice(z.nat.Byte(0) & 1)
+	return 1 ^ saferith.Choice(z.limbs[0]&1)
 }
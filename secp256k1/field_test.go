@@ -5,18 +5,17 @@ import (
 	"reflect"
 	"testing"
 	"testing/quick"
+
+	"github.com/cronokirby/saferith"
 )
 
 func randomFieldElement(r *rand.Rand, size int) *Field {
-	out := NewField()
 	data := make([]byte, FieldBytes)
 	// Fill in a certain number of bytes with zero. Smaller sizes will be closer to zero.
 	for i := 0; i < size && i < len(data); i++ {
 		data[len(data)-i-1] = byte(r.Uint32())
 	}
-	out.nat.SetBytes(data)
-	out.nat.Mod(&out.nat, p)
-	return out
+	return fieldFromNat(new(saferith.Nat).SetBytes(data))
 }
 
 func (*Field) Generate(r *rand.Rand, size int) reflect.Value {
@@ -86,3 +85,197 @@ func TestSquareIsMultiply(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestFieldIsEven(t *testing.T) {
+	if NewField().SetUint64(2).IsEven() != 1 {
+		t.Error("2 should be even")
+	}
+	if NewField().SetUint64(3).IsEven() != 0 {
+		t.Error("3 should not be even")
+	}
+}
+
+// fieldSample is a wrapper around a field element, generating values over the whole range.
+//
+// The default generator for fields favors small values, but the interesting
+// cases for reduction happen close to p, so we also sample those.
+type fieldSample struct {
+	*Field
+}
+
+func (fieldSample) Generate(r *rand.Rand, size int) reflect.Value {
+	data := make([]byte, FieldBytes)
+	r.Read(data)
+	x := new(saferith.Nat).SetBytes(data)
+	switch r.Intn(4) {
+	case 0:
+		// Values just below p.
+		x.Sub(p.Nat(), new(saferith.Nat).SetUint64(r.Uint64()>>uint(r.Intn(64))), 256)
+	case 1:
+		// Small values.
+		x.SetUint64(r.Uint64() >> uint(r.Intn(64)))
+	}
+	return reflect.ValueOf(fieldSample{fieldFromNat(x)})
+}
+
+// refField is a reference implementation of field arithmetic, using saferith.
+type refField struct {
+	nat saferith.Nat
+}
+
+func newRefField(x *Field) *refField {
+	var out refField
+	out.nat.Mod(x.nat(), p)
+	return &out
+}
+
+func (z *refField) matches(x *Field) bool {
+	return z.nat.Eq(x.nat()) == 1
+}
+
+func TestFieldMatchesReference(t *testing.T) {
+	cases := map[string]func(a, b fieldSample) bool{
+		"Add": func(a, b fieldSample) bool {
+			var ref refField
+			ref.nat.ModAdd(&newRefField(a.Field).nat, &newRefField(b.Field).nat, p)
+			return ref.matches(NewField().Set(a.Field).Add(b.Field))
+		},
+		"AddU64": func(a, b fieldSample) bool {
+			var ref refField
+			ref.nat.ModAdd(&newRefField(a.Field).nat, new(saferith.Nat).SetUint64(b.limbs[0]), p)
+			return ref.matches(NewField().Set(a.Field).AddU64(b.limbs[0]))
+		},
+		"Sub": func(a, b fieldSample) bool {
+			var ref refField
+			ref.nat.ModSub(&newRefField(a.Field).nat, &newRefField(b.Field).nat, p)
+			return ref.matches(NewField().Set(a.Field).Sub(b.Field))
+		},
+		"Negate": func(a, _ fieldSample) bool {
+			var ref refField
+			ref.nat.ModNeg(&newRefField(a.Field).nat, p)
+			return ref.matches(NewField().Set(a.Field).Negate())
+		},
+		"Mul": func(a, b fieldSample) bool {
+			var ref refField
+			ref.nat.ModMul(&newRefField(a.Field).nat, &newRefField(b.Field).nat, p)
+			return ref.matches(NewField().Set(a.Field).Mul(b.Field))
+		},
+		"MulU64": func(a, b fieldSample) bool {
+			var ref refField
+			ref.nat.ModMul(&newRefField(a.Field).nat, new(saferith.Nat).SetUint64(b.limbs[3]), p)
+			return ref.matches(NewField().Set(a.Field).MulU64(b.limbs[3]))
+		},
+		"Square": func(a, _ fieldSample) bool {
+			var ref refField
+			ref.nat.ModMul(&newRefField(a.Field).nat, &newRefField(a.Field).nat, p)
+			return ref.matches(NewField().Set(a.Field).Square())
+		},
+		"Invert": func(a, _ fieldSample) bool {
+			var ref refField
+			ref.nat.ModInverse(&newRefField(a.Field).nat, p)
+			return ref.matches(NewField().Set(a.Field).Invert())
+		},
+		"Sqrt": func(a, _ fieldSample) bool {
+			var ref refField
+			ref.nat.ModSqrt(&newRefField(a.Field).nat, p)
+			hasSqrt := NewField().Set(a.Field).HasSqrt()
+			var refSquare saferith.Nat
+			refSquare.ModMul(&ref.nat, &ref.nat, p)
+			refHasSqrt := refSquare.Eq(&newRefField(a.Field).nat)
+			if hasSqrt != refHasSqrt {
+				return false
+			}
+			if hasSqrt == 0 {
+				return true
+			}
+			// The two roots may differ in sign.
+			root := NewField().Set(a.Field).Sqrt()
+			return ref.matches(root) || ref.matches(root.Negate())
+		},
+		"Eq": func(a, b fieldSample) bool {
+			return a.Eq(b.Field) == newRefField(a.Field).nat.Eq(&newRefField(b.Field).nat) &&
+				a.Eq(a.Field) == 1
+		},
+		"UnmarshalBinary": func(a, _ fieldSample) bool {
+			data, _ := a.MarshalBinary()
+			decoded := NewField()
+			if decoded.UnmarshalBinary(data) != nil {
+				return false
+			}
+			return decoded.Eq(a.Field) == 1
+		},
+	}
+	for name, f := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestFieldReductionEdgeCases(t *testing.T) {
+	pMinus1 := NewField().SetUint64(1).Negate()
+	if !newRefField(pMinus1).matches(NewField().SetUint64(0).Sub(NewField().SetUint64(1))) {
+		t.Error("0 - 1 should be p - 1")
+	}
+	if NewField().Set(pMinus1).AddU64(1).EqZero() != 1 {
+		t.Error("(p - 1) + 1 should be 0")
+	}
+	if NewField().Set(pMinus1).Add(pMinus1).Eq(NewField().SetUint64(2).Negate()) != 1 {
+		t.Error("(p - 1) + (p - 1) should be -2")
+	}
+	if NewField().Set(pMinus1).Square().Eq(NewField().SetUint64(1)) != 1 {
+		t.Error("(p - 1)^2 should be 1")
+	}
+	if NewField().Invert().EqZero() != 1 {
+		t.Error("0^-1 should be 0")
+	}
+	allOnes := make([]byte, FieldBytes)
+	for i := range allOnes {
+		allOnes[i] = 0xFF
+	}
+	pBytes := p.Nat().FillBytes(make([]byte, FieldBytes))
+	for _, data := range [][]byte{allOnes, pBytes} {
+		if NewField().UnmarshalBinary(data) == nil {
+			t.Errorf("%x should be rejected as a field element", data)
+		}
+	}
+	pMinus1Bytes, _ := pMinus1.MarshalBinary()
+	if NewField().UnmarshalBinary(pMinus1Bytes) != nil {
+		t.Error("p - 1 should be accepted as a field element")
+	}
+}
+
+func BenchmarkFieldMul(b *testing.B) {
+	x := randomFieldElement(rand.New(rand.NewSource(0)), FieldBytes)
+	y := randomFieldElement(rand.New(rand.NewSource(1)), FieldBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Mul(y)
+	}
+}
+
+func BenchmarkFieldSquare(b *testing.B) {
+	x := randomFieldElement(rand.New(rand.NewSource(0)), FieldBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Square()
+	}
+}
+
+func BenchmarkFieldInvert(b *testing.B) {
+	x := randomFieldElement(rand.New(rand.NewSource(0)), FieldBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Invert()
+	}
+}
+
+func BenchmarkFieldSqrt(b *testing.B) {
+	x := randomFieldElement(rand.New(rand.NewSource(0)), FieldBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Sqrt()
+	}
+}
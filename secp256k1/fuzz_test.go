@@ -0,0 +1,295 @@
+package secp256k1
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+// This file contains fuzz targets, comparing our implementation against a reference.
+//
+// The reference uses math/big, and affine coordinates, with the textbook formulas,
+// so that it's slow, but obviously correct, and shares no code with the implementation.
+//
+// The seed corpora live in testdata/fuzz, and are run as part of the regular tests.
+
+var (
+	refP = bigFromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F")
+	refQ = bigFromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
+	refB = big.NewInt(b)
+)
+
+func bigFromHex(s string) *big.Int {
+	x, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+	return x
+}
+
+// refPoint is an affine point, with nil coordinates representing the identity.
+type refPoint struct {
+	x *big.Int
+	y *big.Int
+}
+
+var refBasePoint = refPoint{
+	x: bigFromHex("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798"),
+	y: bigFromHex("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8"),
+}
+
+func (P refPoint) isIdentity() bool {
+	return P.x == nil
+}
+
+// refRHS calculates x^3 + 7 mod p.
+func refRHS(x *big.Int) *big.Int {
+	out := new(big.Int).Exp(x, big.NewInt(3), refP)
+	return out.Add(out, refB).Mod(out, refP)
+}
+
+// refDecode decodes a compressed or uncompressed point, returning false if the encoding is invalid.
+func refDecode(data []byte) (refPoint, bool) {
+	if len(data) == 65 && data[0] == 4 {
+		x := new(big.Int).SetBytes(data[1:33])
+		y := new(big.Int).SetBytes(data[33:])
+		if x.Cmp(refP) >= 0 || y.Cmp(refP) >= 0 {
+			return refPoint{}, false
+		}
+		y2 := new(big.Int).Mul(y, y)
+		if y2.Mod(y2, refP).Cmp(refRHS(x)) != 0 {
+			return refPoint{}, false
+		}
+		return refPoint{x, y}, true
+	}
+	if len(data) != 33 || (data[0] != 2 && data[0] != 3) {
+		return refPoint{}, false
+	}
+	x := new(big.Int).SetBytes(data[1:])
+	if x.Cmp(refP) >= 0 {
+		return refPoint{}, false
+	}
+	y := new(big.Int).ModSqrt(refRHS(x), refP)
+	if y == nil {
+		return refPoint{}, false
+	}
+	if y.Bit(0) != uint(data[0]&1) {
+		y.Sub(refP, y).Mod(y, refP)
+	}
+	return refPoint{x, y}, true
+}
+
+// refEncode encodes a point in compressed form, or returns nil for the identity.
+func refEncode(P refPoint) []byte {
+	if P.isIdentity() {
+		return nil
+	}
+	out := make([]byte, 33)
+	out[0] = 2 + byte(P.y.Bit(0))
+	P.x.FillBytes(out[1:])
+	return out
+}
+
+func refAdd(P, Q refPoint) refPoint {
+	if P.isIdentity() {
+		return Q
+	}
+	if Q.isIdentity() {
+		return P
+	}
+	var lambda *big.Int
+	if P.x.Cmp(Q.x) == 0 {
+		// Either Q = -P, or Q = P, and there are no points with y = 0 on this curve.
+		if P.y.Cmp(Q.y) != 0 {
+			return refPoint{}
+		}
+		// lambda = 3x^2 / 2y
+		num := new(big.Int).Mul(P.x, P.x)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(P.y, 1)
+		lambda = num.Mul(num, den.ModInverse(den, refP))
+	} else {
+		// lambda = (y2 - y1) / (x2 - x1)
+		num := new(big.Int).Sub(Q.y, P.y)
+		den := new(big.Int).Sub(Q.x, P.x)
+		den.Mod(den, refP)
+		lambda = num.Mul(num, den.ModInverse(den, refP))
+	}
+	lambda.Mod(lambda, refP)
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, P.x).Sub(x, Q.x).Mod(x, refP)
+	y := new(big.Int).Sub(P.x, x)
+	y.Mul(y, lambda).Sub(y, P.y).Mod(y, refP)
+	return refPoint{x, y}
+}
+
+// refScalarMul calculates k * P, with double and add.
+func refScalarMul(k *big.Int, P refPoint) refPoint {
+	var acc refPoint
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		acc = refAdd(acc, acc)
+		if k.Bit(i) == 1 {
+			acc = refAdd(acc, P)
+		}
+	}
+	return acc
+}
+
+// encodeOrNil marshals a point, returning nil for the identity.
+func encodeOrNil(P *Point) []byte {
+	data, err := P.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// decodeOrIdentity decodes a point, using the identity for any invalid encoding.
+//
+// This lets the fuzzer reach the identity, as well as points on the curve.
+func decodeOrIdentity(data []byte) (*Point, refPoint) {
+	ref, ok := refDecode(data)
+	P := NewPoint()
+	if ok && P.UnmarshalBinary(data) != nil {
+		panic("valid point encoding was rejected")
+	}
+	return P, ref
+}
+
+func FuzzFieldUnmarshalBinary(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		z := NewField()
+		err := z.UnmarshalBinary(data)
+		valid := len(data) == FieldBytes && new(big.Int).SetBytes(data).Cmp(refP) < 0
+		if valid != (err == nil) {
+			t.Fatalf("UnmarshalBinary(%x): got error %v, expected valid = %v", data, err, valid)
+		}
+		if !valid {
+			return
+		}
+		encoded, _ := z.MarshalBinary()
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("UnmarshalBinary(%x): roundtrip gave %x", data, encoded)
+		}
+
+		x := new(big.Int).SetBytes(data)
+		checks := []struct {
+			name     string
+			actual   *Field
+			expected *big.Int
+		}{
+			{"Square", NewField().Set(z).Square(), new(big.Int).Mul(x, x)},
+			{"Add", NewField().Set(z).Add(z), new(big.Int).Add(x, x)},
+			{"Negate", NewField().Set(z).Negate(), new(big.Int).Neg(x)},
+			{"Invert", NewField().Set(z).Invert(), new(big.Int).ModInverse(x, refP)},
+		}
+		for _, check := range checks {
+			if check.expected == nil {
+				// The inverse of 0 is 0.
+				check.expected = new(big.Int)
+			}
+			check.expected.Mod(check.expected, refP)
+			actual, _ := check.actual.MarshalBinary()
+			if !bytes.Equal(actual, check.expected.FillBytes(make([]byte, FieldBytes))) {
+				t.Errorf("%s(%x): got %x, expected %x", check.name, data, actual, check.expected)
+			}
+		}
+	})
+}
+
+func FuzzScalarUnmarshalBinary(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := NewScalar()
+		err := s.UnmarshalBinary(data)
+		valid := len(data) == FieldBytes && new(big.Int).SetBytes(data).Cmp(refQ) < 0
+		if valid != (err == nil) {
+			t.Fatalf("UnmarshalBinary(%x): got error %v, expected valid = %v", data, err, valid)
+		}
+		if !valid {
+			return
+		}
+		encoded, _ := s.MarshalBinary()
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("UnmarshalBinary(%x): roundtrip gave %x", data, encoded)
+		}
+
+		x := new(big.Int).SetBytes(data)
+		checks := []struct {
+			name     string
+			actual   *Scalar
+			expected *big.Int
+		}{
+			{"Mul", NewScalar().Set(s).Mul(s).(*Scalar), new(big.Int).Mul(x, x)},
+			{"Add", NewScalar().Set(s).Add(s).(*Scalar), new(big.Int).Add(x, x)},
+			{"Negate", NewScalar().Set(s).Negate().(*Scalar), new(big.Int).Neg(x)},
+			{"Invert", NewScalar().Set(s).Invert().(*Scalar), new(big.Int).ModInverse(x, refQ)},
+		}
+		for _, check := range checks {
+			if check.expected == nil {
+				check.expected = new(big.Int)
+			}
+			check.expected.Mod(check.expected, refQ)
+			actual, _ := check.actual.MarshalBinary()
+			if !bytes.Equal(actual, check.expected.FillBytes(make([]byte, FieldBytes))) {
+				t.Errorf("%s(%x): got %x, expected %x", check.name, data, actual, check.expected)
+			}
+		}
+	})
+}
+
+func FuzzPointUnmarshalBinary(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		P := NewPoint()
+		err := P.UnmarshalBinary(data)
+		ref, valid := refDecode(data)
+		if valid != (err == nil) {
+			t.Fatalf("UnmarshalBinary(%x): got error %v, expected valid = %v", data, err, valid)
+		}
+		if !valid {
+			return
+		}
+		if encoded, expected := encodeOrNil(P), refEncode(ref); !bytes.Equal(encoded, expected) {
+			t.Fatalf("UnmarshalBinary(%x): got %x, expected %x", data, encoded, expected)
+		}
+	})
+}
+
+func FuzzPointAdd(f *testing.F) {
+	f.Fuzz(func(t *testing.T, aData, bData []byte) {
+		A, aRef := decodeOrIdentity(aData)
+		B, bRef := decodeOrIdentity(bData)
+		expected := refEncode(refAdd(aRef, bRef))
+		if actual := encodeOrNil(A.Add(B).(*Point)); !bytes.Equal(actual, expected) {
+			t.Errorf("Add(%x, %x): got %x, expected %x", aData, bData, actual, expected)
+		}
+		expected = refEncode(refAdd(aRef, refAdd(bRef, bRef)))
+		if actual := encodeOrNil(A.Add(B.Add(B)).(*Point)); !bytes.Equal(actual, expected) {
+			t.Errorf("Add(%x, 2 * %x): got %x, expected %x", aData, bData, actual, expected)
+		}
+	})
+}
+
+func FuzzScalarAct(f *testing.F) {
+	f.Fuzz(func(t *testing.T, sData, pData []byte) {
+		// Any bytes give us a scalar, after reduction.
+		k := new(big.Int).SetBytes(sData)
+		k.Mod(k, refQ)
+		s := NewScalar()
+		if err := s.UnmarshalBinary(k.FillBytes(make([]byte, FieldBytes))); err != nil {
+			t.Fatal(err)
+		}
+		P, pRef := decodeOrIdentity(pData)
+
+		expected := refEncode(refScalarMul(k, pRef))
+		if actual := encodeOrNil(s.Act(P).(*Point)); !bytes.Equal(actual, expected) {
+			t.Errorf("Act(%x, %x): got %x, expected %x", sData, pData, actual, expected)
+		}
+		if actual := encodeOrNil(s.VartimeAct(P).(*Point)); !bytes.Equal(actual, expected) {
+			t.Errorf("VartimeAct(%x, %x): got %x, expected %x", sData, pData, actual, expected)
+		}
+		expected = refEncode(refScalarMul(k, refBasePoint))
+		if actual := encodeOrNil(s.ActOnBase().(*Point)); !bytes.Equal(actual, expected) {
+			t.Errorf("ActOnBase(%x): got %x, expected %x", sData, actual, expected)
+		}
+	})
+}
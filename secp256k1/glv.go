@@ -0,0 +1,119 @@
+package secp256k1
+
+import (
+	"math/bits"
+
+	"github.com/cronokirby/saferith"
+)
+
+// The GLV endomorphism of secp256k1 maps (x, y) to (beta * x, y), which is the same
+// as multiplying the point by lambda. This lets us split a 256 bit scalar k into
+// two scalars k1, k2 of around 128 bits each, with k = k1 + k2 * lambda, halving
+// the number of doublings needed for scalar multiplication.
+//
+// The constants for the decomposition follow section 3.5 of "Guide to Elliptic
+// Curve Cryptography", and are the same ones used by libsecp256k1.
+
+// beta is a cube root of unity modulo p.
+var beta *Field
+
+// lambda is a cube root of unity modulo q.
+var lambda *Scalar
+
+// The vectors (a1, b1) and (a2, b2) form a short basis of the lattice of (k1, k2)
+// with k1 + k2 * lambda = 0. Since b1 is negative, we store minusB1 = -b1.
+var glvA1, glvMinusB1, glvA2, glvB2 *Scalar
+
+// glvG1 and glvG2 are round(2^384 * b2 / q) and round(2^384 * (-b1) / q).
+var glvG1, glvG2 [4]uint64
+
+// glvBits is an upper bound on the number of bits in each half of a decomposed scalar.
+const glvBits = 129
+
+func init() {
+	scalarFromHex := func(hex string) *Scalar {
+		nat, _ := new(saferith.Nat).SetHex(hex)
+		return NewScalar().SetNat(nat).(*Scalar)
+	}
+	betaNat, _ := new(saferith.Nat).SetHex("7AE96A2B657C07106E64479EAC3434E99CF0497512F58995C1396C28719501EE")
+	beta = fieldFromNat(betaNat)
+	lambda = scalarFromHex("5363AD4CC05C30E0A5261C028812645A122E22EA20816678DF02967C1B23BD72")
+
+	glvA1 = scalarFromHex("3086D221A7D46BCDE86C90E49284EB15")
+	glvMinusB1 = scalarFromHex("E4437ED6010E88286F547FA90ABFE4C3")
+	glvA2 = scalarFromHex("0114CA50F7A8E2F3F657C1108D9D44CFD8")
+	glvB2 = glvA1
+
+	glvG1 = scalarFromHex("3086D221A7D46BCDE86C90E49284EB153DAA8A1471E8CA7FE893209A45DBB031").limbs
+	glvG2 = scalarFromHex("E4437ED6010E88286F547FA90ABFE4C4221208AC9DF506C61571B4AE8AC47F71").limbs
+}
+
+// mulShift384 calculates round(k * g / 2^384), in constant-time.
+func mulShift384(k *[4]uint64, g *[4]uint64) *Scalar {
+	product := mul256(k, g)
+	// The bit just below 2^384 tells us how to round.
+	out := NewScalar()
+	var carry uint64
+	out.limbs[0], carry = bits.Add64(product[6], product[5]>>63, 0)
+	out.limbs[1], carry = bits.Add64(product[7], 0, carry)
+	out.limbs[2] = carry
+	return out
+}
+
+// halfQ is (q - 1) / 2, letting us check if a scalar is "negative".
+var halfQ = [4]uint64{0xDFE92F46681B20A0, 0x5D576E7357A4501D, 0xFFFFFFFFFFFFFFFF, 0x7FFFFFFFFFFFFFFF}
+
+// isHigh checks if a scalar is greater than (q - 1) / 2, in constant-time.
+func (s *Scalar) isHigh() saferith.Choice {
+	// s > halfQ exactly when halfQ - s borrows.
+	var borrow uint64
+	_, borrow = bits.Sub64(halfQ[0], s.limbs[0], 0)
+	_, borrow = bits.Sub64(halfQ[1], s.limbs[1], borrow)
+	_, borrow = bits.Sub64(halfQ[2], s.limbs[2], borrow)
+	_, borrow = bits.Sub64(halfQ[3], s.limbs[3], borrow)
+	return saferith.Choice(borrow)
+}
+
+// condNegate sets s <- -s, only if yes = 1, in constant-time.
+func (s *Scalar) condNegate(yes saferith.Choice) *Scalar {
+	negated := NewScalar().Set(s).Negate().(*Scalar)
+	condAssignLimbs(yes, &s.limbs, &negated.limbs)
+	return s
+}
+
+// splitScalar decomposes k into k1 + k2 * lambda, in constant-time.
+//
+// The result is returned as the absolute values of k1 and k2, which are both
+// less than 2^glvBits, along with choices indicating whether or not each of
+// them is negative.
+func splitScalar(k *Scalar) (k1 *Scalar, neg1 saferith.Choice, k2 *Scalar, neg2 saferith.Choice) {
+	c1 := mulShift384(&k.limbs, &glvG1)
+	c2 := mulShift384(&k.limbs, &glvG2)
+
+	// k2 = -c1 * b1 - c2 * b2
+	k2 = NewScalar().Set(c1).Mul(glvMinusB1).(*Scalar)
+	k2.Sub(NewScalar().Set(c2).Mul(glvB2))
+	// k1 = k - k2 * lambda, which is the same as k - c1 * a1 - c2 * a2
+	k1 = NewScalar().Set(k2).Mul(lambda).Negate().Add(k).(*Scalar)
+
+	neg1 = k1.isHigh()
+	k1.condNegate(neg1)
+	neg2 = k2.isHigh()
+	k2.condNegate(neg2)
+	return k1, neg1, k2, neg2
+}
+
+// endomorphism returns a new point, equal to lambda * p.
+func (p *Point) endomorphism() *Point {
+	return &Point{
+		x: NewField().Set(p.x).Mul(beta),
+		y: NewField().Set(p.y),
+		z: NewField().Set(p.z),
+	}
+}
+
+// condNegate sets p <- -p, only if yes = 1, in constant-time.
+func (p *Point) condNegate(yes saferith.Choice) *Point {
+	p.y.CondNegate(yes)
+	return p
+}
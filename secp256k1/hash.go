@@ -0,0 +1,131 @@
+package secp256k1
+
+import (
+	"crypto/sha256"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/rfc9380"
+	"github.com/cronokirby/saferith"
+)
+
+// This file implements hashing to secp256k1, following section 8.7 of RFC 9380.
+//
+// Since secp256k1 has A = 0, the simplified SWU map can't be used directly.
+// Instead, we map to an isogenous curve, y^2 = x^3 + A' x + B', and then apply
+// a 3-isogeny to get a point on secp256k1.
+
+// fieldFromHex creates a field element from a Big Endian hex string.
+func fieldFromHex(hex string) *Field {
+	nat, _ := new(saferith.Nat).SetHex(hex)
+	return fieldFromNat(nat)
+}
+
+var (
+	// isoA and isoB are the parameters A' and B' of the isogenous curve.
+	isoA = fieldFromHex("3F8731ABDD661ADCA08A5558F0F5D272E953D363CB6F0E5D405447C01A444533")
+	isoB = NewField().SetUint64(1771)
+	// isoZ is the constant Z = -11 used by the simplified SWU map.
+	isoZ = NewField().SetUint64(11).Negate()
+)
+
+// sqrt sets z <- sqrt(z), returning a choice indicating if z was actually square.
+func sqrt(z *Field) saferith.Choice {
+	root := NewField().Set(z).Sqrt()
+	wasSquare := NewField().Set(root).Square().Eq(z)
+	z.CondAssign(wasSquare, root)
+	return wasSquare
+}
+
+// sgn0 returns the sign of a field element, as defined in section 4.1 of RFC 9380.
+func sgn0(z *Field) saferith.Choice {
+	return 1 ^ z.IsEven()
+}
+
+// sswu is the simplified SWU map to the isogenous curve.
+var sswu = rfc9380.NewSSWU(isoA, isoB, isoZ, sqrt, sgn0)
+
+// isogeny is the 3-isogeny mapping the isogenous curve to secp256k1, from appendix E.1 of RFC 9380.
+var isogeny = &rfc9380.Isogeny[Field, *Field]{
+	XNum: []*Field{
+		fieldFromHex("8E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38DAAAAA8C7"),
+		fieldFromHex("07D3D4C80BC321D5B9F315CEA7FD44C5D595D2FC0BF63B92DFFF1044F17C6581"),
+		fieldFromHex("534C328D23F234E6E2A413DECA25CAECE4506144037C40314ECBD0B53D9DD262"),
+		fieldFromHex("8E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38E38DAAAAA88C"),
+	},
+	XDen: []*Field{
+		fieldFromHex("D35771193D94918A9CA34CCBB7B640DD86CD409542F8487D9FE6B745781EB49B"),
+		fieldFromHex("EDADC6F64383DC1DF7C4B2D51B54225406D36B641F5E41BBC52A56612A8C6D14"),
+		NewField().SetUint64(1),
+	},
+	YNum: []*Field{
+		fieldFromHex("4BDA12F684BDA12F684BDA12F684BDA12F684BDA12F684BDA12F684B8E38E23C"),
+		fieldFromHex("C75E0C32D5CB7C0FA9D0A54B12A0A6D5647AB046D686DA6FDFFC90FC201D71A3"),
+		fieldFromHex("29A6194691F91A73715209EF6512E576722830A201BE2018A765E85A9ECEE931"),
+		fieldFromHex("2F684BDA12F684BDA12F684BDA12F684BDA12F684BDA12F684BDA12F38E38D84"),
+	},
+	YDen: []*Field{
+		fieldFromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFF93B"),
+		fieldFromHex("7A06534BB8BDB49FD5E9E6632722C2989467C1BFC8E8D978DFB425D2685C2573"),
+		fieldFromHex("6484AA716545CA2CF3A70C3FA8FE337E0A3D21162F0D6299A7BF8192BFD2A76F"),
+		NewField().SetUint64(1),
+	},
+}
+
+// mapToCurve maps a field element to a point on secp256k1.
+func mapToCurve(u *Field) *Point {
+	x, y, z := isogeny.Map(sswu.Map(u))
+	out := &Point{x: x, y: y, z: z}
+	// If either denominator vanishes, the isogeny sends the point to the identity.
+	return out.CondAssign(z.EqZero(), NewPoint())
+}
+
+// suite is the secp256k1_XMD:SHA-256_SSWU_RO_ suite, along with its nonuniform variant.
+var suite = &rfc9380.Suite{
+	Expand: rfc9380.XMDExpander(sha256.New),
+	Field:  p,
+	Degree: 1,
+	K:      128,
+	MapToCurve: func(u []*saferith.Nat) kyokusen.Point {
+		return mapToCurve(fieldFromNat(u[0]))
+	},
+}
+
+// HashToCurve hashes a message to a point of secp256k1, using a domain separation tag.
+//
+// This implements the secp256k1_XMD:SHA-256_SSWU_RO_ suite from RFC 9380.
+// The result is uniformly distributed, with no known discrete logarithm.
+func HashToCurve(msg, dst []byte) (*Point, error) {
+	out, err := suite.HashToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*Point), nil
+}
+
+// EncodeToCurve encodes a message as a point of secp256k1, using a domain separation tag.
+//
+// This implements the secp256k1_XMD:SHA-256_SSWU_NU_ suite from RFC 9380.
+// Unlike HashToCurve, the result isn't uniformly distributed, but this is faster.
+func EncodeToCurve(msg, dst []byte) (*Point, error) {
+	out, err := suite.EncodeToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return out.(*Point), nil
+}
+
+// HashToCurve hashes a message to a point, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using the
+// secp256k1_XMD:SHA-256_SSWU_RO_ suite from RFC 9380.
+func (Curve) HashToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	return suite.HashToCurve(msg, dst)
+}
+
+// EncodeToCurve encodes a message as a point, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using the
+// secp256k1_XMD:SHA-256_SSWU_NU_ suite from RFC 9380.
+func (Curve) EncodeToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	return suite.EncodeToCurve(msg, dst)
+}
@@ -0,0 +1,114 @@
+package secp256k1
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+)
+
+// hashVectors holds the test vectors for a suite from appendix J of RFC 9380.
+//
+// The random oracle suites have two intermediate points, Q0 and Q1, while
+// the non-uniform suites have a single one, Q.
+type hashVectors struct {
+	Ciphersuite string
+	Dst         string
+	Vectors     []struct {
+		P   struct{ X, Y string }
+		Q   struct{ X, Y string }
+		Q0  struct{ X, Y string }
+		Q1  struct{ X, Y string }
+		Msg string
+		U   []string
+	}
+}
+
+func readHashVectors(t *testing.T, path string) *hashVectors {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out hashVectors
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return &out
+}
+
+// parseField parses a field element, written in hex with a 0x prefix.
+func parseField(s string) *Field {
+	return fieldFromHex(strings.ToUpper(strings.TrimPrefix(s, "0x")))
+}
+
+func checkPoint(t *testing.T, name string, p kyokusen.Point, x, y string) {
+	normalized := NewPoint().CondAssign(1, castPoint(p))
+	normalized.normalize()
+	if normalized.x.Eq(parseField(x)) != 1 || normalized.y.Eq(parseField(y)) != 1 {
+		t.Errorf("%s: incorrect point", name)
+	}
+}
+
+func TestHashToCurveVectors(t *testing.T) {
+	for _, variant := range []string{"RO", "NU"} {
+		vectors := readHashVectors(t, "testdata/secp256k1_XMD-SHA-256_SSWU_"+variant+"_.json")
+		for i, v := range vectors.Vectors {
+			u, err := suite.HashToField([]byte(v.Msg), []byte(vectors.Dst), len(v.U))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j := range u {
+				if fieldFromNat(u[j][0]).Eq(parseField(v.U[j])) != 1 {
+					t.Errorf("%s %d: incorrect u[%d]", variant, i, j)
+				}
+			}
+			hash := Curve{}.HashToCurve
+			if variant == "NU" {
+				hash = Curve{}.EncodeToCurve
+				checkPoint(t, variant, mapToCurve(fieldFromNat(u[0][0])), v.Q.X, v.Q.Y)
+			} else {
+				checkPoint(t, variant, mapToCurve(fieldFromNat(u[0][0])), v.Q0.X, v.Q0.Y)
+				checkPoint(t, variant, mapToCurve(fieldFromNat(u[1][0])), v.Q1.X, v.Q1.Y)
+			}
+			p, err := hash([]byte(v.Msg), []byte(vectors.Dst))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkPoint(t, variant, p, v.P.X, v.P.Y)
+		}
+	}
+}
+
+func TestHashToCurveMatchesInterface(t *testing.T) {
+	msg, dst := []byte("msg"), []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	direct, err := HashToCurve(msg, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generic, err := kyokusen.HashToCurve(Curve{}, msg, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !direct.Equal(generic) {
+		t.Error("HashToCurve should match kyokusen.HashToCurve")
+	}
+}
+
+func TestMapToCurveOfZero(t *testing.T) {
+	// u = 0 is an exceptional case of the simplified SWU map.
+	p := mapToCurve(NewField())
+	x := NewField().Set(p.x).Mul(NewField().Set(p.z).Invert())
+	y := NewField().Set(p.y).Mul(NewField().Set(p.z).Invert())
+	rhs := NewField().Set(x).Square().Mul(x).Add(NewField().SetUint64(b))
+	if p.IsIdentity() || NewField().Set(y).Square().Eq(rhs) != 1 {
+		t.Error("mapping 0 should produce a point on the curve")
+	}
+}
+
+func BenchmarkHashToCurve(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = HashToCurve([]byte("message"), []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_"))
+	}
+}
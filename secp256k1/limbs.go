@@ -0,0 +1,63 @@
+package secp256k1
+
+import (
+	"math/bits"
+
+	"github.com/cronokirby/kyokusen/internal/limbs"
+	"github.com/cronokirby/saferith"
+)
+
+// This file contains helpers shared by the field and scalar arithmetic, which
+// both represent numbers as 4 64 bit limbs, in little endian order.
+
+// condAssignLimbs sets z <- x, only if yes = 1, in constant-time.
+func condAssignLimbs(yes saferith.Choice, z *[4]uint64, x *[4]uint64) {
+	m := limbs.Mask(yes)
+	for i := range z {
+		z[i] ^= m & (z[i] ^ x[i])
+	}
+}
+
+// eqLimbs checks if two numbers are equal, in constant-time.
+func eqLimbs(a *[4]uint64, b *[4]uint64) saferith.Choice {
+	var diff uint64
+	for i := range a {
+		diff |= a[i] ^ b[i]
+	}
+	return limbs.IsZero(diff)
+}
+
+// condSubtract sets z <- (carry * 2^256 + l) mod m, assuming that this value is < 2m.
+//
+// This runs in constant-time.
+func condSubtract(z *[4]uint64, carry uint64, l [4]uint64, m *[4]uint64) {
+	// We calculate l - m, and use that if it didn't borrow more than the carry.
+	var borrow uint64
+	z[0], borrow = bits.Sub64(l[0], m[0], 0)
+	z[1], borrow = bits.Sub64(l[1], m[1], borrow)
+	z[2], borrow = bits.Sub64(l[2], m[2], borrow)
+	z[3], borrow = bits.Sub64(l[3], m[3], borrow)
+	// l >= m exactly when we have a carry, or no borrow.
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	condAssignLimbs(saferith.Choice(borrow), z, &l)
+}
+
+// mul256 calculates the full 512 bit product of two numbers.
+func mul256(a *[4]uint64, b *[4]uint64) (out [8]uint64) {
+	// This is just schoolbook multiplication.
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, out[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			out[i+j] = lo
+			carry = hi
+		}
+		out[i+4] = carry
+	}
+	return out
+}
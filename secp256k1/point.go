@@ -1,7 +1,6 @@
 package secp256k1
 
 import (
-	"crypto/subtle"
 	"errors"
 	"fmt"
 
@@ -33,9 +32,9 @@ func init() {
 	xNat, _ := new(saferith.Nat).SetHex("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798")
 	yNat, _ := new(saferith.Nat).SetHex("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8")
 	basePoint = &Point{
-		x: &Field{nat: *xNat},
-		y: &Field{nat: *yNat},
-		z: &Field{nat: *new(saferith.Nat).SetUint64(1)},
+		x: fieldFromNat(xNat),
+		y: fieldFromNat(yNat),
+		z: NewField().SetUint64(1),
 	}
 }
 
@@ -106,28 +105,89 @@ func (p *Point) MarshalBinary() ([]byte, error) {
 }
 
 // UnmarshalBinary unmarshals a Secp256k1 point from Bitcoin's encoding.
+//
+// The SEC1 uncompressed encoding, used for public keys in other protocols,
+// is also accepted, as long as the point lies on the curve.
 func (p *Point) UnmarshalBinary(data []byte) error {
-	if len(data) != 1+fieldBytes {
+	switch {
+	case len(data) == 1+fieldBytes && (data[0] == 2 || data[0] == 3):
+		return p.setX("UnmarshalBinary", data[1:], saferith.Choice(data[0]&1)^1)
+	case len(data) == 1+2*fieldBytes && data[0] == 4:
+		var x, y Field
+		if err := x.UnmarshalBinary(data[1 : 1+fieldBytes]); err != nil {
+			return err
+		}
+		if err := y.UnmarshalBinary(data[1+fieldBytes:]); err != nil {
+			return err
+		}
+		rhs := NewField().Set(&x).Square().Mul(&x).AddU64(b)
+		if NewField().Set(&y).Square().Eq(rhs) != 1 {
+			return errors.New("secp256k1.UnmarshalBinary: invalid point")
+		}
+		p.x.Set(&x)
+		p.y.Set(&y)
+		p.z.SetUint64(1)
+		p.normalized = true
+		return nil
+	default:
 		return errors.New("secp256k1.UnmarshalBinary: invalid data")
 	}
-	if err := p.x.UnmarshalBinary(data[1:]); err != nil {
+}
+
+// setX sets this point to the one with a given x coordinate, and a y coordinate of a given parity.
+//
+// This will return an error if x isn't a valid field element, or doesn't correspond
+// to a point on the curve. The error mentions fn, the name of the calling method.
+func (p *Point) setX(fn string, xData []byte, yShouldBeEven saferith.Choice) error {
+	if err := p.x.UnmarshalBinary(xData); err != nil {
 		return err
 	}
 	p.y.Set(p.x).Square().Mul(p.x).AddU64(b)
 	if p.y.HasSqrt() != 1 {
-		return errors.New("secp256k1.UnmarshalBinary: invalid point")
+		return fmt.Errorf("secp256k1.%s: invalid point", fn)
 	}
 	p.y.Sqrt()
-	yShouldBeEven := saferith.Choice(subtle.ConstantTimeByteEq(data[0], 2))
 	p.y.CondNegate(p.y.IsEven() ^ yShouldBeEven)
 	p.z.SetUint64(1)
 	p.normalized = true
 	return nil
 }
 
+// MarshalXOnly marshals a Secp256k1 point as just its x coordinate, following BIP-340.
+//
+// The result is always 32 bytes long. This encoding forgets the y coordinate of
+// the point, which is assumed to be even when unmarshalling.
+//
+// The point at infinity can't be marshalled.
+func (p *Point) MarshalXOnly() ([]byte, error) {
+	p.normalize()
+	if p.IsIdentity() {
+		return nil, errors.New("secp256k1: can't marshal point at infinity")
+	}
+	return p.x.MarshalBinary()
+}
+
+// UnmarshalXOnly unmarshals a Secp256k1 point from its x coordinate, following BIP-340.
+//
+// This expects exactly 32 bytes, and will choose the point with an even y coordinate,
+// which is the lift_x operation in BIP-340.
+func (p *Point) UnmarshalXOnly(data []byte) error {
+	if len(data) != fieldBytes {
+		return errors.New("secp256k1.UnmarshalXOnly: invalid data")
+	}
+	return p.setX("UnmarshalXOnly", data, 1)
+}
+
+// HasEvenY checks if the affine y coordinate of this point is even.
+//
+// This is always false for the point at infinity.
+func (p *Point) HasEvenY() bool {
+	p.normalize()
+	return (p.y.IsEven() & (1 ^ p.z.EqZero())) == 1
+}
+
 func (*Point) Curve() kyokusen.Curve {
-	// TODO: Implement
-	return nil
+	return Curve{}
 }
 
 func (p1 *Point) Add(other kyokusen.Point) kyokusen.Point {
@@ -205,9 +265,12 @@ func (p *Point) IsIdentity() bool {
 	return p.z.EqZero() == 1
 }
 
-func (*Point) XScalar() kyokusen.Scalar {
-	// TODO: Implement
-	return nil
+// XScalar returns the affine x coordinate of this point, reduced modulo the order of the group.
+//
+// For the identity point, this returns 0.
+func (p *Point) XScalar() kyokusen.Scalar {
+	p.normalize()
+	return NewScalar().SetNat(p.x.nat())
 }
 
 // CondAssign conditionally modifies the contents of a point.
@@ -217,3 +280,10 @@ func (p *Point) CondAssign(yes saferith.Choice, other *Point) *Point {
 	p.z.CondAssign(yes, other.z)
 	return p
 }
+
+// CondSelect returns a new point, equal to other if yes = 1, and to this point otherwise.
+//
+// This implements the kyokusen.SelectablePoint interface, and runs in constant-time.
+func (p *Point) CondSelect(yes saferith.Choice, other kyokusen.Point) kyokusen.Point {
+	return NewPoint().CondAssign(1, p).CondAssign(yes, castPoint(other))
+}
@@ -1,12 +1,15 @@
 package secp256k1
 
 import (
+	"bytes"
+	"encoding/hex"
 	"math/rand"
 	"reflect"
 	"testing"
 	"testing/quick"
 
 	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
 )
 
 func randomPoint(r *rand.Rand, size int) *Point {
@@ -126,3 +129,83 @@ func BenchmarkPointAddition(t *testing.B) {
 		point = point.Add(point)
 	}
 }
+
+func TestBasePointXScalar(t *testing.T) {
+	xNat, _ := new(saferith.Nat).SetHex("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798")
+	expected := NewScalar().SetNat(xNat)
+	if !NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).ActOnBase().XScalar().Equal(expected) {
+		t.Error("XScalar of the base point is incorrect")
+	}
+}
+
+func TestIdentityXScalarIsZero(t *testing.T) {
+	if !NewPoint().XScalar().IsZero() {
+		t.Error("XScalar of the identity point should be zero")
+	}
+}
+
+func TestPointMarshalXOnlyRoundtrip(t *testing.T) {
+	err := quick.Check(func(a *Point) bool {
+		if a.IsIdentity() {
+			return true
+		}
+		data, err := a.MarshalXOnly()
+		if err != nil {
+			return false
+		}
+		a2 := NewPoint()
+		if err := a2.UnmarshalXOnly(data); err != nil {
+			return false
+		}
+		if !a2.HasEvenY() {
+			return false
+		}
+		if a.HasEvenY() {
+			return a.Equal(a2)
+		}
+		return a.Negate().Equal(a2)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBasePointMarshalBinary(t *testing.T) {
+	data, err := NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).ActOnBase().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := hex.DecodeString("0279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798")
+	if !bytes.Equal(data, expected) {
+		t.Errorf("incorrect encoding of base point: %X", data)
+	}
+}
+
+func TestPointUnmarshalRejectsInvalidPrefix(t *testing.T) {
+	data, _ := Curve{}.NewBasePoint().MarshalBinary()
+	for _, prefix := range []byte{0x00, 0x01, 0x04, 0x05, 0x06, 0x07, 0xFF} {
+		data[0] = prefix
+		if NewPoint().UnmarshalBinary(data) == nil {
+			t.Errorf("prefix %02X should be rejected", prefix)
+		}
+	}
+}
+
+func TestPointUnmarshalUncompressed(t *testing.T) {
+	G := Curve{}.NewBasePoint()
+	x, _ := basePoint.x.MarshalBinary()
+	y, _ := basePoint.y.MarshalBinary()
+	data := append(append([]byte{4}, x...), y...)
+	P := NewPoint()
+	if err := P.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !P.Equal(G) {
+		t.Error("uncompressed encoding of the base point decoded to the wrong point")
+	}
+	// Changing y moves the point off the curve.
+	data[len(data)-1] ^= 1
+	if NewPoint().UnmarshalBinary(data) == nil {
+		t.Error("a point off the curve should be rejected")
+	}
+}
@@ -0,0 +1,67 @@
+package secp256k1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/kyokusen/ecdsa"
+	"github.com/cronokirby/saferith"
+)
+
+// SignRecoverable creates an ECDSA signature of a digest, along with a recovery id.
+//
+// The signature itself is created by ecdsa.SignWithNonce. The nonce must be
+// secret, and never used more than once. For example, it can be derived using
+// the rfc6979 package.
+//
+// The recovery id v is between 0 and 3, and allows recovering the public key
+// from the signature, using Recover. The lowest bit of v indicates whether the
+// nonce point had an odd y coordinate, and the second bit whether its x coordinate
+// overflowed the order of the group.
+func SignRecoverable(secret, nonce *Scalar, digest []byte) (r, s *Scalar, v byte, err error) {
+	rScalar, sScalar, err := ecdsa.SignWithNonce(secret, nonce, ecdsa.DigestToScalar(Curve{}, digest))
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("secp256k1.SignRecoverable: %w", err)
+	}
+	R := nonce.ActOnBase().(*Point)
+	R.normalize()
+	_, _, xLessThanQ := R.x.nat().CmpMod(q)
+	v = byte(1^R.y.IsEven()) | byte(1^xLessThanQ)<<1
+	return rScalar.(*Scalar), sScalar.(*Scalar), v, nil
+}
+
+// Recover calculates the public key used to create an ECDSA signature of a digest.
+//
+// This needs the recovery id v, between 0 and 3, as produced by SignRecoverable.
+// If the signature is valid, then it will verify against the resulting public key.
+func Recover(digest []byte, r, s *Scalar, v byte) (*Point, error) {
+	if v > 3 {
+		return nil, errors.New("secp256k1.Recover: invalid recovery id")
+	}
+	if r.IsZero() || s.IsZero() {
+		return nil, errors.New("secp256k1.Recover: invalid signature")
+	}
+	// The x coordinate of R is either r, or r + q, if it overflowed the order.
+	var x saferith.Nat
+	x.SetNat(r.nat())
+	if v&2 != 0 {
+		x.Add(&x, q.Nat(), p.BitLen()+1)
+		if _, _, lt := x.CmpMod(p); lt != 1 {
+			return nil, errors.New("secp256k1.Recover: invalid signature")
+		}
+	}
+	R := NewPoint()
+	if err := R.setX("Recover", x.FillBytes(make([]byte, fieldBytes)), saferith.Choice(1^(v&1))); err != nil {
+		return nil, errors.New("secp256k1.Recover: invalid signature")
+	}
+	// Q = r^-1 (s R - e G)
+	rInv := NewScalar().Set(r).Invert()
+	u1 := ecdsa.DigestToScalar(Curve{}, digest).Mul(rInv).Negate().(*Scalar)
+	u2 := NewScalar().Set(s).Mul(rInv)
+	// Every input here is public, so we can use variable-time operations.
+	Q := u1.VartimeDoubleBaseMul(u2, R).(*Point)
+	if Q.IsIdentity() {
+		return nil, errors.New("secp256k1.Recover: invalid signature")
+	}
+	return Q, nil
+}
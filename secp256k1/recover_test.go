@@ -0,0 +1,159 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+
+	"github.com/cronokirby/kyokusen/ecdsa"
+	"github.com/cronokirby/saferith"
+)
+
+func scalarFromHex(s string) *Scalar {
+	nat, _ := new(saferith.Nat).SetHex(s)
+	return NewScalar().SetNat(nat).(*Scalar)
+}
+
+func TestRecoverRoundtrip(t *testing.T) {
+	err := quick.Check(func(secret, nonce *Scalar, digest [32]byte) bool {
+		if secret.IsZero() || nonce.IsZero() {
+			return true
+		}
+		r, s, v, err := SignRecoverable(secret, nonce, digest[:])
+		if err != nil {
+			return false
+		}
+		Q, err := Recover(digest[:], r, s, v)
+		if err != nil {
+			return false
+		}
+		return Q.Equal(secret.ActOnBase())
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecoverWrongIDGivesWrongKey(t *testing.T) {
+	secret := scalarFromHex("A1BECEF2069444A9DC6331C3247E113C3EE142EDDA683DB8643F9CB0AF7CBE33")
+	nonce := scalarFromHex("EDB3A01063A0C6CCFC0D77295077CBD322CF364BFA64B7EEEA3B20305135D444")
+	digest, _ := hex.DecodeString("4A6C419A1E25C85327115C4ACE586DECDDFE2990ED8F3D4D801871158338501D")
+	r, s, v, err := SignRecoverable(secret, nonce, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Q, err := Recover(digest, r, s, v^1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Q.Equal(secret.ActOnBase()) {
+		t.Error("recovering with the wrong parity should give a different key")
+	}
+}
+
+func TestRecoverVectors(t *testing.T) {
+	// These vectors come from dcrd, which normalizes s to be low, flipping the
+	// parity bit of the recovery id in the process.
+	vectors := []struct {
+		key, hash, nonce, r, s string
+		v                      byte
+	}{
+		{
+			key:   "0000000000000000000000000000000000000000000000000000000000000001",
+			hash:  "C301BA9DE5D6053CAAD9F5EB46523F007702ADD2C62FA39DE03146A36B8026B7",
+			nonce: "A6DF66500AFEB7711D4C8E2220960855D940A5ED57260D2C98FBF6066CCA283E",
+			r:     "B073759A96A835B09B79E7B93C37FDBE48FB82B000C4A0E1404BA5D1FBC15D0A",
+			s:     "7E34928A3E3832EC21E7711644D9388F7DEB6340EAD661D7056B0665974B87F3",
+			v:     1,
+		},
+		{
+			key:   "0000000000000000000000000000000000000000000000000000000000000002",
+			hash:  "C301BA9DE5D6053CAAD9F5EB46523F007702ADD2C62FA39DE03146A36B8026B7",
+			nonce: "679A6D36E7FE6C02D7668AF86D78186E8F9CCC04371AC1C8C37939D1F5CAE07A",
+			r:     "4A090D82F48CA12D9E7AA24B5DCC187EE0DB2920496F671D63E86036AAA7997E",
+			s:     "00261FFE8BA45007FC5FBBBA6B4C6ED41BEAFB48B09FA8AF1D6A3FBC6CCEFBAD",
+			v:     0,
+		},
+		{
+			key:   "65B46D4EB001C649A86309286AAF94B18386EFFE62C2E1586D9B1898CCF0099B",
+			hash:  "4C6EB9E38415034F4C93D3304D10BEF38BF0AD420EEFD0F72F940F11C5857786",
+			nonce: "7AFD696A9E770961D2B2EAEC77AB7C22C734886FA57BC4A50A9F1946168CD06F",
+			r:     "81DB1D6DCA08819AD936D3284A359091E57C036648D477B96AF9D8326965A7D1",
+			s:     "1BDF719C4BE69351BA7617A187AC246912101AEA4B5A7D6DFC234478622B43C6",
+			v:     1,
+		},
+	}
+	for i, vector := range vectors {
+		secret := scalarFromHex(vector.key)
+		digest, _ := hex.DecodeString(vector.hash)
+		r, s, v, err := SignRecoverable(secret, scalarFromHex(vector.nonce), digest)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if !r.Equal(scalarFromHex(vector.r)) {
+			t.Errorf("vector %d: incorrect r", i)
+		}
+		expectedS := scalarFromHex(vector.s)
+		expectedV := vector.v
+		if !s.Equal(expectedS) {
+			expectedS.Negate()
+			expectedV ^= 1
+		}
+		if !s.Equal(expectedS) || v != expectedV {
+			t.Errorf("vector %d: incorrect s or v", i)
+		}
+		Q, err := Recover(digest, scalarFromHex(vector.r), scalarFromHex(vector.s), vector.v)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if !Q.Equal(secret.ActOnBase()) {
+			t.Errorf("vector %d: recovered incorrect public key", i)
+		}
+	}
+}
+
+func TestRecoverOverflowingR(t *testing.T) {
+	// We look for an x coordinate just past the order of the group, so that r = x - q.
+	var R *Point
+	var x *Field
+	for j := uint64(1); R == nil; j++ {
+		x = fieldFromNat(new(saferith.Nat).Add(q.Nat(), new(saferith.Nat).SetUint64(j), p.BitLen()))
+		candidate := NewPoint()
+		xBytes, _ := x.MarshalBinary()
+		if candidate.UnmarshalXOnly(xBytes) == nil {
+			R = candidate
+		}
+	}
+	r := NewScalar().SetNat(x.nat()).(*Scalar)
+	s := scalarFromHex("1BDF719C4BE69351BA7617A187AC246912101AEA4B5A7D6DFC234478622B43C6")
+	digest, _ := hex.DecodeString("4C6EB9E38415034F4C93D3304D10BEF38BF0AD420EEFD0F72F940F11C5857786")
+	// UnmarshalXOnly produces a point with an even y coordinate.
+	Q, err := Recover(digest, r, s, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Q = r^-1 (s R - e G)
+	rInv := NewScalar().Set(r).Invert()
+	u1 := ecdsa.DigestToScalar(Curve{}, digest).Mul(rInv).Negate()
+	u2 := NewScalar().Set(s).Mul(rInv)
+	if !Q.Equal(u1.ActOnBase().Add(u2.Act(R))) {
+		t.Error("recovered incorrect public key")
+	}
+	// Without the overflow bit, we use a different R.
+	if Q2, err := Recover(digest, r, s, 0); err == nil && Q2.Equal(Q) {
+		t.Error("recovery id should affect the recovered key")
+	}
+}
+
+func TestRecoverRejectsOverflowPastField(t *testing.T) {
+	// r + q = p, which isn't a valid field element.
+	var rNat saferith.Nat
+	rNat.Sub(p.Nat(), q.Nat(), p.BitLen())
+	r := NewScalar().SetNat(&rNat).(*Scalar)
+	s := scalarFromHex("01")
+	for _, v := range []byte{2, 3, 4} {
+		if _, err := Recover(make([]byte, 32), r, s, v); err == nil {
+			t.Errorf("recovery id %d should have been rejected", v)
+		}
+	}
+}
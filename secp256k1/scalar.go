@@ -1,25 +1,39 @@
 package secp256k1
 
 import (
+	"encoding/binary"
 	"errors"
+	"math/bits"
 
 	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/limbs"
 	"github.com/cronokirby/saferith"
 )
 
 // q is the modulus for the scalars used in secp256k1.
-var q *saferith.Modulus
+//
+// Like p, this is initialized directly, so that other init functions can use it.
+var q, _ = saferith.ModulusFromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
 
-func init() {
-	q, _ = saferith.ModulusFromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
-}
+// qLimbs is q, as 64 bit limbs, in little endian order.
+var qLimbs = [4]uint64{0xBFD25E8CD0364141, 0xBAAEDCE6AF48A03B, 0xFFFFFFFFFFFFFFFE, 0xFFFFFFFFFFFFFFFF}
+
+// qComplement is 2^256 - q, which is a 129 bit number.
+//
+// Like with the field, we have 2^256 = qComplement mod q, which lets us reduce
+// large numbers by folding their top limbs back into the bottom ones.
+var qComplement = [3]uint64{0x402DA1732FC9BEBF, 0x4551231950B75FC4, 1}
 
+// Scalar represents an element of the scalar field of secp256k1, i.e. an integer modulo q.
+//
+// Internally, this is represented with 4 64 bit limbs, in little endian order.
+// The value is always kept fully reduced modulo q.
 type Scalar struct {
-	nat saferith.Nat
+	limbs [4]uint64
 }
 
 func (s *Scalar) String() string {
-	return s.nat.String()
+	return s.nat().String()
 }
 
 func NewScalar() *Scalar {
@@ -38,91 +52,245 @@ func castScalar(s kyokusen.Scalar) *Scalar {
 	return casted
 }
 
+// bytes returns the value of this scalar as 32 Big Endian bytes.
+func (s *Scalar) bytes() []byte {
+	out := make([]byte, FieldBytes)
+	for i, limb := range s.limbs {
+		binary.BigEndian.PutUint64(out[FieldBytes-8*(i+1):], limb)
+	}
+	return out
+}
+
+// setBytes sets s to the value of 32 Big Endian bytes, without any reduction.
+func (s *Scalar) setBytes(data []byte) {
+	for i := range s.limbs {
+		s.limbs[i] = binary.BigEndian.Uint64(data[FieldBytes-8*(i+1):])
+	}
+}
+
+// nat converts this scalar into a number.
+func (s *Scalar) nat() *saferith.Nat {
+	return new(saferith.Nat).SetBytes(s.bytes())
+}
+
 // MarshalBinary returns the contents of this scalar as Big Endian bytes.
 func (s *Scalar) MarshalBinary() ([]byte, error) {
-	// This makes sure that the size of the scalar is padded to the right size.
-	s.nat.Mod(&s.nat, q)
-	return s.nat.Bytes(), nil
+	return s.bytes(), nil
 }
 
 // UnmarshalBinary deserializes Big Endian bytes into this scalar.
 func (s *Scalar) UnmarshalBinary(data []byte) error {
-	if len(data) != p.BitLen()/8 {
+	if len(data) != q.BitLen()/8 {
 		return errors.New("secp256k1.Scalar.UnmarshalBinary: invalid data length")
 	}
-	s.nat.SetBytes(data)
-	if _, _, lt := s.nat.CmpMod(p); lt != 1 {
+	var unreduced Scalar
+	unreduced.setBytes(data)
+	// Reducing changes the value exactly when it is >= q.
+	var reduced Scalar
+	reduced.reduce(0, unreduced.limbs)
+	if eqLimbs(&reduced.limbs, &unreduced.limbs) != 1 {
 		return errors.New("secp256k1.Scalar.UnmarshalBinary: value is greater than order")
 	}
+	s.limbs = unreduced.limbs
 	return nil
 }
 
 // Curve returns the curve associated with this scalar field.
 func (s *Scalar) Curve() kyokusen.Curve {
-	return nil
+	return Curve{}
+}
+
+// reduce sets s <- (carry * 2^256 + l) mod q, assuming that this value is < 2q.
+func (s *Scalar) reduce(carry uint64, l [4]uint64) *Scalar {
+	condSubtract(&s.limbs, carry, l, &qLimbs)
+	return s
+}
+
+// addMulQComplement calculates out <- out + x * qComplement.
+//
+// The caller needs to make sure that out is large enough to hold the result.
+func addMulQComplement(out []uint64, x []uint64) {
+	for i, xi := range x {
+		var carry uint64
+		for j, cj := range qComplement {
+			hi, lo := bits.Mul64(xi, cj)
+			var c uint64
+			lo, c = bits.Add64(lo, out[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			out[i+j] = lo
+			carry = hi
+		}
+		for k := i + len(qComplement); k < len(out); k++ {
+			out[k], carry = bits.Add64(out[k], carry, 0)
+		}
+	}
+}
+
+// reduceWide sets s <- x mod q, for a 512 bit number x.
+func (s *Scalar) reduceWide(x [8]uint64) *Scalar {
+	// Each step replaces the limbs above 2^256 with their product by qComplement,
+	// which shrinks the number from 512 to 385 bits, then to 259 bits, and
+	// then to at most 2^256 + 2^132.
+	var t [7]uint64
+	copy(t[:], x[:4])
+	addMulQComplement(t[:], x[4:])
+	var u [5]uint64
+	copy(u[:], t[:4])
+	addMulQComplement(u[:], t[4:])
+	var v [5]uint64
+	copy(v[:], u[:4])
+	addMulQComplement(v[:], u[4:])
+	return s.reduce(v[4], [4]uint64{v[0], v[1], v[2], v[3]})
 }
 
 func (s1 *Scalar) Add(other kyokusen.Scalar) kyokusen.Scalar {
 	s2 := castScalar(other)
-	s1.nat.ModAdd(&s1.nat, &s2.nat, q)
-	return s1
+	var l [4]uint64
+	var carry uint64
+	l[0], carry = bits.Add64(s1.limbs[0], s2.limbs[0], 0)
+	l[1], carry = bits.Add64(s1.limbs[1], s2.limbs[1], carry)
+	l[2], carry = bits.Add64(s1.limbs[2], s2.limbs[2], carry)
+	l[3], carry = bits.Add64(s1.limbs[3], s2.limbs[3], carry)
+	return s1.reduce(carry, l)
 }
 
 func (s1 *Scalar) Sub(other kyokusen.Scalar) kyokusen.Scalar {
 	s2 := castScalar(other)
-	s1.nat.ModSub(&s1.nat, &s2.nat, q)
+	var borrow uint64
+	s1.limbs[0], borrow = bits.Sub64(s1.limbs[0], s2.limbs[0], 0)
+	s1.limbs[1], borrow = bits.Sub64(s1.limbs[1], s2.limbs[1], borrow)
+	s1.limbs[2], borrow = bits.Sub64(s1.limbs[2], s2.limbs[2], borrow)
+	s1.limbs[3], borrow = bits.Sub64(s1.limbs[3], s2.limbs[3], borrow)
+	// If we borrowed, we need to add q back.
+	m := limbs.Mask(saferith.Choice(borrow))
+	var carry uint64
+	s1.limbs[0], carry = bits.Add64(s1.limbs[0], m&qLimbs[0], 0)
+	s1.limbs[1], carry = bits.Add64(s1.limbs[1], m&qLimbs[1], carry)
+	s1.limbs[2], carry = bits.Add64(s1.limbs[2], m&qLimbs[2], carry)
+	s1.limbs[3], _ = bits.Add64(s1.limbs[3], m&qLimbs[3], carry)
 	return s1
 }
 
 func (s1 *Scalar) Negate() kyokusen.Scalar {
-	s1.nat.ModNeg(&s1.nat, q)
-	return s1
+	x := *s1
+	s1.limbs = [4]uint64{}
+	return s1.Sub(&x)
 }
 
 func (s1 *Scalar) Mul(other kyokusen.Scalar) kyokusen.Scalar {
 	s2 := castScalar(other)
-	s1.nat.ModMul(&s1.nat, &s2.nat, q)
-	return s1
+	return s1.reduceWide(mul256(&s1.limbs, &s2.limbs))
+}
+
+// square calculates s <- s * s, returning s.
+func (s *Scalar) square() *Scalar {
+	return s.reduceWide(mul256(&s.limbs, &s.limbs))
+}
+
+// squareN calculates s <- s^(2^n), returning s.
+func (s *Scalar) squareN(n int) *Scalar {
+	for i := 0; i < n; i++ {
+		s.square()
+	}
+	return s
 }
 
+// Invert calculates s <- s^-1, in constant-time.
+//
+// This computes s^(q - 2), using the same fixed addition chain as libsecp256k1.
+// If s = 0, the result is 0.
 func (s1 *Scalar) Invert() kyokusen.Scalar {
-	s1.nat.ModInverse(&s1.nat, q)
+	x := NewScalar().Set(s1).(*Scalar)
+	// Each of these variables is x raised to the power in its name. For xn, the
+	// power is instead 2^n - 1, i.e. n bits set to 1.
+	u2 := NewScalar().Set(x).(*Scalar).square()
+	x2 := NewScalar().Set(u2).Mul(x).(*Scalar)
+	u5 := NewScalar().Set(u2).Mul(x2).(*Scalar)
+	x3 := NewScalar().Set(u5).Mul(u2).(*Scalar)
+	u9 := NewScalar().Set(x3).Mul(u2).(*Scalar)
+	u11 := NewScalar().Set(u9).Mul(u2).(*Scalar)
+	u13 := NewScalar().Set(u11).Mul(u2).(*Scalar)
+	x6 := NewScalar().Set(u13).(*Scalar).squareN(2).Mul(u11).(*Scalar)
+	x8 := NewScalar().Set(x6).(*Scalar).squareN(2).Mul(x2).(*Scalar)
+	x14 := NewScalar().Set(x8).(*Scalar).squareN(6).Mul(x6).(*Scalar)
+	x28 := NewScalar().Set(x14).(*Scalar).squareN(14).Mul(x14).(*Scalar)
+	x56 := NewScalar().Set(x28).(*Scalar).squareN(28).Mul(x28).(*Scalar)
+	x112 := NewScalar().Set(x56).(*Scalar).squareN(56).Mul(x56).(*Scalar)
+	s1.Set(x112).(*Scalar).squareN(14).Mul(x14)
+
+	// The rest of the exponent is handled in short windows.
+	steps := []struct {
+		n int
+		x *Scalar
+	}{
+		{3, u5}, {4, x3}, {4, u5}, {5, u11}, {4, u11}, {4, x3}, {5, x3}, {6, u13},
+		{4, u5}, {3, x3}, {5, u9}, {6, u5}, {10, x3}, {4, x3}, {9, x8}, {5, u9},
+		{6, u11}, {4, u13}, {5, x2}, {6, u13}, {10, u13}, {4, u9}, {6, x}, {8, x6},
+	}
+	for _, step := range steps {
+		s1.squareN(step.n).Mul(step.x)
+	}
 	return s1
 }
 
 func (s1 *Scalar) Equal(other kyokusen.Scalar) bool {
 	s2 := castScalar(other)
-	return s1.nat.Eq(&s2.nat) == 1
+	return eqLimbs(&s1.limbs, &s2.limbs) == 1
 }
 
 func (s1 *Scalar) IsZero() bool {
-	return s1.nat.EqZero() == 1
+	return limbs.IsZero(s1.limbs[0]|s1.limbs[1]|s1.limbs[2]|s1.limbs[3]) == 1
 }
 
 func (s1 *Scalar) Set(other kyokusen.Scalar) kyokusen.Scalar {
 	s2 := castScalar(other)
-	s1.nat.SetNat(&s2.nat)
+	s1.limbs = s2.limbs
 	return s1
 }
 
 func (s1 *Scalar) SetNat(other *saferith.Nat) kyokusen.Scalar {
-	s1.nat.Mod(other, q)
+	var reduced saferith.Nat
+	reduced.Mod(other, q)
+	s1.setBytes(reduced.FillBytes(make([]byte, FieldBytes)))
 	return s1
 }
 
+// Act calculates s * P, in constant-time.
+//
+// This splits s into two halves using the GLV endomorphism, and then processes
+// both halves together, 4 bits at a time, using a table of small multiples of P.
 func (s *Scalar) Act(other kyokusen.Point) kyokusen.Point {
-	bytes := s.nat.Bytes()
+	P := castPoint(other)
+	k1, neg1, k2, neg2 := splitScalar(s)
+	k1Bytes := k1.bytes()
+	k2Bytes := k2.bytes()
+
+	// table[j] = j * P
+	var table [1 << tableWindow]*Point
+	table[0] = NewPoint()
+	for j := 1; j < len(table); j++ {
+		table[j] = table[j-1].Add(P).(*Point)
+	}
+
 	acc := NewPoint()
-	for _, b := range bytes {
-		for i := 7; i >= 0; i-- {
+	selected := NewPoint()
+	for i := (glvBits+tableWindow-1)/tableWindow - 1; i >= 0; i-- {
+		for j := 0; j < tableWindow; j++ {
 			acc = acc.Add(acc).(*Point)
-			added := acc.Add(other).(*Point)
-			acc.CondAssign(saferith.Choice((b>>i)&1), added)
 		}
+		selectPoint(selected, table[:], nibble(k1Bytes, i))
+		acc = acc.Add(selected.condNegate(neg1)).(*Point)
+		selectPoint(selected, table[:], nibble(k2Bytes, i))
+		acc = acc.Add(selected.condNegate(neg2).endomorphism()).(*Point)
 	}
 	return acc
 }
 
+// ActOnBase calculates s * G, where G is the generator of the group.
+//
+// This uses a precomputed table of multiples of G, built the first time this is called.
 func (s *Scalar) ActOnBase() kyokusen.Point {
-	return s.Act(basePoint)
+	return getBaseTable().Act(s)
 }
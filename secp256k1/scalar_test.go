@@ -92,6 +92,15 @@ func TestScalarMarshalRoundtrip(t *testing.T) {
 	}
 }
 
+func TestScalarUnmarshalRejectsOrder(t *testing.T) {
+	// Values between q and p used to be accepted, since they were checked against p.
+	for _, x := range []*saferith.Nat{q.Nat(), new(saferith.Nat).Add(q.Nat(), new(saferith.Nat).SetUint64(1), 256)} {
+		if NewScalar().UnmarshalBinary(x.FillBytes(make([]byte, 32))) == nil {
+			t.Errorf("%v should be rejected as a scalar", x)
+		}
+	}
+}
+
 func TestScalarActOneIsIdentity(t *testing.T) {
 	err := quick.Check(func(p *Point) bool {
 		p1 := NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).Act(p)
@@ -129,8 +138,186 @@ func BenchmarkActOnBase(b *testing.B) {
 	b.StopTimer()
 	r := rand.New(rand.NewSource(0))
 	s := randomScalar(r, 100)
+	// This makes sure that the precomputed table isn't included in the timing.
+	s.ActOnBase()
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
 		s.ActOnBase()
 	}
 }
+
+// actNaive calculates s * P with a simple double and add ladder, for comparison.
+func actNaive(s *Scalar, p *Point) *Point {
+	acc := NewPoint()
+	for _, b := range s.bytes() {
+		for i := 7; i >= 0; i-- {
+			acc = acc.Add(acc).(*Point)
+			added := acc.Add(p).(*Point)
+			acc.CondAssign(saferith.Choice((b>>i)&1), added)
+		}
+	}
+	return acc
+}
+
+func TestScalarActMatchesNaive(t *testing.T) {
+	err := quick.Check(func(s *Scalar, p *Point) bool {
+		return s.Act(p).Equal(actNaive(s, p))
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarActEdgeCases(t *testing.T) {
+	one := NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).(*Scalar)
+	minusOne := NewScalar().Set(one).Negate().(*Scalar)
+	for _, s := range []*Scalar{NewScalar(), one, minusOne, lambda, NewScalar().Set(lambda).Negate().(*Scalar)} {
+		if !s.Act(basePoint).Equal(actNaive(s, basePoint)) {
+			t.Errorf("incorrect result for %v", s)
+		}
+	}
+	if !minusOne.Act(basePoint).Equal(basePoint.Negate()) {
+		t.Error("-1 * G should be -G")
+	}
+	if !one.Act(NewPoint()).IsIdentity() {
+		t.Error("1 * O should be O")
+	}
+}
+
+func TestSplitScalar(t *testing.T) {
+	err := quick.Check(func(s *Scalar) bool {
+		k1, neg1, k2, neg2 := splitScalar(s)
+		if k1.nat().TrueLen() > glvBits || k2.nat().TrueLen() > glvBits {
+			return false
+		}
+		recombined := NewScalar().Set(k2).(*Scalar).condNegate(neg2).Mul(lambda)
+		recombined.Add(NewScalar().Set(k1).(*Scalar).condNegate(neg1))
+		return recombined.Equal(s)
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLambdaActsAsEndomorphism(t *testing.T) {
+	err := quick.Check(func(p *Point) bool {
+		return actNaive(lambda, p).Equal(p.endomorphism())
+	}, &quick.Config{MaxCount: 10})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+// scalarSample is a wrapper around a scalar, generating values over the whole range.
+type scalarSample struct {
+	*Scalar
+}
+
+func (scalarSample) Generate(r *rand.Rand, size int) reflect.Value {
+	data := make([]byte, FieldBytes)
+	r.Read(data)
+	x := new(saferith.Nat).SetBytes(data)
+	switch r.Intn(4) {
+	case 0:
+		// Values just below q.
+		x.Sub(q.Nat(), new(saferith.Nat).SetUint64(r.Uint64()>>uint(r.Intn(64))), 256)
+	case 1:
+		// Small values.
+		x.SetUint64(r.Uint64() >> uint(r.Intn(64)))
+	}
+	return reflect.ValueOf(scalarSample{NewScalar().SetNat(x).(*Scalar)})
+}
+
+func TestScalarMatchesReference(t *testing.T) {
+	// Each case compares our implementation against saferith.
+	cases := map[string]func(a, b scalarSample) bool{
+		"Add": func(a, b scalarSample) bool {
+			expected := new(saferith.Nat).ModAdd(a.nat(), b.nat(), q)
+			return NewScalar().Set(a.Scalar).Add(b.Scalar).(*Scalar).nat().Eq(expected) == 1
+		},
+		"Sub": func(a, b scalarSample) bool {
+			expected := new(saferith.Nat).ModSub(a.nat(), b.nat(), q)
+			return NewScalar().Set(a.Scalar).Sub(b.Scalar).(*Scalar).nat().Eq(expected) == 1
+		},
+		"Negate": func(a, _ scalarSample) bool {
+			expected := new(saferith.Nat).ModNeg(a.nat(), q)
+			return NewScalar().Set(a.Scalar).Negate().(*Scalar).nat().Eq(expected) == 1
+		},
+		"Mul": func(a, b scalarSample) bool {
+			expected := new(saferith.Nat).ModMul(a.nat(), b.nat(), q)
+			return NewScalar().Set(a.Scalar).Mul(b.Scalar).(*Scalar).nat().Eq(expected) == 1
+		},
+		"Invert": func(a, _ scalarSample) bool {
+			expected := new(saferith.Nat).ModInverse(a.nat(), q)
+			return NewScalar().Set(a.Scalar).Invert().(*Scalar).nat().Eq(expected) == 1
+		},
+		"SetNat": func(a, b scalarSample) bool {
+			// We make a 512 bit number, to check the reduction.
+			wide := new(saferith.Nat).Lsh(a.nat(), 256, 512)
+			wide.Add(wide, b.nat(), 512)
+			expected := new(saferith.Nat).Mod(wide, q)
+			return NewScalar().SetNat(wide).(*Scalar).nat().Eq(expected) == 1
+		},
+		"IsHigh": func(a, _ scalarSample) bool {
+			gt, _, _ := a.nat().Cmp(new(saferith.Nat).Rsh(q.Nat(), 1, 256))
+			return a.isHigh() == gt
+		},
+		"UnmarshalBinary": func(a, _ scalarSample) bool {
+			data, _ := a.MarshalBinary()
+			decoded := NewScalar()
+			if decoded.UnmarshalBinary(data) != nil {
+				return false
+			}
+			return decoded.Equal(a.Scalar)
+		},
+	}
+	for name, f := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestScalarReductionEdgeCases(t *testing.T) {
+	one := NewScalar().SetNat(new(saferith.Nat).SetUint64(1)).(*Scalar)
+	minusOne := NewScalar().Set(one).Negate().(*Scalar)
+	if !NewScalar().Set(minusOne).Add(one).IsZero() {
+		t.Error("(q - 1) + 1 should be 0")
+	}
+	if !NewScalar().Set(minusOne).Mul(minusOne).Equal(one) {
+		t.Error("(q - 1)^2 should be 1")
+	}
+	if !NewScalar().Set(minusOne).Invert().Equal(minusOne) {
+		t.Error("(q - 1)^-1 should be q - 1")
+	}
+	if !NewScalar().Invert().IsZero() {
+		t.Error("0^-1 should be 0")
+	}
+	qBytes := q.Nat().FillBytes(make([]byte, FieldBytes))
+	if NewScalar().UnmarshalBinary(qBytes) == nil {
+		t.Error("q should be rejected as a scalar")
+	}
+	minusOneBytes, _ := minusOne.MarshalBinary()
+	if NewScalar().UnmarshalBinary(minusOneBytes) != nil {
+		t.Error("q - 1 should be accepted as a scalar")
+	}
+}
+
+func BenchmarkScalarMul(b *testing.B) {
+	x := randomScalar(rand.New(rand.NewSource(0)), FieldBytes)
+	y := randomScalar(rand.New(rand.NewSource(1)), FieldBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Mul(y)
+	}
+}
+
+func BenchmarkScalarInvert(b *testing.B) {
+	x := randomScalar(rand.New(rand.NewSource(0)), FieldBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Invert()
+	}
+}
@@ -0,0 +1,142 @@
+// Package schnorr implements BIP-340 Schnorr signatures over secp256k1.
+//
+// Public keys are x-only: they consist of only the 32 byte x coordinate of a point,
+// with the y coordinate implicitly chosen to be even. Signatures are 64 bytes,
+// consisting of the x coordinate of the nonce commitment, followed by a scalar.
+package schnorr
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/cronokirby/kyokusen/secp256k1"
+	"github.com/cronokirby/saferith"
+)
+
+// PublicKeyBytes is the number of bytes in an x-only public key.
+const PublicKeyBytes = 32
+
+// SignatureBytes is the number of bytes in a signature.
+const SignatureBytes = 64
+
+// AuxRandBytes is the number of bytes of auxiliary randomness used when signing.
+const AuxRandBytes = 32
+
+// TaggedHash computes the hash of some data, prefixed with a tag, as defined in BIP-340.
+//
+// This is SHA256(SHA256(tag) || SHA256(tag) || data...).
+func TaggedHash(tag string, data ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// hashToScalar interprets a 32 byte hash as a scalar, reducing it modulo the order.
+func hashToScalar(hash []byte) *secp256k1.Scalar {
+	return secp256k1.NewScalar().SetNat(new(saferith.Nat).SetBytes(hash)).(*secp256k1.Scalar)
+}
+
+// PublicKey calculates the x-only public key associated with a secret key.
+func PublicKey(secret *secp256k1.Scalar) ([]byte, error) {
+	if secret.IsZero() {
+		return nil, errors.New("schnorr.PublicKey: secret key is zero")
+	}
+	return secret.ActOnBase().(*secp256k1.Point).MarshalXOnly()
+}
+
+// Sign creates a signature of a message using a secret key.
+//
+// The auxiliary randomness should be 32 fresh random bytes, although signing
+// is still secure, albeit deterministic, if these bytes are fixed.
+func Sign(secret *secp256k1.Scalar, msg []byte, auxRand []byte) ([]byte, error) {
+	if len(auxRand) != AuxRandBytes {
+		return nil, errors.New("schnorr.Sign: invalid auxiliary randomness length")
+	}
+	if secret.IsZero() {
+		return nil, errors.New("schnorr.Sign: secret key is zero")
+	}
+	P := secret.ActOnBase().(*secp256k1.Point)
+	d := secp256k1.NewScalar().Set(secret)
+	if !P.HasEvenY() {
+		d.Negate()
+	}
+	pBytes, err := P.MarshalXOnly()
+	if err != nil {
+		return nil, err
+	}
+
+	dBytes, _ := d.MarshalBinary()
+	t := TaggedHash("BIP0340/aux", auxRand)
+	for i := range t {
+		t[i] ^= dBytes[i]
+	}
+	k := hashToScalar(TaggedHash("BIP0340/nonce", t, pBytes, msg))
+	if k.IsZero() {
+		return nil, errors.New("schnorr.Sign: nonce is zero")
+	}
+	R := k.ActOnBase().(*secp256k1.Point)
+	if !R.HasEvenY() {
+		k.Negate()
+	}
+	rBytes, err := R.MarshalXOnly()
+	if err != nil {
+		return nil, err
+	}
+
+	e := hashToScalar(TaggedHash("BIP0340/challenge", rBytes, pBytes, msg))
+	s := e.Mul(d).Add(k)
+	sBytes, _ := s.MarshalBinary()
+
+	sig := make([]byte, 0, SignatureBytes)
+	sig = append(sig, rBytes...)
+	sig = append(sig, sBytes...)
+	// Verifying the signature protects against faults leaking the secret key.
+	if err := Verify(pBytes, msg, sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// Verify checks that a signature of a message is valid under an x-only public key.
+//
+// This returns nil if the signature is valid, and an error otherwise.
+func Verify(public []byte, msg []byte, sig []byte) error {
+	if len(public) != PublicKeyBytes {
+		return errors.New("schnorr.Verify: invalid public key length")
+	}
+	if len(sig) != SignatureBytes {
+		return errors.New("schnorr.Verify: invalid signature length")
+	}
+	P := secp256k1.NewPoint()
+	if err := P.UnmarshalXOnly(public); err != nil {
+		return errors.New("schnorr.Verify: invalid public key")
+	}
+	// We only need to check that r is a valid field element here.
+	if err := secp256k1.NewField().UnmarshalBinary(sig[:32]); err != nil {
+		return errors.New("schnorr.Verify: invalid signature")
+	}
+	s := secp256k1.NewScalar()
+	if err := s.UnmarshalBinary(sig[32:]); err != nil {
+		return errors.New("schnorr.Verify: invalid signature")
+	}
+	e := hashToScalar(TaggedHash("BIP0340/challenge", sig[:32], public, msg))
+	// Every input here is public, so we can use variable-time operations.
+	R := s.VartimeDoubleBaseMul(e.Negate(), P).(*secp256k1.Point)
+	if !R.HasEvenY() {
+		return errors.New("schnorr.Verify: invalid signature")
+	}
+	rBytes, err := R.MarshalXOnly()
+	if err != nil {
+		return errors.New("schnorr.Verify: invalid signature")
+	}
+	if subtle.ConstantTimeCompare(rBytes, sig[:32]) != 1 {
+		return errors.New("schnorr.Verify: invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package schnorr
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/cronokirby/kyokusen/secp256k1"
+)
+
+func TestBIP340Vectors(t *testing.T) {
+	file, err := os.Open("testdata/bip340-vectors.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first record is the header.
+	for _, record := range records[1:] {
+		index := record[0]
+		secretHex, publicHex, auxHex, msgHex, sigHex := record[1], record[2], record[3], record[4], record[5]
+		expected := record[6] == "TRUE"
+		public, _ := hex.DecodeString(publicHex)
+		msg, _ := hex.DecodeString(msgHex)
+		sig, _ := hex.DecodeString(sigHex)
+
+		if secretHex != "" {
+			secretBytes, _ := hex.DecodeString(secretHex)
+			auxRand, _ := hex.DecodeString(auxHex)
+			secret := secp256k1.NewScalar()
+			if err := secret.UnmarshalBinary(secretBytes); err != nil {
+				t.Fatalf("vector %s: %v", index, err)
+			}
+			actualPublic, err := PublicKey(secret)
+			if err != nil {
+				t.Fatalf("vector %s: %v", index, err)
+			}
+			if !bytes.Equal(actualPublic, public) {
+				t.Errorf("vector %s: incorrect public key", index)
+			}
+			actualSig, err := Sign(secret, msg, auxRand)
+			if err != nil {
+				t.Errorf("vector %s: %v", index, err)
+			} else if !bytes.Equal(actualSig, sig) {
+				t.Errorf("vector %s: incorrect signature", index)
+			}
+		}
+
+		err := Verify(public, msg, sig)
+		if expected && err != nil {
+			t.Errorf("vector %s: %v", index, err)
+		}
+		if !expected && err == nil {
+			t.Errorf("vector %s (%s): invalid signature verified", index, record[7])
+		}
+	}
+}
+
+func TestTaggedHashConcatenates(t *testing.T) {
+	way1 := TaggedHash("tag", []byte("hello"), []byte("world"))
+	way2 := TaggedHash("tag", []byte("helloworld"))
+	if !bytes.Equal(way1, way2) {
+		t.Error("tagged hash should only depend on the concatenated data")
+	}
+	if bytes.Equal(way1, TaggedHash("other tag", []byte("helloworld"))) {
+		t.Error("tagged hash should depend on the tag")
+	}
+}
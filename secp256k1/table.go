@@ -0,0 +1,83 @@
+package secp256k1
+
+import (
+	"crypto/subtle"
+	"sync"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/saferith"
+)
+
+// tableWindow is the number of scalar bits handled by each row of a Table.
+const tableWindow = 4
+
+// tableRows is the number of rows in a Table, enough to cover a full scalar.
+const tableRows = (8*FieldBytes + tableWindow - 1) / tableWindow
+
+// Table holds precomputed multiples of a fixed point, speeding up scalar multiplication.
+//
+// Building a table is expensive, but then multiplying the point by a scalar only
+// requires one addition per 4 bits of the scalar, with no doublings. This is
+// worth it for points used many times, like the generator, or a long-lived
+// second generator in some protocol.
+type Table struct {
+	// rows[i][j] = j * 16^i * P, for each j in 0..15.
+	rows [tableRows][1 << tableWindow]*Point
+}
+
+// NewTable precomputes a table of multiples of a point.
+func NewTable(p *Point) *Table {
+	t := new(Table)
+	// The point we're taking multiples of in the current row.
+	var rowBase kyokusen.Point = p
+	for i := range t.rows {
+		t.rows[i][0] = NewPoint()
+		for j := 1; j < len(t.rows[i]); j++ {
+			t.rows[i][j] = t.rows[i][j-1].Add(rowBase).(*Point)
+		}
+		rowBase = t.rows[i][len(t.rows[i])-1].Add(rowBase)
+	}
+	return t
+}
+
+// nibble returns the i-th group of 4 bits in a Big Endian number, starting from the least significant bits.
+func nibble(bytes []byte, i int) int32 {
+	b := bytes[len(bytes)-1-i/2]
+	return int32((b >> (4 * (i % 2))) & 0xF)
+}
+
+// selectPoint sets out <- entries[d], in constant-time.
+//
+// Every entry gets read, regardless of the value of d.
+func selectPoint(out *Point, entries []*Point, d int32) {
+	for j, entry := range entries {
+		out.CondAssign(saferith.Choice(subtle.ConstantTimeEq(d, int32(j))), entry)
+	}
+}
+
+// Act calculates s * P, where P is the point used to build this table.
+//
+// This runs in constant-time, with every entry of the table being read, regardless
+// of the value of the scalar.
+func (t *Table) Act(s kyokusen.Scalar) kyokusen.Point {
+	bytes := castScalar(s).bytes()
+	acc := NewPoint()
+	selected := NewPoint()
+	for i := range t.rows {
+		selectPoint(selected, t.rows[i][:], nibble(bytes, i))
+		acc = acc.Add(selected).(*Point)
+	}
+	return acc
+}
+
+var baseTableOnce sync.Once
+
+// baseTable is the precomputed table for the generator, built on first use.
+var baseTable *Table
+
+func getBaseTable() *Table {
+	baseTableOnce.Do(func() {
+		baseTable = NewTable(basePoint)
+	})
+	return baseTable
+}
@@ -0,0 +1,31 @@
+package secp256k1
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestActOnBaseMatchesAct(t *testing.T) {
+	err := quick.Check(func(s *Scalar) bool {
+		return s.ActOnBase().Equal(s.Act(basePoint))
+	}, &quick.Config{})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTableActMatchesAct(t *testing.T) {
+	err := quick.Check(func(p *Point, s1, s2 *Scalar) bool {
+		table := NewTable(p)
+		return table.Act(s1).Equal(s1.Act(p)) && table.Act(s2).Equal(s2.Act(p))
+	}, &quick.Config{MaxCount: 5})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTableActZeroIsIdentity(t *testing.T) {
+	if !NewScalar().ActOnBase().IsIdentity() {
+		t.Error("0 * G should be the identity")
+	}
+}
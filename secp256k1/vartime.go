@@ -0,0 +1,95 @@
+package secp256k1
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/wnaf"
+)
+
+// The functions in this file run in variable-time, and must NEVER be used with secret scalars.
+
+// vartimeWindow is the width of the NAF used for arbitrary points.
+const vartimeWindow = 5
+
+// vartimeBaseWindow is the width of the NAF used for the base point, which has a larger precomputed table.
+const vartimeBaseWindow = 8
+
+// oddMultiples returns P, 3P, 5P, ..., (2^(w - 1) - 1)P.
+func oddMultiples(p *Point, w uint) []*Point {
+	out := make([]*Point, 1<<(w-2))
+	out[0] = p
+	double := p.Add(p)
+	for i := 1; i < len(out); i++ {
+		out[i] = out[i-1].Add(double).(*Point)
+	}
+	return out
+}
+
+// endomorphisms applies the GLV endomorphism to each point in a table.
+func endomorphisms(table []*Point) []*Point {
+	out := make([]*Point, len(table))
+	for i, p := range table {
+		out[i] = p.endomorphism()
+	}
+	return out
+}
+
+// wnafGroup implements the operations wnaf.Interleave needs, returning new points.
+var wnafGroup = &wnaf.Group[*Point]{
+	Identity: NewPoint,
+	Double: func(a *Point) *Point {
+		return a.Add(a).(*Point)
+	},
+	Add: func(a, b *Point) *Point {
+		return a.Add(b).(*Point)
+	},
+	Sub: func(a, b *Point) *Point {
+		return a.Sub(b).(*Point)
+	},
+}
+
+// newTerms splits a scalar using GLV, and returns the two terms needed to multiply a point by it.
+func newTerms(s *Scalar, table []*Point, endoTable []*Point, w uint) [2]wnaf.Term[*Point] {
+	k1, neg1, k2, neg2 := splitScalar(s)
+	return [2]wnaf.Term[*Point]{
+		{Digits: wnaf.Recode(new(big.Int).SetBytes(k1.bytes()), w), Table: table, Negate: neg1 == 1},
+		{Digits: wnaf.Recode(new(big.Int).SetBytes(k2.bytes()), w), Table: endoTable, Negate: neg2 == 1},
+	}
+}
+
+var vartimeBaseTablesOnce sync.Once
+
+// vartimeBaseTable and vartimeBaseEndoTable hold odd multiples of G and lambda * G.
+var vartimeBaseTable, vartimeBaseEndoTable []*Point
+
+func getVartimeBaseTables() ([]*Point, []*Point) {
+	vartimeBaseTablesOnce.Do(func() {
+		vartimeBaseTable = oddMultiples(basePoint, vartimeBaseWindow)
+		vartimeBaseEndoTable = endomorphisms(vartimeBaseTable)
+	})
+	return vartimeBaseTable, vartimeBaseEndoTable
+}
+
+// VartimeAct calculates s * P, in variable-time, using a width 5 NAF, and the GLV endomorphism.
+//
+// The time this takes depends on the value of s, so this must NEVER be used
+// with a secret scalar.
+func (s *Scalar) VartimeAct(other kyokusen.Point) kyokusen.Point {
+	table := oddMultiples(castPoint(other), vartimeWindow)
+	terms := newTerms(s, table, endomorphisms(table), vartimeWindow)
+	return wnaf.Interleave(wnafGroup, terms[:])
+}
+
+// VartimeDoubleBaseMul calculates s * G + b * P, in variable-time, interleaving the NAFs of both scalars.
+//
+// The time this takes depends on the value of the scalars, so this must NEVER
+// be used with secret scalars.
+func (s *Scalar) VartimeDoubleBaseMul(b kyokusen.Scalar, P kyokusen.Point) kyokusen.Point {
+	baseTable, baseEndoTable := getVartimeBaseTables()
+	table := oddMultiples(castPoint(P), vartimeWindow)
+	baseTerms := newTerms(s, baseTable, baseEndoTable, vartimeBaseWindow)
+	terms := newTerms(castScalar(b), table, endomorphisms(table), vartimeWindow)
+	return wnaf.Interleave(wnafGroup, []wnaf.Term[*Point]{baseTerms[0], baseTerms[1], terms[0], terms[1]})
+}
@@ -0,0 +1,63 @@
+package secp256k1
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestVartimeActMatchesAct(t *testing.T) {
+	err := quick.Check(func(s *Scalar, p *Point) bool {
+		return s.VartimeAct(p).Equal(s.Act(p))
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVartimeDoubleBaseMulMatchesAct(t *testing.T) {
+	err := quick.Check(func(a, b *Scalar, p *Point) bool {
+		expected := a.ActOnBase().Add(b.Act(p))
+		return a.VartimeDoubleBaseMul(b, p).Equal(expected)
+	}, &quick.Config{MaxCount: 20})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVartimeEdgeCases(t *testing.T) {
+	zero := NewScalar()
+	if !zero.VartimeAct(basePoint).IsIdentity() {
+		t.Error("0 * G should be the identity")
+	}
+	if !zero.VartimeDoubleBaseMul(zero, basePoint).IsIdentity() {
+		t.Error("0 * G + 0 * G should be the identity")
+	}
+	minusOne := NewScalar().Set(scalarFromHex("01")).Negate().(*Scalar)
+	if !minusOne.VartimeDoubleBaseMul(scalarFromHex("01"), basePoint).IsIdentity() {
+		t.Error("-1 * G + 1 * G should be the identity")
+	}
+}
+
+func BenchmarkVartimeAct(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	s := randomScalar(r, 100)
+	p := randomPoint(r, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.VartimeAct(p)
+	}
+}
+
+func BenchmarkVartimeDoubleBaseMul(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	s1 := randomScalar(r, 100)
+	s2 := randomScalar(r, 100)
+	p := randomPoint(r, 100)
+	// This makes sure that the precomputed table isn't included in the timing.
+	s1.VartimeDoubleBaseMul(s2, p)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s1.VartimeDoubleBaseMul(s2, p)
+	}
+}
@@ -0,0 +1,41 @@
+package kyokusen
+
+// VartimeScalar is an optional interface for Scalars supporting faster variable-time operations.
+//
+// The time taken by these methods depends on the value of the scalars, so they
+// must NEVER be used with secret scalars. They're intended for things like
+// verifying signatures and proofs, where every scalar involved is public.
+type VartimeScalar interface {
+	Scalar
+	// VartimeAct acts on a Point with this Scalar, like Act, but in variable-time.
+	//
+	// This shouldn't mutate the Scalar, or the Point.
+	VartimeAct(Point) Point
+	// VartimeDoubleBaseMul calculates this * G + b * P, in variable-time, where G is the base point.
+	//
+	// This shouldn't mutate either Scalar, or the Point.
+	VartimeDoubleBaseMul(b Scalar, P Point) Point
+}
+
+// VartimeAct calculates s * P, in variable-time.
+//
+// This must NEVER be used with a secret scalar. If s implements VartimeScalar,
+// this uses the faster variable-time implementation, and otherwise falls back to Act.
+func VartimeAct(s Scalar, P Point) Point {
+	if v, ok := s.(VartimeScalar); ok {
+		return v.VartimeAct(P)
+	}
+	return s.Act(P)
+}
+
+// VartimeDoubleBaseMul calculates a * G + b * P, in variable-time, where G is the base point.
+//
+// This must NEVER be used with secret scalars. If a implements VartimeScalar,
+// this uses the faster variable-time implementation, and otherwise falls back
+// to ActOnBase and Act.
+func VartimeDoubleBaseMul(a, b Scalar, P Point) Point {
+	if v, ok := a.(VartimeScalar); ok {
+		return v.VartimeDoubleBaseMul(b, P)
+	}
+	return a.ActOnBase().Add(b.Act(P))
+}
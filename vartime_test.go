@@ -0,0 +1,33 @@
+package kyokusen_test
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/secp256k1"
+)
+
+// plainScalar hides the VartimeScalar methods of a secp256k1 scalar.
+type plainScalar struct {
+	kyokusen.Scalar
+}
+
+func TestVartimeDoubleBaseMulFallback(t *testing.T) {
+	curve := secp256k1.Curve{}
+	a := randomScalar(curve)
+	b := randomScalar(curve)
+	P := randomScalar(curve).ActOnBase()
+	expected := a.ActOnBase().Add(b.Act(P))
+	if !kyokusen.VartimeDoubleBaseMul(a, b, P).Equal(expected) {
+		t.Error("VartimeDoubleBaseMul doesn't match ActOnBase and Act")
+	}
+	if !kyokusen.VartimeDoubleBaseMul(plainScalar{a}, b, P).Equal(expected) {
+		t.Error("fallback for VartimeDoubleBaseMul doesn't match ActOnBase and Act")
+	}
+	if !kyokusen.VartimeAct(a, P).Equal(a.Act(P)) {
+		t.Error("VartimeAct doesn't match Act")
+	}
+	if !kyokusen.VartimeAct(plainScalar{a}, P).Equal(a.Act(P)) {
+		t.Error("fallback for VartimeAct doesn't match Act")
+	}
+}
@@ -0,0 +1,128 @@
+// Package vesta implements the Vesta curve, y^2 = x^3 + 5, which has prime order.
+//
+// Vesta forms a cycle with the Pallas curve: the base field of Vesta is the
+// scalar field of Pallas, and the scalar field of Vesta is the base field of Pallas.
+// Scalar.SetCycleField and Scalar.CycleField convert between the two sides of this cycle.
+//
+// HashToCurve and EncodeToCurve implement the vesta_XMD:SHA-256_SSWU_RO_ and
+// vesta_XMD:SHA-256_SSWU_NU_ suites, following RFC 9380. These use the same
+// isogeny as the Zcash implementation, pasta_curves, but aren't interoperable
+// with it: pasta_curves expands messages with BLAKE2b, and formats the domain
+// separation tag differently, so the two produce different points.
+//
+// The arithmetic is shared with Pallas, in internal/pasta.
+package vesta
+
+import (
+	"github.com/cronokirby/kyokusen"
+	"github.com/cronokirby/kyokusen/internal/pasta"
+	"github.com/cronokirby/saferith"
+)
+
+// PointBytes is the size of the encoding of a point.
+const PointBytes = pasta.FieldBytes
+
+// ScalarBytes is the number of bytes in the encoding of a scalar.
+const ScalarBytes = pasta.FieldBytes
+
+var curve = pasta.NewVesta(Curve{})
+
+// Field represents an element of the base field of Vesta.
+//
+// This is the same type as the scalar field of Pallas, before it's wrapped to
+// implement kyokusen.Scalar.
+type Field = pasta.Fq
+
+// Point represents a point on the Vesta curve.
+type Point = pasta.VestaPoint
+
+// Scalar represents an integer modulo the order of the Vesta group.
+type Scalar = pasta.VestaScalar
+
+// NewField creates a new field element, with its value set to 0.
+func NewField() *Field {
+	return curve.NewField()
+}
+
+// NewPoint returns the Vesta identity point.
+func NewPoint() *Point {
+	return curve.NewPoint()
+}
+
+// NewScalar returns a new scalar, with its value set to 0.
+func NewScalar() *Scalar {
+	return curve.NewScalar()
+}
+
+// HashToCurve hashes a message to a point of Vesta, using a domain separation tag.
+//
+// This implements the vesta_XMD:SHA-256_SSWU_RO_ suite, following RFC 9380.
+// This isn't interoperable with the hash to curve function used by Zcash.
+func HashToCurve(msg, dst []byte) (*Point, error) {
+	return curve.HashToCurve(msg, dst)
+}
+
+// EncodeToCurve encodes a message as a point of Vesta, using a domain separation tag.
+//
+// This implements the vesta_XMD:SHA-256_SSWU_NU_ suite, following RFC 9380.
+// This isn't interoperable with the hash to curve function used by Zcash.
+func EncodeToCurve(msg, dst []byte) (*Point, error) {
+	return curve.EncodeToCurve(msg, dst)
+}
+
+// Curve represents the Vesta curve, implementing the kyokusen.Curve interface.
+type Curve struct{}
+
+func (Curve) NewPoint() kyokusen.Point {
+	return curve.NewPoint()
+}
+
+func (Curve) NewBasePoint() kyokusen.Point {
+	return curve.NewBasePoint()
+}
+
+func (Curve) NewScalar() kyokusen.Scalar {
+	return curve.NewScalar()
+}
+
+func (Curve) Name() string {
+	return curve.Name()
+}
+
+func (Curve) ScalarBits() int {
+	return curve.ScalarBits()
+}
+
+func (Curve) SafeScalarBytes() int {
+	return curve.SafeScalarBytes()
+}
+
+func (Curve) Order() *saferith.Modulus {
+	return curve.Order()
+}
+
+// HashToCurve hashes a message to a point, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using the
+// vesta_XMD:SHA-256_SSWU_RO_ suite.
+func (Curve) HashToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	// We can't return the result directly, since a nil *Point isn't a nil kyokusen.Point.
+	P, err := curve.HashToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return P, nil
+}
+
+// EncodeToCurve encodes a message as a point, using a domain separation tag.
+//
+// This implements the kyokusen.HashableCurve interface, using the
+// vesta_XMD:SHA-256_SSWU_NU_ suite.
+func (Curve) EncodeToCurve(msg, dst []byte) (kyokusen.Point, error) {
+	// We can't return the result directly, since a nil *Point isn't a nil kyokusen.Point.
+	P, err := curve.EncodeToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return P, nil
+}
@@ -0,0 +1,16 @@
+//go:build dudect
+
+package vesta
+
+import (
+	"testing"
+
+	"github.com/cronokirby/kyokusen/internal/dudect"
+)
+
+// These check that operations on secret values don't leak timing information:
+//
+//	go test -tags dudect -run ConstantTime -v ./vesta
+func TestConstantTime(t *testing.T) {
+	dudect.TestCurve(t, Curve{})
+}